@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"phantom_mask/internal/utils"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+
+	transactionDateLayout = "2006-01-02 15:04:05"
+)
+
+// Target is where and how the dump is written. The json format writes pharmacies.json and users.json as the
+// importer reads them, ndjson writes the same records one per line to pharmacies.ndjson and users.ndjson, and
+// csv writes pharmacies.csv, opening_hours.csv, products.csv, users.csv and purchases.csv with the columns of
+// the importer CSV source.
+type Target struct {
+	Format string
+	Dir    string
+}
+
+// NewTarget reads the target from the command line flags.
+func NewTarget() Target {
+	return Target{
+		Format: *outputFormat,
+		Dir:    *outputDir,
+	}
+}
+
+// envOr returns the environment variable key, or fallback when it is unset.
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+func NewExportData(c context.Context, logger *zap.Logger, target Target, export storage.IExport) *ExportData {
+	return &ExportData{
+		ctx:    c,
+		logger: logger,
+		target: target,
+		export: export,
+	}
+}
+
+// ExportData dumps a snapshot of the database into the importer input shapes, so importing the dump into another
+// environment or backend recreates the same pharmacies, opening hours, products, users and purchase histories.
+type ExportData struct {
+	ctx    context.Context
+	logger *zap.Logger
+	target Target
+	export storage.IExport
+}
+
+func (e ExportData) Init() error {
+	start := time.Now()
+	snapshot, err := e.export.Snapshot(e.ctx)
+	if err != nil {
+		return err
+	}
+	users, pharmacies, err := buildData(snapshot)
+	if err != nil {
+		return err
+	}
+	files, err := e.target.Write(users, pharmacies)
+	if err != nil {
+		return err
+	}
+	e.logger.Info("export done",
+		zap.String("format", e.target.Format),
+		zap.Strings("files", files),
+		zap.Int("pharmacies", len(pharmacies)),
+		zap.Int("users", len(users)),
+		zap.Duration("elapsed", time.Since(start)),
+	)
+	return nil
+}
+
+// buildData converts the snapshot rows into the importer input, ordered by name and purchase histories by date.
+// Opening hours are rendered with utils.FormatTimeFormat.
+func buildData(snapshot *entity.Snapshot) ([]entity.UserJSON, []entity.PharmacyJSON, error) {
+	pharmacyNames := map[string]string{}
+	for _, phy := range snapshot.Pharmacies {
+		pharmacyNames[string(phy.UID)] = phy.Name
+	}
+	schemas := map[string][]utils.DaySchema{}
+	for _, info := range snapshot.PharmacyInfos {
+		schemas[string(info.UID)] = append(schemas[string(info.UID)], utils.DaySchema{
+			Day:       info.Day,
+			OpenHour:  info.OpenHour,
+			CloseHour: info.CloseHour,
+		})
+	}
+	masks := map[string][]entity.MaskJSON{}
+	productNames := map[string]string{}
+	for _, product := range snapshot.Products {
+		masks[string(product.UID)] = append(masks[string(product.UID)], entity.MaskJSON{
+			Name:  product.Name,
+			Price: product.Price,
+		})
+		productNames[string(product.UID)+string(product.ProductID)] = product.Name
+	}
+
+	pharmacies := make([]entity.PharmacyJSON, 0, len(snapshot.Pharmacies))
+	for _, phy := range snapshot.Pharmacies {
+		days := groupByHours(schemas[string(phy.UID)])
+		sold := masks[string(phy.UID)]
+		sort.Slice(sold, func(i, j int) bool { return sold[i].Name < sold[j].Name })
+		if sold == nil {
+			sold = []entity.MaskJSON{}
+		}
+		pharmacies = append(pharmacies, entity.PharmacyJSON{
+			Name:         phy.Name,
+			CashBalance:  phy.CashBalance,
+			OpeningHours: utils.FormatTimeFormat(days),
+			Masks:        sold,
+		})
+	}
+	sort.Slice(pharmacies, func(i, j int) bool { return pharmacies[i].Name < pharmacies[j].Name })
+
+	histories := map[string][]*entity.PurchaseHistory{}
+	for _, history := range snapshot.PurchaseHistories {
+		histories[string(history.UID)] = append(histories[string(history.UID)], history)
+	}
+	users := make([]entity.UserJSON, 0, len(snapshot.Users))
+	for _, us := range snapshot.Users {
+		rows := histories[string(us.UID)]
+		sort.Slice(rows, func(i, j int) bool { return rows[i].TransactionDate.Before(rows[j].TransactionDate) })
+		purchases := make([]entity.PurchaseHistoryJSON, 0, len(rows))
+		for _, history := range rows {
+			maskName, ok := productNames[string(history.PharmacyUID)+string(history.ProductID)]
+			if !ok {
+				return nil, nil, fmt.Errorf("purchase history of user %q at %s names an unknown product", us.Name, history.TransactionDate)
+			}
+			purchases = append(purchases, entity.PurchaseHistoryJSON{
+				PharmacyName:      pharmacyNames[string(history.PharmacyUID)],
+				MaskName:          maskName,
+				TransactionAmount: history.TransactionAmount,
+				TransactionDate:   history.TransactionDate.UTC().Format(transactionDateLayout),
+			})
+		}
+		users = append(users, entity.UserJSON{
+			Name:              us.Name,
+			CashBalance:       us.CashBalance,
+			PurchaseHistories: purchases,
+		})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, pharmacies, nil
+}
+
+// groupByHours orders days by weekday, then moves the days sharing the hours of an earlier day right after it,
+// so utils.FormatTimeFormat renders one segment per distinct hours.
+func groupByHours(days []utils.DaySchema) []utils.DaySchema {
+	sort.Slice(days, func(i, j int) bool { return days[i].Day < days[j].Day })
+	grouped := make([]utils.DaySchema, 0, len(days))
+	used := make([]bool, len(days))
+	for i := range days {
+		for j := i; j < len(days); j++ {
+			if !used[j] && days[j].OpenHour == days[i].OpenHour && days[j].CloseHour == days[i].CloseHour {
+				grouped = append(grouped, days[j])
+				used[j] = true
+			}
+		}
+	}
+	return grouped
+}
+
+// Write creates the target directory and writes the files of the format into it, returning their paths.
+func (t Target) Write(users []entity.UserJSON, pharmacies []entity.PharmacyJSON) ([]string, error) {
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	var files []string
+	write := func(name string, fn func(w io.Writer) error) error {
+		path := filepath.Join(t.Dir, name)
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := fn(file); err != nil {
+			file.Close()
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		files = append(files, path)
+		return file.Close()
+	}
+
+	switch t.Format {
+	case FormatJSON:
+		if err := write("pharmacies.json", func(w io.Writer) error { return writeJSON(w, pharmacies) }); err != nil {
+			return nil, err
+		}
+		return files, write("users.json", func(w io.Writer) error { return writeJSON(w, users) })
+	case FormatNDJSON:
+		if err := write("pharmacies.ndjson", func(w io.Writer) error { return writeNDJSON(w, pharmacies) }); err != nil {
+			return nil, err
+		}
+		return files, write("users.ndjson", func(w io.Writer) error { return writeNDJSON(w, users) })
+	case FormatCSV:
+		for _, table := range csvTables(users, pharmacies) {
+			table := table
+			if err := write(table.name, func(w io.Writer) error { return writeCSV(w, table.rows) }); err != nil {
+				return nil, err
+			}
+		}
+		return files, nil
+	}
+	return nil, fmt.Errorf("unsupported output format %q", t.Format)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeNDJSON[T any](w io.Writer, items []T) error {
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+type csvTable struct {
+	name string
+	rows [][]string
+}
+
+// csvTables splits the dump into the tables of the importer CSV source, each starting with its header.
+func csvTables(users []entity.UserJSON, pharmacies []entity.PharmacyJSON) []csvTable {
+	float := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	tables := []csvTable{
+		{name: "pharmacies.csv", rows: [][]string{{"name", "cash_balance"}}},
+		{name: "opening_hours.csv", rows: [][]string{{"pharmacy_name", "opening_hours"}}},
+		{name: "products.csv", rows: [][]string{{"pharmacy_name", "mask_name", "price"}}},
+		{name: "users.csv", rows: [][]string{{"name", "cash_balance"}}},
+		{name: "purchases.csv", rows: [][]string{{"user_name", "pharmacy_name", "mask_name", "transaction_amount", "transaction_date"}}},
+	}
+	for _, phy := range pharmacies {
+		tables[0].rows = append(tables[0].rows, []string{phy.Name, float(phy.CashBalance)})
+		if phy.OpeningHours != "" {
+			tables[1].rows = append(tables[1].rows, []string{phy.Name, phy.OpeningHours})
+		}
+		for _, mask := range phy.Masks {
+			tables[2].rows = append(tables[2].rows, []string{phy.Name, mask.Name, float(mask.Price)})
+		}
+	}
+	for _, us := range users {
+		tables[3].rows = append(tables[3].rows, []string{us.Name, float(us.CashBalance)})
+		for _, usHis := range us.PurchaseHistories {
+			tables[4].rows = append(tables[4].rows, []string{
+				us.Name, usHis.PharmacyName, usHis.MaskName, float(usHis.TransactionAmount), usHis.TransactionDate,
+			})
+		}
+	}
+	return tables
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"phantom_mask/internal/entity"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ExporterSuite struct {
+	suite.Suite
+	snapshot *entity.Snapshot
+}
+
+func newID() []byte {
+	uid := uuid.New()
+	return uid[:]
+}
+
+func (suite *ExporterSuite) SetupTest() {
+	medlife, keystone, user := newID(), newID(), newID()
+	maskT, cottonKiss := newID(), newID()
+	suite.snapshot = &entity.Snapshot{
+		Pharmacies: []*entity.Pharmacy{
+			{UID: medlife, Name: "Medlife", CashBalance: 10.5},
+			{UID: keystone, Name: "Keystone", CashBalance: 20},
+		},
+		PharmacyInfos: []*entity.PharmacyInfo{
+			{UID: medlife, Day: 3, OpenHour: 8, CloseHour: 12},
+			{UID: medlife, Day: 1, OpenHour: 8, CloseHour: 12},
+			{UID: medlife, Day: 2, OpenHour: 14, CloseHour: 18},
+		},
+		Products: []*entity.Product{
+			{UID: medlife, ProductID: maskT, Name: "MaskT (green) (10 per pack)", Price: 41.86},
+			{UID: medlife, ProductID: cottonKiss, Name: "Cotton Kiss (blue) (6 per pack)", Price: 5},
+		},
+		Users: []*entity.User{
+			{UID: user, Name: "Yvonne Guerrero", CashBalance: 191.83},
+		},
+		PurchaseHistories: []*entity.PurchaseHistory{
+			{UID: user, PharmacyUID: medlife, ProductID: maskT, TransactionAmount: 41.86, TransactionDate: time.Date(2021, 1, 5, 15, 18, 51, 0, time.UTC)},
+			{UID: user, PharmacyUID: medlife, ProductID: cottonKiss, TransactionAmount: 5, TransactionDate: time.Date(2021, 1, 4, 15, 18, 51, 0, time.UTC)},
+		},
+	}
+}
+
+func (suite *ExporterSuite) TestBuildData() {
+	users, pharmacies, err := buildData(suite.snapshot)
+	suite.NoError(err)
+	suite.Equal([]entity.PharmacyJSON{
+		{Name: "Keystone", CashBalance: 20, Masks: []entity.MaskJSON{}},
+		{Name: "Medlife", CashBalance: 10.5, OpeningHours: "Mon, Wed 08:00 - 12:00 / Tue 14:00 - 18:00", Masks: []entity.MaskJSON{
+			{Name: "Cotton Kiss (blue) (6 per pack)", Price: 5},
+			{Name: "MaskT (green) (10 per pack)", Price: 41.86},
+		}},
+	}, pharmacies)
+	suite.Equal([]entity.UserJSON{{Name: "Yvonne Guerrero", CashBalance: 191.83, PurchaseHistories: []entity.PurchaseHistoryJSON{
+		{PharmacyName: "Medlife", MaskName: "Cotton Kiss (blue) (6 per pack)", TransactionAmount: 5, TransactionDate: "2021-01-04 15:18:51"},
+		{PharmacyName: "Medlife", MaskName: "MaskT (green) (10 per pack)", TransactionAmount: 41.86, TransactionDate: "2021-01-05 15:18:51"},
+	}}}, users)
+}
+
+func (suite *ExporterSuite) TestBuildDataUnknownProduct() {
+	suite.snapshot.Products = suite.snapshot.Products[:1]
+	_, _, err := buildData(suite.snapshot)
+	suite.Error(err)
+}
+
+func (suite *ExporterSuite) TestWrite() {
+	users, pharmacies, err := buildData(suite.snapshot)
+	suite.NoError(err)
+
+	dir := suite.T().TempDir()
+	files, err := Target{Format: FormatJSON, Dir: dir}.Write(users, pharmacies)
+	suite.NoError(err)
+	suite.Equal([]string{filepath.Join(dir, "pharmacies.json"), filepath.Join(dir, "users.json")}, files)
+	content, err := os.ReadFile(files[1])
+	suite.NoError(err)
+	var decoded []entity.UserJSON
+	suite.NoError(json.Unmarshal(content, &decoded))
+	suite.Equal(users, decoded)
+
+	files, err = Target{Format: FormatNDJSON, Dir: dir}.Write(users, pharmacies)
+	suite.NoError(err)
+	content, err = os.ReadFile(files[0])
+	suite.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	suite.Len(lines, 2)
+	var decodedPharmacy entity.PharmacyJSON
+	suite.NoError(json.Unmarshal([]byte(lines[1]), &decodedPharmacy))
+	suite.Equal(pharmacies[1], decodedPharmacy)
+
+	files, err = Target{Format: FormatCSV, Dir: dir}.Write(users, pharmacies)
+	suite.NoError(err)
+	suite.Len(files, 5)
+	content, err = os.ReadFile(filepath.Join(dir, "purchases.csv"))
+	suite.NoError(err)
+	suite.Equal("user_name,pharmacy_name,mask_name,transaction_amount,transaction_date\n"+
+		"Yvonne Guerrero,Medlife,Cotton Kiss (blue) (6 per pack),5,2021-01-04 15:18:51\n"+
+		"Yvonne Guerrero,Medlife,MaskT (green) (10 per pack),41.86,2021-01-05 15:18:51\n", string(content))
+	content, err = os.ReadFile(filepath.Join(dir, "opening_hours.csv"))
+	suite.NoError(err)
+	suite.Equal("pharmacy_name,opening_hours\nMedlife,\"Mon, Wed 08:00 - 12:00 / Tue 14:00 - 18:00\"\n", string(content))
+
+	_, err = Target{Format: "xml", Dir: dir}.Write(users, pharmacies)
+	suite.Error(err)
+}
+
+func TestExporterSuite(t *testing.T) {
+	suite.Run(t, new(ExporterSuite))
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"github.com/justdomepaul/toolbox/errorhandler"
+)
+
+var (
+	system = "Export Data"
+)
+
+var (
+	outputFormat = flag.String("format", envOr("EXPORT_FORMAT", FormatJSON), "output format, json, ndjson or csv (env EXPORT_FORMAT)")
+	outputDir    = flag.String("out", envOr("EXPORT_DIR", "./export"), "directory the files are written to (env EXPORT_DIR)")
+)
+
+func main() {
+	flag.Parse()
+
+	defer errorhandler.PanicErrorHandler(system, "export data interrupt => \n")
+
+	_, cleanup, err := Runner()
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+}
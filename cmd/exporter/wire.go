@@ -0,0 +1,50 @@
+//go:build wireinject
+
+package main
+
+import (
+	"context"
+	"github.com/google/wire"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	zapTool "github.com/justdomepaul/toolbox/zap"
+	"go.uber.org/zap"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+)
+
+func ctx() context.Context {
+	return context.Background()
+}
+
+var ctxSet = wire.NewSet(ctx)
+
+var LoggerSet = wire.NewSet(zapTool.NewLogger)
+
+type Empty struct{}
+
+func Run(logger *zap.Logger, coreOptions config.Set, exportData *ExportData) (Empty, func(), error) {
+	if err := exportData.Init(); err != nil {
+		return Empty{}, nil, err
+	}
+	return Empty{}, func() {}, nil
+}
+
+func Runner() (Empty, func(), error) {
+	panic(wire.Build(wire.NewSet(
+		ctxSet,
+		wire.NewSet(
+			config.NewSet,
+			config.NewCore,
+			config.NewGRPC,
+			config.NewJWT,
+			config.NewServer,
+			config.NewSpanner,
+		),
+		LoggerSet,
+		spanner.NewExtendSpannerDatabase,
+		wire.NewSet(spannerDB.NewExport, wire.Bind(new(storage.IExport), new(*spannerDB.Export))),
+		wire.NewSet(NewTarget, NewExportData),
+		Run,
+	)))
+}
@@ -0,0 +1,472 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"phantom_mask/internal/utils"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ImportNamespace is the UUIDv5 namespace of the IDs the importer derives from natural keys.
+var ImportNamespace = uuid.MustParse("0c3f6f1e-2f0b-4c55-8a57-9b4f3d6e1a10")
+
+// importID derives a stable ID from the kind of record and its natural key, so every run writes the same rows.
+func importID(kind string, keys ...string) []byte {
+	uid := uuid.NewSHA1(ImportNamespace, []byte(kind+"\x00"+strings.Join(keys, "\x00")))
+	return uid[:]
+}
+
+func pharmacyID(name string) []byte {
+	return importID("pharmacy", name)
+}
+
+func productID(pharmacyName, maskName string) []byte {
+	return importID("product", pharmacyName, maskName)
+}
+
+// userID keys a user by name alone, the source files have no other identity for a user. Two users sharing a name
+// would be merged into one row, so dataValidator.user reports the second one as a duplicate before anything is
+// written; a delta naming an imported user updates that user.
+func userID(name string) []byte {
+	return importID("user", name)
+}
+
+// NewBatchOption reads the worker pool size from the --workers flag.
+func NewBatchOption() spannerDB.BatchOption {
+	return spannerDB.BatchOption{
+		Workers: *workers,
+	}
+}
+
+// ImportOption bounds the memory an import uses besides the reference index.
+type ImportOption struct {
+	// ChunkRows is about how many rows are buffered before they are written, at least 1
+	ChunkRows int
+	// QuarantineFile receives the broken purchase histories of a BrokenQuarantine source as NDJSON
+	QuarantineFile string
+}
+
+// NewImportOption reads the chunk size from the --chunk-rows flag.
+func NewImportOption() ImportOption {
+	return ImportOption{
+		ChunkRows:      *chunkRows,
+		QuarantineFile: *quarantineFile,
+	}
+}
+
+func NewImportData(c context.Context, logger *zap.Logger, source Source, option ImportOption, batch storage.IBatch) *ImportData {
+	if option.ChunkRows < 1 {
+		option.ChunkRows = 1
+	}
+	return &ImportData{
+		ctx:    c,
+		logger: logger,
+		source: source,
+		option: option,
+		batch:  batch,
+		out:    os.Stdout,
+	}
+}
+
+// ImportData streams the Source twice: first through the validator, refusing to write anything when it finds a
+// problem, then into chunks of about ChunkRows rows, so only the chunk and the reference index stay in memory.
+// Every record is upserted under an ID derived from its natural key, so running it again converges to the same
+// database state instead of duplicating data. Chunks are written in batches, parents before children, and only
+// rows differing from the stored ones are written.
+//
+// A delta Source adds to the existing rows: pharmacies with empty opening hours keep their stored ones, and
+// purchase histories may name products outside of the delta, which are looked up before anything is written.
+// Purchase histories naming a product neither in the input nor stored are handled as Source.OnBrokenReference
+// says: the import is refused, or they are skipped, or they are written to the quarantine file.
+type ImportData struct {
+	ctx    context.Context
+	logger *zap.Logger
+	source Source
+	option ImportOption
+	batch  storage.IBatch
+	// out receives the summary printed when the import is done
+	out io.Writer
+}
+
+// importRows holds every row of one import, grouped by table.
+type importRows struct {
+	pharmacies        []entity.Pharmacy
+	pharmacyIDs       [][]byte
+	pharmacyInfos     []entity.PharmacyInfo
+	masks             []entity.Mask
+	products          []entity.Product
+	users             []entity.User
+	purchaseHistories []entity.PurchaseHistory
+}
+
+func (r importRows) count() int {
+	return len(r.pharmacies) + len(r.pharmacyInfos) + len(r.masks) + len(r.products) + len(r.users) + len(r.purchaseHistories)
+}
+
+// buildRows converts validated input into rows, so it assumes every reference resolves. In a delta the opening
+// hours of pharmacies without any are left out.
+func buildRows(users []entity.UserJSON, pharmacies []entity.PharmacyJSON, delta bool) (importRows, error) {
+	rows := importRows{}
+	maskSeen := map[string]bool{}
+	for _, phy := range pharmacies {
+		phyUID := pharmacyID(phy.Name)
+		rows.pharmacies = append(rows.pharmacies, entity.Pharmacy{
+			UID:         phyUID,
+			Name:        phy.Name,
+			CashBalance: phy.CashBalance,
+		})
+
+		if !delta || phy.OpeningHours != "" {
+			rows.pharmacyIDs = append(rows.pharmacyIDs, phyUID)
+			openCloseHour, err := utils.ParseTimeFormat(phy.OpeningHours)
+			if err != nil {
+				return rows, err
+			}
+			for _, info := range openCloseHour {
+				rows.pharmacyInfos = append(rows.pharmacyInfos, entity.PharmacyInfo{
+					UID:       phyUID,
+					Day:       info.Day,
+					OpenHour:  info.OpenHour,
+					CloseHour: info.CloseHour,
+				})
+			}
+		}
+
+		for _, mask := range phy.Masks {
+			canonical := storage.NewCanonicalMask(mask.Name)
+			if !maskSeen[string(canonical.MaskID)] {
+				maskSeen[string(canonical.MaskID)] = true
+				rows.masks = append(rows.masks, canonical)
+			}
+			rows.products = append(rows.products, entity.Product{
+				UID:       phyUID,
+				ProductID: productID(phy.Name, mask.Name),
+				Name:      mask.Name,
+				Price:     mask.Price,
+				MaskID:    canonical.MaskID,
+			})
+		}
+	}
+
+	for _, us := range users {
+		usUID := userID(us.Name)
+		rows.users = append(rows.users, entity.User{
+			UID:         usUID,
+			Name:        us.Name,
+			CashBalance: us.CashBalance,
+		})
+		for _, usHis := range us.PurchaseHistories {
+			specifyTime, err := time.Parse(transactionDateLayout, usHis.TransactionDate)
+			if err != nil {
+				return rows, err
+			}
+			rows.purchaseHistories = append(rows.purchaseHistories, entity.PurchaseHistory{
+				UID:               usUID,
+				PharmacyUID:       pharmacyID(usHis.PharmacyName),
+				ProductID:         productID(usHis.PharmacyName, usHis.MaskName),
+				TransactionAmount: usHis.TransactionAmount,
+				TransactionDate:   specifyTime,
+			})
+		}
+	}
+	return rows, nil
+}
+
+// lookupRows is how many unresolved products resolveReferences looks up at once.
+const lookupRows = 10000
+
+// resolveReferences looks up the products a delta names outside of it, moving them to the products of the
+// index when they are stored and to its missing products otherwise.
+func (i ImportData) resolveReferences(index *refIndex) error {
+	lookups := make([]entity.Product, 0, lookupRows)
+	resolve := func() error {
+		exists, err := i.batch.ProductsExist(i.ctx, lookups)
+		if err != nil {
+			return err
+		}
+		for lookupIndex, lookup := range lookups {
+			if exists[lookupIndex] {
+				index.add(index.products, lookup.ProductID)
+			} else {
+				index.add(index.missing, lookup.ProductID)
+			}
+		}
+		lookups = lookups[:0]
+		return nil
+	}
+	for product, pharmacy := range index.unresolved {
+		product, pharmacy := product, pharmacy
+		lookups = append(lookups, entity.Product{UID: pharmacy[:], ProductID: product[:]})
+		if len(lookups) == lookupRows {
+			if err := resolve(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := resolve(); err != nil {
+		return err
+	}
+	index.unresolved = map[[16]byte][16]byte{}
+	return nil
+}
+
+// quarantineRecord is one line of the quarantine file.
+type quarantineRecord struct {
+	User            string                     `json:"user"`
+	PurchaseHistory entity.PurchaseHistoryJSON `json:"purchaseHistory"`
+	Reason          string                     `json:"reason"`
+}
+
+// stageSummary is the outcome of writing one table.
+type stageSummary struct {
+	name string
+	storage.BatchResult
+	skipped     int
+	quarantined int
+}
+
+// printSummary writes one line per stage and a total line to w.
+func printSummary(w io.Writer, stages []stageSummary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "table\tinserted\tupdated\tunchanged\tdeleted\tskipped\tquarantined\t")
+	line := func(stage stageSummary) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			stage.name, stage.Inserted, stage.Updated, stage.Unchanged, stage.Deleted, stage.skipped, stage.quarantined)
+	}
+	total := stageSummary{name: "total"}
+	for _, stage := range stages {
+		line(stage)
+		total.Add(stage.BatchResult)
+		total.skipped += stage.skipped
+		total.quarantined += stage.quarantined
+	}
+	line(total)
+	return tw.Flush()
+}
+
+// chunkWriter buffers streamed records and writes them once they hold about ChunkRows rows, keeping the
+// summary of every stage across chunks.
+type chunkWriter struct {
+	i          ImportData
+	index      *refIndex
+	masks      map[[16]byte]struct{}
+	pharmacies []entity.PharmacyJSON
+	users      []entity.UserJSON
+	buffered   int
+	stages     []stageSummary
+	rows       []int
+	elapsed    []time.Duration
+	// quarantine receives the broken purchase histories, they are only logged when it is nil
+	quarantine *json.Encoder
+}
+
+func newChunkWriter(i ImportData, index *refIndex) *chunkWriter {
+	names := []string{"pharmacies", "users", "masks", "pharmacy infos", "products", "purchase histories"}
+	w := &chunkWriter{
+		i:       i,
+		index:   index,
+		masks:   map[[16]byte]struct{}{},
+		rows:    make([]int, len(names)),
+		elapsed: make([]time.Duration, len(names)),
+	}
+	for _, name := range names {
+		w.stages = append(w.stages, stageSummary{name: name})
+	}
+	return w
+}
+
+func (w *chunkWriter) addPharmacy(_ int, phy entity.PharmacyJSON) error {
+	w.pharmacies = append(w.pharmacies, phy)
+	w.buffered += 1 + len(phy.Masks)
+	return w.flushFull()
+}
+
+func (w *chunkWriter) addUser(_ int, us entity.UserJSON) error {
+	w.users = append(w.users, us)
+	w.buffered += 1 + len(us.PurchaseHistories)
+	return w.flushFull()
+}
+
+// dropBroken leaves out the purchase histories naming a missing product of the index, logging them as skipped or
+// writing them to the quarantine file.
+func (w *chunkWriter) dropBroken(users []entity.UserJSON) ([]entity.UserJSON, error) {
+	if len(w.index.missing) == 0 {
+		return users, nil
+	}
+	resolved := make([]entity.UserJSON, 0, len(users))
+	for _, us := range users {
+		histories := make([]entity.PurchaseHistoryJSON, 0, len(us.PurchaseHistories))
+		for _, usHis := range us.PurchaseHistories {
+			if !w.index.has(w.index.missing, productID(usHis.PharmacyName, usHis.MaskName)) {
+				histories = append(histories, usHis)
+				continue
+			}
+			_, reason := w.index.brokenReference(usHis, w.i.source.Delta)
+			if w.quarantine != nil {
+				if err := w.quarantine.Encode(quarantineRecord{User: us.Name, PurchaseHistory: usHis, Reason: reason}); err != nil {
+					return nil, err
+				}
+				w.stages[5].quarantined++
+				continue
+			}
+			w.i.logger.Warn("purchase history skipped",
+				zap.String("user", us.Name),
+				zap.String("transaction_date", usHis.TransactionDate),
+				zap.String("reason", reason),
+			)
+			w.stages[5].skipped++
+		}
+		us.PurchaseHistories = histories
+		resolved = append(resolved, us)
+	}
+	return resolved, nil
+}
+
+func (w *chunkWriter) flushFull() error {
+	if w.buffered < w.i.option.ChunkRows {
+		return nil
+	}
+	return w.flush()
+}
+
+// flush writes the buffered records in stage order, masks already written by an earlier chunk are left out.
+func (w *chunkWriter) flush() error {
+	if len(w.pharmacies) == 0 && len(w.users) == 0 {
+		return nil
+	}
+	users, err := w.dropBroken(w.users)
+	if err != nil {
+		return err
+	}
+	rows, err := buildRows(users, w.pharmacies, w.i.source.Delta)
+	if err != nil {
+		return err
+	}
+	masks := make([]entity.Mask, 0, len(rows.masks))
+	for _, mask := range rows.masks {
+		if w.index.add(w.masks, mask.MaskID) {
+			masks = append(masks, mask)
+		}
+	}
+
+	ctx, batch := w.i.ctx, w.i.batch
+	writes := []struct {
+		rows  int
+		write func() (storage.BatchResult, error)
+	}{
+		{len(rows.pharmacies), func() (storage.BatchResult, error) { return batch.UpsertPharmacies(ctx, rows.pharmacies) }},
+		{len(rows.users), func() (storage.BatchResult, error) { return batch.UpsertUsers(ctx, rows.users) }},
+		{len(masks), func() (storage.BatchResult, error) { return batch.UpsertMasks(ctx, masks) }},
+		{len(rows.pharmacyInfos), func() (storage.BatchResult, error) {
+			return batch.ReplacePharmacyInfos(ctx, rows.pharmacyIDs, rows.pharmacyInfos)
+		}},
+		{len(rows.products), func() (storage.BatchResult, error) { return batch.UpsertProducts(ctx, rows.products) }},
+		{len(rows.purchaseHistories), func() (storage.BatchResult, error) {
+			return batch.UpsertPurchaseHistories(ctx, rows.purchaseHistories)
+		}},
+	}
+	for index, write := range writes {
+		start := time.Now()
+		result, err := write.write()
+		if err != nil {
+			return err
+		}
+		w.elapsed[index] += time.Since(start)
+		w.rows[index] += write.rows
+		w.stages[index].Add(result)
+	}
+	w.i.logger.Info("import chunk written",
+		zap.Int("pharmacies", len(w.pharmacies)),
+		zap.Int("users", len(w.users)),
+		zap.Int("rows", rows.count()),
+	)
+	w.pharmacies, w.users, w.buffered = nil, nil, 0
+	return nil
+}
+
+func (i ImportData) Init() error {
+	source, cleanup, err := i.source.Spool()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	index, problems := source.Validate()
+	if len(problems) != 0 {
+		return &ValidationError{Problems: problems}
+	}
+	if err := i.resolveReferences(index); err != nil {
+		return err
+	}
+	if source.onBroken() == BrokenFail && len(index.missing) != 0 {
+		return &ValidationError{Problems: source.brokenReferences(index)}
+	}
+
+	start := time.Now()
+	var quarantine *os.File
+	if source.onBroken() == BrokenQuarantine {
+		if quarantine, err = os.Create(i.option.QuarantineFile); err != nil {
+			return err
+		}
+		defer quarantine.Close()
+	}
+	writer := newChunkWriter(i, index)
+	if quarantine != nil {
+		writer.quarantine = json.NewEncoder(quarantine)
+	}
+	if _, err := source.Stream(writer.addPharmacy, writer.addUser); err != nil {
+		return err
+	}
+	if err := writer.flush(); err != nil {
+		return err
+	}
+	if quarantine != nil {
+		if err := quarantine.Close(); err != nil {
+			return err
+		}
+		i.logger.Info("purchase histories quarantined",
+			zap.String("file", i.option.QuarantineFile),
+			zap.Int("records", writer.stages[5].quarantined),
+		)
+	}
+	total := 0
+	for index, stage := range writer.stages {
+		total += writer.rows[index]
+		i.logger.Info("import stage done",
+			zap.String("stage", stage.name),
+			zap.Int("rows", writer.rows[index]),
+			zap.Int("inserted", stage.Inserted),
+			zap.Int("updated", stage.Updated),
+			zap.Int("unchanged", stage.Unchanged),
+			zap.Int("deleted", stage.Deleted),
+			zap.Int("skipped", stage.skipped),
+			zap.Int("quarantined", stage.quarantined),
+			zap.Duration("elapsed", writer.elapsed[index]),
+			zap.Float64("rows_per_second", throughput(writer.rows[index], writer.elapsed[index])),
+		)
+	}
+	elapsed := time.Since(start)
+	i.logger.Info("import done",
+		zap.Bool("delta", i.source.Delta),
+		zap.Int("rows", total),
+		zap.Duration("elapsed", elapsed),
+		zap.Float64("rows_per_second", throughput(total, elapsed)),
+	)
+	return printSummary(i.out, writer.stages)
+}
+
+func throughput(rows int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(rows) / elapsed.Seconds()
+}
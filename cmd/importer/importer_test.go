@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"strings"
+	"testing"
+)
+
+// fakeBatch records the rows written to it and reports every row as inserted.
+type fakeBatch struct {
+	stored  map[string]bool
+	lookups int
+	calls   []string
+	masks   int
+	written map[string]int
+}
+
+func (f *fakeBatch) record(stage string, rows int) (storage.BatchResult, error) {
+	if rows == 0 {
+		return storage.BatchResult{}, nil
+	}
+	if f.written == nil {
+		f.written = map[string]int{}
+	}
+	f.calls = append(f.calls, stage)
+	f.written[stage] += rows
+	return storage.BatchResult{Inserted: rows}, nil
+}
+
+func (f *fakeBatch) UpsertPharmacies(_ context.Context, inputs []entity.Pharmacy) (storage.BatchResult, error) {
+	return f.record("pharmacies", len(inputs))
+}
+
+func (f *fakeBatch) ReplacePharmacyInfos(_ context.Context, _ [][]byte, inputs []entity.PharmacyInfo) (storage.BatchResult, error) {
+	return f.record("pharmacy infos", len(inputs))
+}
+
+func (f *fakeBatch) UpsertMasks(_ context.Context, inputs []entity.Mask) (storage.BatchResult, error) {
+	return f.record("masks", len(inputs))
+}
+
+func (f *fakeBatch) UpsertProducts(_ context.Context, inputs []entity.Product) (storage.BatchResult, error) {
+	return f.record("products", len(inputs))
+}
+
+func (f *fakeBatch) UpsertUsers(_ context.Context, inputs []entity.User) (storage.BatchResult, error) {
+	return f.record("users", len(inputs))
+}
+
+func (f *fakeBatch) UpsertPurchaseHistories(_ context.Context, inputs []entity.PurchaseHistory) (storage.BatchResult, error) {
+	return f.record("purchase histories", len(inputs))
+}
+
+func (f *fakeBatch) ProductsExist(_ context.Context, inputs []entity.Product) ([]bool, error) {
+	f.lookups += len(inputs)
+	result := make([]bool, 0, len(inputs))
+	for _, input := range inputs {
+		result = append(result, f.stored[string(input.UID)+string(input.ProductID)])
+	}
+	return result, nil
+}
+
+type ImporterSuite struct {
+	suite.Suite
+}
+
+func (suite *ImporterSuite) TestBuildRowsIsDeterministic() {
+	users, pharmacies, problems := load(Source{Format: FormatJSON, Users: "../../data/users.json", Pharmacies: "../../data/pharmacies.json"})
+	suite.Empty(problems)
+
+	first, err := buildRows(users, pharmacies, false)
+	suite.NoError(err)
+	second, err := buildRows(users, pharmacies, false)
+	suite.NoError(err)
+	suite.Equal(first, second)
+	suite.Len(first.pharmacies, len(pharmacies))
+	suite.Len(first.users, len(users))
+
+	products := map[string]bool{}
+	maskIDs := map[string]bool{}
+	for _, product := range first.products {
+		products[string(product.UID)+string(product.ProductID)] = true
+		maskIDs[string(product.MaskID)] = true
+	}
+	suite.Len(products, len(first.products))
+	suite.Len(first.masks, len(maskIDs))
+	suite.Less(len(first.masks), len(first.products))
+	for _, history := range first.purchaseHistories {
+		suite.True(products[string(history.PharmacyUID)+string(history.ProductID)])
+	}
+}
+
+func (suite *ImporterSuite) TestImportIDSeparatesKinds() {
+	suite.Equal(pharmacyID("Medlife"), pharmacyID("Medlife"))
+	suite.NotEqual(pharmacyID("Medlife"), userID("Medlife"))
+	suite.NotEqual(productID("Medlife", "MaskT"), productID("Welltrack", "MaskT"))
+}
+
+func (suite *ImporterSuite) TestBuildRowsDeltaKeepsOpeningHours() {
+	pharmacies := []entity.PharmacyJSON{
+		{Name: "Medlife", CashBalance: 10},
+		{Name: "Keystone", CashBalance: 20, OpeningHours: "Mon 08:00 - 12:00"},
+	}
+	rows, err := buildRows(nil, pharmacies, true)
+	suite.NoError(err)
+	suite.Equal([][]byte{pharmacyID("Keystone")}, rows.pharmacyIDs)
+	suite.Len(rows.pharmacyInfos, 1)
+
+	rows, err = buildRows(nil, pharmacies, false)
+	suite.NoError(err)
+	suite.Len(rows.pharmacyIDs, 2)
+}
+
+func (suite *ImporterSuite) TestResolveReferences() {
+	batch := &fakeBatch{stored: map[string]bool{
+		string(pharmacyID("Medlife")) + string(productID("Medlife", "MaskT")): true,
+	}}
+	importData := ImportData{
+		ctx:    context.Background(),
+		logger: zap.NewNop(),
+		batch:  batch,
+	}
+	index := newRefIndex()
+	for _, name := range []string{"MaskT", "True Barrier"} {
+		index.unresolved[indexKey(productID("Medlife", name))] = indexKey(pharmacyID("Medlife"))
+	}
+
+	suite.NoError(importData.resolveReferences(index))
+	suite.Equal(2, batch.lookups)
+	suite.Empty(index.unresolved)
+	suite.True(index.has(index.products, productID("Medlife", "MaskT")))
+	suite.True(index.has(index.missing, productID("Medlife", "True Barrier")))
+}
+
+func (suite *ImporterSuite) TestInitWritesChunks() {
+	batch := &fakeBatch{}
+	source := Source{Format: FormatJSON, Users: "../../data/users.json", Pharmacies: "../../data/pharmacies.json"}
+	users, pharmacies, problems := load(source)
+	suite.Empty(problems)
+	rows, err := buildRows(users, pharmacies, false)
+	suite.NoError(err)
+
+	var summary bytes.Buffer
+	importData := NewImportData(context.Background(), zap.NewNop(), source, ImportOption{ChunkRows: 50}, batch)
+	importData.out = &summary
+	suite.NoError(importData.Init())
+	suite.Contains(summary.String(), "total")
+	suite.Equal(map[string]int{
+		"pharmacies":         len(rows.pharmacies),
+		"users":              len(rows.users),
+		"masks":              len(rows.masks),
+		"pharmacy infos":     len(rows.pharmacyInfos),
+		"products":           len(rows.products),
+		"purchase histories": len(rows.purchaseHistories),
+	}, batch.written)
+	suite.Greater(len(batch.calls), 6)
+	suite.Equal("pharmacies", batch.calls[0])
+	suite.Equal("purchase histories", batch.calls[len(batch.calls)-1])
+
+	invalid := &fakeBatch{}
+	source.Users = filepath.Join(suite.T().TempDir(), "users.json")
+	suite.NoError(os.WriteFile(source.Users, []byte(`[{"name": "Yvonne Guerrero", "cashBalance": -1}]`), 0o644))
+	importData = NewImportData(context.Background(), zap.NewNop(), source, ImportOption{ChunkRows: 50}, invalid)
+	var validationErr *ValidationError
+	suite.ErrorAs(importData.Init(), &validationErr)
+	suite.Empty(invalid.calls)
+}
+
+func (suite *ImporterSuite) TestPrintSummary() {
+	var buf bytes.Buffer
+	suite.NoError(printSummary(&buf, []stageSummary{
+		{name: "pharmacies", BatchResult: storage.BatchResult{Inserted: 1, Updated: 2, Unchanged: 3}},
+		{name: "purchase histories", BatchResult: storage.BatchResult{Inserted: 4, Deleted: 1}, skipped: 2, quarantined: 1},
+	}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	suite.Len(lines, 4)
+	suite.Equal([]string{"table", "inserted", "updated", "unchanged", "deleted", "skipped", "quarantined"}, strings.Fields(lines[0]))
+	suite.Equal([]string{"total", "5", "2", "3", "1", "2", "1"}, strings.Fields(lines[3]))
+}
+
+func (suite *ImporterSuite) TestInitHandlesBrokenReferences() {
+	dir := suite.T().TempDir()
+	source := Source{
+		Format:     FormatJSON,
+		Pharmacies: filepath.Join(dir, "pharmacies.json"),
+		Users:      filepath.Join(dir, "users.json"),
+	}
+	suite.NoError(os.WriteFile(source.Pharmacies, []byte(`[{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon 08:00 - 12:00",
+		"masks": [{"name": "MaskT", "price": 5}]}]`), 0o644))
+	suite.NoError(os.WriteFile(source.Users, []byte(`[{"name": "Yvonne Guerrero", "cashBalance": 100, "purchaseHistories": [
+		{"pharmacyName": "Medlife", "maskName": "MaskT", "transactionAmount": 5, "transactionDate": "2021-01-04 15:18:51"},
+		{"pharmacyName": "Keystone", "maskName": "MaskT", "transactionAmount": 5, "transactionDate": "2021-01-05 15:18:51"}]}]`), 0o644))
+	option := ImportOption{ChunkRows: 50, QuarantineFile: filepath.Join(dir, "quarantine.ndjson")}
+	run := func(onBroken string) (*fakeBatch, error) {
+		batch := &fakeBatch{}
+		source.OnBrokenReference = onBroken
+		importData := NewImportData(context.Background(), zap.NewNop(), source, option, batch)
+		importData.out = &bytes.Buffer{}
+		return batch, importData.Init()
+	}
+
+	batch, err := run(BrokenFail)
+	var validationErr *ValidationError
+	suite.ErrorAs(err, &validationErr)
+	suite.Len(validationErr.Problems, 1)
+	suite.Empty(batch.calls)
+
+	batch, err = run(BrokenSkip)
+	suite.NoError(err)
+	suite.Equal(1, batch.written["purchase histories"])
+	suite.NoFileExists(option.QuarantineFile)
+
+	batch, err = run(BrokenQuarantine)
+	suite.NoError(err)
+	suite.Equal(1, batch.written["purchase histories"])
+	content, err := os.ReadFile(option.QuarantineFile)
+	suite.NoError(err)
+	var record quarantineRecord
+	suite.NoError(json.Unmarshal(content, &record))
+	suite.Equal(quarantineRecord{
+		User: "Yvonne Guerrero",
+		PurchaseHistory: entity.PurchaseHistoryJSON{
+			PharmacyName: "Keystone", MaskName: "MaskT", TransactionAmount: 5, TransactionDate: "2021-01-05 15:18:51",
+		},
+		Reason: `unknown pharmacy "Keystone"`,
+	}, record)
+}
+
+func TestImporterSuite(t *testing.T) {
+	suite.Run(t, new(ImporterSuite))
+}
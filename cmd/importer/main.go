@@ -1,14 +1,42 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"github.com/justdomepaul/toolbox/errorhandler"
+	"os"
+	"runtime"
 )
 
 var (
 	system = "Import Data"
 )
 
+var (
+	dryRun    = flag.Bool("dry-run", false, "validate the input files and report every problem without touching the database")
+	workers   = flag.Int("workers", runtime.NumCPU(), "number of batches written to the database at the same time")
+	chunkRows = flag.Int("chunk-rows", 10000, "rows buffered in memory before they are written")
+	delta     = flag.Bool("delta", false, "import new or changed records on top of the existing rows instead of a complete snapshot")
+
+	onBrokenReference = flag.String("on-broken-reference", envOr("IMPORT_ON_BROKEN_REFERENCE", BrokenFail),
+		"purchase histories naming a mask their pharmacy does not sell: fail, skip or quarantine (env IMPORT_ON_BROKEN_REFERENCE)")
+	quarantineFile = flag.String("quarantine-file", envOr("IMPORT_QUARANTINE_FILE", "./quarantine.ndjson"),
+		"file the quarantined purchase histories are written to (env IMPORT_QUARANTINE_FILE)")
+
+	inputFormat      = flag.String("format", envOr("IMPORT_FORMAT", FormatJSON), "input format, json or csv (env IMPORT_FORMAT)")
+	pharmaciesPath   = flag.String("pharmacies", envOr("IMPORT_PHARMACIES", PharmacyDataPath), "pharmacies input, - for stdin (env IMPORT_PHARMACIES)")
+	usersPath        = flag.String("users", envOr("IMPORT_USERS", UserDataPath), "users input, - for stdin (env IMPORT_USERS)")
+	openingHoursPath = flag.String("opening-hours", os.Getenv("IMPORT_OPENING_HOURS"), "csv opening hours input (env IMPORT_OPENING_HOURS)")
+	productsPath     = flag.String("products", os.Getenv("IMPORT_PRODUCTS"), "csv products input (env IMPORT_PRODUCTS)")
+	purchasesPath    = flag.String("purchases", os.Getenv("IMPORT_PURCHASES"), "csv purchases input (env IMPORT_PURCHASES)")
+)
+
 func main() {
+	flag.Parse()
+	if *dryRun {
+		os.Exit(runDryRun())
+	}
+
 	defer errorhandler.PanicErrorHandler(system, "import data interrupt => \n")
 
 	_, cleanup, err := Runner()
@@ -17,3 +45,31 @@ func main() {
 	}
 	defer cleanup()
 }
+
+// runDryRun prints every problem of the input files to stderr and returns the exit code.
+func runDryRun() int {
+	source, cleanup, err := NewSource().Spool()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer cleanup()
+	index, problems := source.Validate()
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(problems) != 0 {
+		fmt.Fprintf(os.Stderr, "dry run found %d problem(s)\n", len(problems))
+		return 1
+	}
+	if len(index.missing) != 0 {
+		broken := source.brokenReferences(index)
+		for _, problem := range broken {
+			fmt.Fprintf(os.Stderr, "would %s: %s\n", source.onBroken(), problem)
+		}
+		fmt.Printf("dry run found no problem, %d purchase history(ies) would be left out\n", len(broken))
+		return 0
+	}
+	fmt.Println("dry run found no problem")
+	return 0
+}
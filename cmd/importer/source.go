@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"phantom_mask/internal/entity"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+
+	// StdinPath reads an input from standard input instead of a file.
+	StdinPath = "-"
+)
+
+// Source locates the import input. The JSON format reads Pharmacies and Users as in data/, the CSV format reads
+// one file per table with a header row:
+//
+//	Pharmacies   name,cash_balance
+//	OpeningHours pharmacy_name,opening_hours
+//	Products     pharmacy_name,mask_name,price
+//	Users        name,cash_balance
+//	Purchases    user_name,pharmacy_name,mask_name,transaction_amount,transaction_date
+//
+// Any path may be StdinPath, at most one of them, and gzip compressed input is detected and decompressed.
+// JSON inputs hold either an array or a sequence of records such as NDJSON, and are decoded one record at a time.
+// A Delta source only holds new or changed records, see ImportData. OnBrokenReference is BrokenFail,
+// BrokenSkip or BrokenQuarantine.
+type Source struct {
+	Format            string
+	Delta             bool
+	OnBrokenReference string
+	Pharmacies        string
+	Users             string
+	OpeningHours      string
+	Products          string
+	Purchases         string
+
+	// stdin is the file standard input was spooled to, see Spool
+	stdin string
+}
+
+// NewSource reads the source from the command line flags.
+func NewSource() Source {
+	return Source{
+		Format:            *inputFormat,
+		Delta:             *delta,
+		OnBrokenReference: *onBrokenReference,
+		Pharmacies:        *pharmaciesPath,
+		Users:             *usersPath,
+		OpeningHours:      *openingHoursPath,
+		Products:          *productsPath,
+		Purchases:         *purchasesPath,
+	}
+}
+
+// envOr returns the environment variable key, or fallback when it is unset.
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	file io.Closer
+}
+
+func (r gzipReadCloser) Close() error {
+	if err := r.Reader.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+type bufferedReadCloser struct {
+	*bufio.Reader
+	file io.Closer
+}
+
+func (r bufferedReadCloser) Close() error {
+	return r.file.Close()
+}
+
+// open opens path, or standard input for StdinPath, and transparently decompresses gzip content.
+func (s Source) open(path string) (io.ReadCloser, error) {
+	var file io.ReadCloser = os.Stdin
+	switch {
+	case path == StdinPath && s.stdin != "":
+		path = s.stdin
+		fallthrough
+	case path != StdinPath:
+		opened, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		file = opened
+	}
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return gzipReadCloser{Reader: gz, file: file}, nil
+	}
+	return bufferedReadCloser{Reader: reader, file: file}, nil
+}
+
+// onBroken returns OnBrokenReference, BrokenFail when it is empty.
+func (s Source) onBroken() string {
+	if s.OnBrokenReference == "" {
+		return BrokenFail
+	}
+	return s.OnBrokenReference
+}
+
+// Spool copies standard input to a temporary file when the source reads it, so the source can be streamed more
+// than once. The returned cleanup removes the file.
+func (s Source) Spool() (Source, func(), error) {
+	cleanup := func() {}
+	if s.stdin != "" {
+		return s, cleanup, nil
+	}
+	for _, path := range []string{s.Pharmacies, s.Users, s.OpeningHours, s.Products, s.Purchases} {
+		if path != StdinPath {
+			continue
+		}
+		file, err := os.CreateTemp("", "importer-stdin-*")
+		if err != nil {
+			return s, cleanup, err
+		}
+		cleanup = func() { os.Remove(file.Name()) }
+		if _, err := io.Copy(file, os.Stdin); err != nil {
+			file.Close()
+			cleanup()
+			return s, func() {}, err
+		}
+		if err := file.Close(); err != nil {
+			cleanup()
+			return s, func() {}, err
+		}
+		s.stdin = file.Name()
+		break
+	}
+	return s, cleanup, nil
+}
+
+// Stream calls pharmacyFn with every pharmacy, then userFn with every user, in input order. Unreadable input is
+// returned as problems and skipped, an error of pharmacyFn or userFn stops the stream and is returned.
+func (s Source) Stream(pharmacyFn func(index int, phy entity.PharmacyJSON) error, userFn func(index int, us entity.UserJSON) error) ([]Problem, error) {
+	stdin := 0
+	for _, path := range []string{s.Pharmacies, s.Users, s.OpeningHours, s.Products, s.Purchases} {
+		if path == StdinPath {
+			stdin++
+		}
+	}
+	if stdin > 1 {
+		return []Problem{{File: StdinPath, Msg: "only one input can be read from stdin"}}, nil
+	}
+
+	switch s.Format {
+	case FormatJSON:
+		problems, err := decodeStream(s, s.Pharmacies, "pharmacies", pharmacyFn)
+		if err != nil {
+			return problems, err
+		}
+		userProblems, err := decodeStream(s, s.Users, "users", userFn)
+		return append(problems, userProblems...), err
+	case FormatCSV:
+		users, pharmacies, problems := s.loadCSV()
+		if len(problems) != 0 {
+			return problems, nil
+		}
+		for index, phy := range pharmacies {
+			if err := pharmacyFn(index, phy); err != nil {
+				return nil, err
+			}
+		}
+		for index, us := range users {
+			if err := userFn(index, us); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	return []Problem{{Msg: fmt.Sprintf("unsupported input format %q", s.Format)}}, nil
+}
+
+// decodeStream decodes the JSON array or record sequence at path one record at a time and calls fn with each.
+// Records of the wrong type are reported as problems located by kind and index and skipped, a syntax error
+// stops the file.
+func decodeStream[T any](s Source, path, kind string, fn func(index int, record T) error) ([]Problem, error) {
+	input, err := s.open(path)
+	if err != nil {
+		return []Problem{{File: path, Msg: err.Error()}}, nil
+	}
+	defer input.Close()
+	reader := bufio.NewReader(input)
+	first, err := firstByte(reader)
+	if err != nil {
+		return []Problem{{File: path, Msg: fmt.Sprintf("read input: %s", err)}}, nil
+	}
+	decoder := json.NewDecoder(reader)
+	array := first == '['
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return []Problem{{File: path, Msg: err.Error()}}, nil
+		}
+	}
+
+	var problems []Problem
+	for index := 0; ; index++ {
+		if array && !decoder.More() {
+			break
+		}
+		start := decoder.InputOffset()
+		var record T
+		err := decoder.Decode(&record)
+		if !array && errors.Is(err, io.EOF) {
+			break
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			problems = append(problems, Problem{
+				File:   path,
+				Record: fmt.Sprintf("%s[%d].%s", kind, index, typeErr.Field),
+				Msg:    fmt.Sprintf("%s at offset %d", err, start+typeErr.Offset),
+			})
+			continue
+		}
+		if err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				err = fmt.Errorf("%w at offset %d", err, syntaxErr.Offset)
+			}
+			return append(problems, Problem{File: path, Record: fmt.Sprintf("%s[%d]", kind, index), Msg: err.Error()}), nil
+		}
+		if err := fn(index, record); err != nil {
+			return problems, err
+		}
+	}
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return append(problems, Problem{File: path, Msg: err.Error()}), nil
+		}
+	}
+	return problems, nil
+}
+
+// firstByte returns the first byte after leading whitespace without consuming it.
+func firstByte(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+// readCSV calls fn with every record of the CSV file at path keyed by its header, after checking the header
+// holds columns. Line numbers start at 1 for the header.
+func (s Source) readCSV(path string, columns []string, fn func(line int, record map[string]string) []Problem) []Problem {
+	if path == "" {
+		return []Problem{{Msg: fmt.Sprintf("missing csv input with columns %s", strings.Join(columns, ","))}}
+	}
+	input, err := s.open(path)
+	if err != nil {
+		return []Problem{{File: path, Msg: err.Error()}}
+	}
+	defer input.Close()
+	reader := csv.NewReader(input)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return []Problem{{File: path, Msg: fmt.Sprintf("read header: %s", err)}}
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	for _, column := range columns {
+		if _, ok := index[column]; !ok {
+			return []Problem{{File: path, Record: "line 1", Msg: fmt.Sprintf("missing column %q", column)}}
+		}
+	}
+
+	var problems []Problem
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			problems = append(problems, Problem{File: path, Record: fmt.Sprintf("line %d", line), Msg: err.Error()})
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				continue
+			}
+			break
+		}
+		record := map[string]string{}
+		for _, column := range columns {
+			record[column] = strings.TrimSpace(row[index[column]])
+		}
+		problems = append(problems, fn(line, record)...)
+	}
+	return problems
+}
+
+// parseCSVFloat parses the column of a CSV record, reporting a problem when it is not a number.
+func parseCSVFloat(path string, line int, record map[string]string, column string) (float64, []Problem) {
+	value, err := strconv.ParseFloat(record[column], 64)
+	if err != nil {
+		return 0, []Problem{{File: path, Record: fmt.Sprintf("line %d.%s", line, column), Msg: err.Error()}}
+	}
+	return value, nil
+}
+
+// loadCSV maps the CSV tables to the JSON structures, opening hours rows of the same pharmacy are joined with "/".
+func (s Source) loadCSV() ([]entity.UserJSON, []entity.PharmacyJSON, []Problem) {
+	var pharmacies []entity.PharmacyJSON
+	var users []entity.UserJSON
+	pharmacyIndex := map[string]int{}
+	userIndex := map[string]int{}
+	unknown := func(path string, line int, column, kind, name string) []Problem {
+		return []Problem{{File: path, Record: fmt.Sprintf("line %d.%s", line, column), Msg: fmt.Sprintf("unknown %s %q", kind, name)}}
+	}
+
+	problems := s.readCSV(s.Pharmacies, []string{"name", "cash_balance"}, func(line int, record map[string]string) []Problem {
+		cashBalance, problems := parseCSVFloat(s.Pharmacies, line, record, "cash_balance")
+		pharmacyIndex[record["name"]] = len(pharmacies)
+		pharmacies = append(pharmacies, entity.PharmacyJSON{Name: record["name"], CashBalance: cashBalance})
+		return problems
+	})
+	problems = append(problems, s.readCSV(s.OpeningHours, []string{"pharmacy_name", "opening_hours"}, func(line int, record map[string]string) []Problem {
+		index, ok := pharmacyIndex[record["pharmacy_name"]]
+		if !ok {
+			return unknown(s.OpeningHours, line, "pharmacy_name", "pharmacy", record["pharmacy_name"])
+		}
+		if pharmacies[index].OpeningHours != "" {
+			pharmacies[index].OpeningHours += " / "
+		}
+		pharmacies[index].OpeningHours += record["opening_hours"]
+		return nil
+	})...)
+	problems = append(problems, s.readCSV(s.Products, []string{"pharmacy_name", "mask_name", "price"}, func(line int, record map[string]string) []Problem {
+		price, problems := parseCSVFloat(s.Products, line, record, "price")
+		index, ok := pharmacyIndex[record["pharmacy_name"]]
+		if !ok {
+			return append(problems, unknown(s.Products, line, "pharmacy_name", "pharmacy", record["pharmacy_name"])...)
+		}
+		pharmacies[index].Masks = append(pharmacies[index].Masks, entity.MaskJSON{Name: record["mask_name"], Price: price})
+		return problems
+	})...)
+	problems = append(problems, s.readCSV(s.Users, []string{"name", "cash_balance"}, func(line int, record map[string]string) []Problem {
+		cashBalance, problems := parseCSVFloat(s.Users, line, record, "cash_balance")
+		userIndex[record["name"]] = len(users)
+		users = append(users, entity.UserJSON{Name: record["name"], CashBalance: cashBalance})
+		return problems
+	})...)
+	problems = append(problems, s.readCSV(s.Purchases, []string{"user_name", "pharmacy_name", "mask_name", "transaction_amount", "transaction_date"}, func(line int, record map[string]string) []Problem {
+		amount, problems := parseCSVFloat(s.Purchases, line, record, "transaction_amount")
+		index, ok := userIndex[record["user_name"]]
+		if !ok {
+			return append(problems, unknown(s.Purchases, line, "user_name", "user", record["user_name"])...)
+		}
+		users[index].PurchaseHistories = append(users[index].PurchaseHistories, entity.PurchaseHistoryJSON{
+			PharmacyName:      record["pharmacy_name"],
+			MaskName:          record["mask_name"],
+			TransactionAmount: amount,
+			TransactionDate:   record["transaction_date"],
+		})
+		return problems
+	})...)
+	return users, pharmacies, problems
+}
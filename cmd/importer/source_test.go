@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"phantom_mask/internal/entity"
+	"testing"
+)
+
+type SourceSuite struct {
+	suite.Suite
+	dir string
+}
+
+// load streams the source into slices and returns the problems Validate finds.
+func load(source Source) ([]entity.UserJSON, []entity.PharmacyJSON, []Problem) {
+	var users []entity.UserJSON
+	var pharmacies []entity.PharmacyJSON
+	_, _ = source.Stream(func(_ int, phy entity.PharmacyJSON) error {
+		pharmacies = append(pharmacies, phy)
+		return nil
+	}, func(_ int, us entity.UserJSON) error {
+		users = append(users, us)
+		return nil
+	})
+	_, problems := source.Validate()
+	return users, pharmacies, problems
+}
+
+func (suite *SourceSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *SourceSuite) writeFile(name, content string) string {
+	path := filepath.Join(suite.dir, name)
+	suite.NoError(os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func (suite *SourceSuite) csvSource() Source {
+	return Source{
+		Format:       FormatCSV,
+		Pharmacies:   suite.writeFile("pharmacies.csv", "name,cash_balance\nMedlife,10\nKeystone,20\n"),
+		OpeningHours: suite.writeFile("opening_hours.csv", "pharmacy_name,opening_hours\nMedlife,Mon 08:00 - 12:00\nMedlife,Tue 14:00 - 18:00\nKeystone,Wed 08:00 - 12:00\n"),
+		Products:     suite.writeFile("products.csv", "price,mask_name,pharmacy_name\n13.7,True Barrier (green) (3 per pack),Medlife\n5,Cotton Kiss (blue) (6 per pack),Keystone\n"),
+		Users:        suite.writeFile("users.csv", "name,cash_balance\nYvonne Guerrero,100\n"),
+		Purchases: suite.writeFile("purchases.csv", "user_name,pharmacy_name,mask_name,transaction_amount,transaction_date\n"+
+			"Yvonne Guerrero,Medlife,True Barrier (green) (3 per pack),13.7,2021-01-04 15:18:51\n"),
+	}
+}
+
+func (suite *SourceSuite) TestLoadCSV() {
+	users, pharmacies, problems := load(suite.csvSource())
+	suite.Empty(problems)
+	suite.Len(pharmacies, 2)
+	suite.Equal("Medlife", pharmacies[0].Name)
+	suite.Equal("Mon 08:00 - 12:00 / Tue 14:00 - 18:00", pharmacies[0].OpeningHours)
+	suite.Len(pharmacies[0].Masks, 1)
+	suite.Equal(13.7, pharmacies[0].Masks[0].Price)
+	suite.Len(users, 1)
+	suite.Equal(float64(100), users[0].CashBalance)
+	suite.Len(users[0].PurchaseHistories, 1)
+	suite.Equal("Medlife", users[0].PurchaseHistories[0].PharmacyName)
+}
+
+func (suite *SourceSuite) TestLoadCSVReportsProblems() {
+	source := suite.csvSource()
+	source.Products = suite.writeFile("bad_products.csv", "pharmacy_name,mask_name,price\nMedlife,MaskT,cheap\nWelltrack,MaskT,1\n")
+	source.Purchases = suite.writeFile("bad_purchases.csv", "user_name,pharmacy_name,mask_name\n")
+	_, _, problems := load(source)
+	suite.Equal([]Problem{
+		{File: source.Products, Record: "line 2.price", Msg: `strconv.ParseFloat: parsing "cheap": invalid syntax`},
+		{File: source.Products, Record: "line 3.pharmacy_name", Msg: `unknown pharmacy "Welltrack"`},
+		{File: source.Purchases, Record: "line 1", Msg: `missing column "transaction_amount"`},
+	}, problems)
+}
+
+func (suite *SourceSuite) TestLoadGzipJSON() {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(`[{"name": "Yvonne Guerrero", "cashBalance": 100, "purchaseHistories": []}]`))
+	suite.NoError(err)
+	suite.NoError(writer.Close())
+
+	source := Source{
+		Format:     FormatJSON,
+		Users:      suite.writeFile("users.json.gz", buf.String()),
+		Pharmacies: suite.writeFile("pharmacies.json", `[{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon 08:00 - 12:00", "masks": []}]`),
+	}
+	users, pharmacies, problems := load(source)
+	suite.Empty(problems)
+	suite.Len(users, 1)
+	suite.Len(pharmacies, 1)
+}
+
+func (suite *SourceSuite) TestLoadRejectsSourceErrors() {
+	_, _, problems := load(Source{Format: FormatJSON, Users: StdinPath, Pharmacies: StdinPath})
+	suite.Equal([]Problem{{File: StdinPath, Msg: "only one input can be read from stdin"}}, problems)
+
+	_, _, problems = load(Source{Format: "xml"})
+	suite.Equal([]Problem{{Msg: `unsupported input format "xml"`}}, problems)
+}
+
+func (suite *SourceSuite) TestSpoolStdin() {
+	stdin, err := os.Open(suite.writeFile("users.json", `[{"name": "Yvonne Guerrero", "cashBalance": 100}]`))
+	suite.NoError(err)
+	defer stdin.Close()
+	original := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = original }()
+
+	source := Source{
+		Format:     FormatJSON,
+		Users:      StdinPath,
+		Pharmacies: suite.writeFile("pharmacies.json", `[]`),
+	}
+	spooled, cleanup, err := source.Spool()
+	suite.NoError(err)
+	for round := 0; round < 2; round++ {
+		users, _, problems := load(spooled)
+		suite.Empty(problems)
+		suite.Len(users, 1)
+	}
+	cleanup()
+	suite.NoFileExists(spooled.stdin)
+}
+
+func TestSourceSuite(t *testing.T) {
+	suite.Run(t, new(SourceSuite))
+}
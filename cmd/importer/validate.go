@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/utils"
+	"time"
+)
+
+const (
+	UserDataPath     = "./data/users.json"
+	PharmacyDataPath = "./data/pharmacies.json"
+
+	transactionDateLayout = "2006-01-02 15:04:05"
+
+	// BrokenFail refuses the import when a purchase history names a mask its pharmacy does not sell
+	BrokenFail = "fail"
+	// BrokenSkip leaves such purchase histories out of the import
+	BrokenSkip = "skip"
+	// BrokenQuarantine leaves them out and writes them with the reason to the quarantine file
+	BrokenQuarantine = "quarantine"
+)
+
+// Problem is one invalid value found in an input file. Record locates the JSON element, such as
+// `pharmacies[3] "DFW Wellness".openingHours`, and is empty when the whole file could not be read.
+type Problem struct {
+	File   string
+	Record string
+	Msg    string
+}
+
+func (p Problem) String() string {
+	if p.File == "" {
+		return p.Msg
+	}
+	if p.Record == "" {
+		return fmt.Sprintf("%s: %s", p.File, p.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Record, p.Msg)
+}
+
+// ValidationError carries every problem found before an import.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid import data: %d problem(s), first: %s", len(e.Problems), e.Problems[0])
+}
+
+// refIndex holds the IDs derived from the natural keys of the input records, 16 bytes each, so references
+// between records resolve without keeping the records in memory.
+type refIndex struct {
+	pharmacies map[[16]byte]struct{}
+	products   map[[16]byte]struct{}
+	users      map[[16]byte]struct{}
+	// unresolved maps the products a delta references outside of it to their pharmacy, until they are looked up
+	unresolved map[[16]byte][16]byte
+	// missing holds the products purchase histories reference that are neither in the input nor stored
+	missing map[[16]byte]struct{}
+}
+
+func newRefIndex() *refIndex {
+	return &refIndex{
+		pharmacies: map[[16]byte]struct{}{},
+		products:   map[[16]byte]struct{}{},
+		users:      map[[16]byte]struct{}{},
+		unresolved: map[[16]byte][16]byte{},
+		missing:    map[[16]byte]struct{}{},
+	}
+}
+
+func indexKey(id []byte) [16]byte {
+	var key [16]byte
+	copy(key[:], id)
+	return key
+}
+
+// add inserts id into set and reports whether it was missing.
+func (idx *refIndex) add(set map[[16]byte]struct{}, id []byte) bool {
+	key := indexKey(id)
+	if _, ok := set[key]; ok {
+		return false
+	}
+	set[key] = struct{}{}
+	return true
+}
+
+func (idx *refIndex) has(set map[[16]byte]struct{}, id []byte) bool {
+	_, ok := set[indexKey(id)]
+	return ok
+}
+
+// brokenReference returns the field of usHis naming a product missing from the input and the database, and why.
+func (idx *refIndex) brokenReference(usHis entity.PurchaseHistoryJSON, delta bool) (string, string) {
+	if delta {
+		return "maskName", fmt.Sprintf("pharmacy %q does not sell mask %q in the input or the database", usHis.PharmacyName, usHis.MaskName)
+	}
+	if !idx.has(idx.pharmacies, pharmacyID(usHis.PharmacyName)) {
+		return "pharmacyName", fmt.Sprintf("unknown pharmacy %q", usHis.PharmacyName)
+	}
+	return "maskName", fmt.Sprintf("pharmacy %q does not sell mask %q", usHis.PharmacyName, usHis.MaskName)
+}
+
+// dataValidator checks one record at a time, every pharmacy before the first user, and collects the problems.
+type dataValidator struct {
+	userPath     string
+	pharmacyPath string
+	delta        bool
+	onBroken     string
+	index        *refIndex
+	problems     []Problem
+}
+
+func newDataValidator(userPath, pharmacyPath string, delta bool, onBroken string) *dataValidator {
+	return &dataValidator{
+		userPath:     userPath,
+		pharmacyPath: pharmacyPath,
+		delta:        delta,
+		onBroken:     onBroken,
+		index:        newRefIndex(),
+	}
+}
+
+func (v *dataValidator) report(file, record, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{File: file, Record: record, Msg: fmt.Sprintf(format, args...)})
+}
+
+// pharmacy checks the name, cash balance, opening hours and masks of the pharmacy at index, each day at most once
+// in the opening hours.
+func (v *dataValidator) pharmacy(index int, phy entity.PharmacyJSON) {
+	record := fmt.Sprintf("pharmacies[%d] %q", index, phy.Name)
+	if phy.Name == "" {
+		v.report(v.pharmacyPath, record, "name is empty")
+	} else if !v.index.add(v.index.pharmacies, pharmacyID(phy.Name)) {
+		v.report(v.pharmacyPath, record, "duplicate pharmacy name")
+	}
+	if phy.CashBalance < 0 {
+		v.report(v.pharmacyPath, record+".cashBalance", "negative cash balance %v", phy.CashBalance)
+	}
+	if schemas, err := utils.ParseTimeFormat(phy.OpeningHours); err != nil {
+		v.report(v.pharmacyPath, record+".openingHours", "%s", err)
+	} else {
+		// PharmacyInfo is keyed by pharmacy and day, so split shifts of one day cannot be stored.
+		days := map[int64]bool{}
+		for _, schema := range schemas {
+			if days[schema.Day] {
+				v.report(v.pharmacyPath, record+".openingHours", "%s is listed more than once", time.Weekday(schema.Day))
+			}
+			days[schema.Day] = true
+		}
+	}
+	for maskIndex, mask := range phy.Masks {
+		maskRecord := fmt.Sprintf("%s.masks[%d] %q", record, maskIndex, mask.Name)
+		if mask.Name == "" {
+			v.report(v.pharmacyPath, maskRecord, "name is empty")
+		} else if !v.index.add(v.index.products, productID(phy.Name, mask.Name)) {
+			v.report(v.pharmacyPath, maskRecord, "duplicate mask name in pharmacy")
+		}
+		if mask.Price <= 0 {
+			v.report(v.pharmacyPath, maskRecord+".price", "price must be positive, got %v", mask.Price)
+		}
+	}
+}
+
+// user checks the name, cash balance and purchase histories of the user at index, the purchase histories
+// against the pharmacies seen so far. Broken references are only reported with BrokenFail, otherwise they are
+// recorded in the index. A delta only holds part of the data, so the products it names outside of it are left
+// unresolved until they are looked up in the database.
+func (v *dataValidator) user(index int, us entity.UserJSON) {
+	record := fmt.Sprintf("users[%d] %q", index, us.Name)
+	if us.Name == "" {
+		v.report(v.userPath, record, "name is empty")
+	} else if !v.index.add(v.index.users, userID(us.Name)) {
+		v.report(v.userPath, record, "duplicate user name")
+	}
+	if us.CashBalance < 0 {
+		v.report(v.userPath, record+".cashBalance", "negative cash balance %v", us.CashBalance)
+	}
+	dates := map[time.Time]bool{}
+	for hisIndex, usHis := range us.PurchaseHistories {
+		hisRecord := fmt.Sprintf("%s.purchaseHistories[%d]", record, hisIndex)
+		if date, err := time.Parse(transactionDateLayout, usHis.TransactionDate); err != nil {
+			v.report(v.userPath, hisRecord+".transactionDate", "%s", err)
+		} else if dates[date] {
+			v.report(v.userPath, hisRecord+".transactionDate", "duplicate transaction date %s", usHis.TransactionDate)
+		} else {
+			dates[date] = true
+		}
+		if usHis.TransactionAmount <= 0 {
+			v.report(v.userPath, hisRecord+".transactionAmount", "transaction amount must be positive, got %v", usHis.TransactionAmount)
+		}
+		id := productID(usHis.PharmacyName, usHis.MaskName)
+		switch {
+		case v.index.has(v.index.products, id):
+		case v.delta:
+			v.index.unresolved[indexKey(id)] = indexKey(pharmacyID(usHis.PharmacyName))
+		case v.onBroken != BrokenFail:
+			v.index.add(v.index.missing, id)
+		default:
+			field, reason := v.index.brokenReference(usHis, false)
+			v.report(v.userPath, hisRecord+"."+field, "%s", reason)
+		}
+	}
+}
+
+// Validate streams the source through a dataValidator, the returned problems are empty when it can be imported.
+// The index holds every pharmacy, product and user of the source.
+func (s Source) Validate() (*refIndex, []Problem) {
+	switch s.onBroken() {
+	case BrokenFail, BrokenSkip, BrokenQuarantine:
+	default:
+		return nil, []Problem{{Msg: fmt.Sprintf("unsupported broken reference behavior %q", s.OnBrokenReference)}}
+	}
+	validator := newDataValidator(s.Users, s.Pharmacies, s.Delta, s.onBroken())
+	problems, _ := s.Stream(func(index int, phy entity.PharmacyJSON) error {
+		validator.pharmacy(index, phy)
+		return nil
+	}, func(index int, us entity.UserJSON) error {
+		validator.user(index, us)
+		return nil
+	})
+	return validator.index, append(problems, validator.problems...)
+}
+
+// brokenReferences streams the users again and reports every purchase history naming a missing product of index.
+func (s Source) brokenReferences(index *refIndex) []Problem {
+	var problems []Problem
+	_, _ = s.Stream(func(int, entity.PharmacyJSON) error {
+		return nil
+	}, func(userIndex int, us entity.UserJSON) error {
+		for hisIndex, usHis := range us.PurchaseHistories {
+			if !index.has(index.missing, productID(usHis.PharmacyName, usHis.MaskName)) {
+				continue
+			}
+			field, reason := index.brokenReference(usHis, s.Delta)
+			problems = append(problems, Problem{
+				File:   s.Users,
+				Record: fmt.Sprintf("users[%d] %q.purchaseHistories[%d].%s", userIndex, us.Name, hisIndex, field),
+				Msg:    reason,
+			})
+		}
+		return nil
+	})
+	return problems
+}
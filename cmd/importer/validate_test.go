@@ -0,0 +1,170 @@
+package main
+
+import (
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"phantom_mask/internal/entity"
+	"strings"
+	"testing"
+)
+
+type ValidateSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *ValidateSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *ValidateSuite) writeFile(name, content string) string {
+	path := filepath.Join(suite.dir, name)
+	suite.NoError(os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func (suite *ValidateSuite) TestLoadDataReportsEveryProblem() {
+	pharmacyPath := suite.writeFile("pharmacies.json", `[
+		{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon, Funday 08:00 - 12:00",
+		 "masks": [{"name": "True Barrier (green) (3 per pack)", "price": 13.7}, {"name": "Cotton Kiss", "price": 0}]},
+		{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon 08:00 - 12:00", "masks": []}
+	]`)
+	userPath := suite.writeFile("users.json", `[
+		{"name": "Yvonne Guerrero", "cashBalance": 100, "purchaseHistories": [
+			{"pharmacyName": "Medlife", "maskName": "True Barrier (green) (3 per pack)", "transactionAmount": 12.35, "transactionDate": "2021-01-04 15:18:51"},
+			{"pharmacyName": "Keystone", "maskName": "True Barrier (green) (3 per pack)", "transactionAmount": 12.35, "transactionDate": "2021-01-05 15:18:51"},
+			{"pharmacyName": "Medlife", "maskName": "MaskT", "transactionAmount": 12.35, "transactionDate": "2021/01/06"}
+		]}
+	]`)
+
+	_, _, problems := load(Source{Format: FormatJSON, Users: userPath, Pharmacies: pharmacyPath})
+	var got []string
+	for _, problem := range problems {
+		got = append(got, problem.String())
+	}
+	suite.Len(got, 6, strings.Join(got, "\n"))
+	suite.Contains(got[0], `pharmacies[0] "Medlife".openingHours: parse opening hours`)
+	suite.Contains(got[0], `"Funday"`)
+	suite.Equal(pharmacyPath+`: pharmacies[0] "Medlife".masks[1] "Cotton Kiss".price: price must be positive, got 0`, got[1])
+	suite.Equal(pharmacyPath+`: pharmacies[1] "Medlife": duplicate pharmacy name`, got[2])
+	suite.Contains(got[3], `users[0] "Yvonne Guerrero".purchaseHistories[1].pharmacyName: unknown pharmacy "Keystone"`)
+	suite.Contains(got[4], `users[0] "Yvonne Guerrero".purchaseHistories[2].transactionDate: parsing time`)
+	suite.Contains(got[5], `purchaseHistories[2].maskName: pharmacy "Medlife" does not sell mask "MaskT"`)
+}
+
+func (suite *ValidateSuite) TestLoadDataReportsDecodeProblem() {
+	pharmacyPath := suite.writeFile("pharmacies.json", `[{"name": "Medlife", "cashBalance": "ten"}]`)
+	userPath := suite.writeFile("users.json", `[{"name": `)
+
+	_, _, problems := load(Source{Format: FormatJSON, Users: userPath, Pharmacies: pharmacyPath})
+	suite.Len(problems, 2)
+	suite.Equal(pharmacyPath, problems[0].File)
+	suite.Equal("pharmacies[0].cashBalance", problems[0].Record)
+	suite.Equal(userPath, problems[1].File)
+
+	_, _, problems = load(Source{Format: FormatJSON, Users: filepath.Join(suite.dir, "missing.json"), Pharmacies: pharmacyPath})
+	suite.Len(problems, 2)
+}
+
+func (suite *ValidateSuite) TestLoadDataBundledFiles() {
+	_, _, problems := load(Source{Format: FormatJSON, Users: "../../data/users.json", Pharmacies: "../../data/pharmacies.json"})
+	suite.Empty(problems)
+}
+
+func (suite *ValidateSuite) TestValidateDataDelta() {
+	pharmacies := []entity.PharmacyJSON{{Name: "Keystone", CashBalance: 10}}
+	users := []entity.UserJSON{{Name: "Yvonne Guerrero", CashBalance: 100, PurchaseHistories: []entity.PurchaseHistoryJSON{
+		{PharmacyName: "Medlife", MaskName: "MaskT", TransactionAmount: 12.35, TransactionDate: "2021-01-04 15:18:51"},
+		{PharmacyName: "Medlife", MaskName: "MaskT", TransactionAmount: -1, TransactionDate: "2021-01-05 15:18:51"},
+	}}}
+	validate := func(delta bool) []Problem {
+		validator := newDataValidator("users.json", "pharmacies.json", delta, BrokenFail)
+		validator.pharmacy(0, pharmacies[0])
+		validator.user(0, users[0])
+		return validator.problems
+	}
+	suite.Equal([]Problem{{
+		File:   "users.json",
+		Record: `users[0] "Yvonne Guerrero".purchaseHistories[1].transactionAmount`,
+		Msg:    "transaction amount must be positive, got -1",
+	}}, validate(true))
+	suite.Len(validate(false), 3)
+}
+
+func (suite *ValidateSuite) TestValidateRepeatedDays() {
+	pharmacyPath := suite.writeFile("pharmacies.json", `[
+		{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon - Fri 08:00 - 12:00 / Mon 14:00 - 18:00", "masks": []},
+		{"name": "Keystone", "cashBalance": 10, "openingHours": "Mon 22:00 - 02:00 / Tue 08:00 - 12:00", "masks": []}
+	]`)
+	userPath := suite.writeFile("users.json", `[]`)
+	_, problems := Source{Format: FormatJSON, Users: userPath, Pharmacies: pharmacyPath}.Validate()
+	suite.Equal([]Problem{{
+		File:   pharmacyPath,
+		Record: `pharmacies[0] "Medlife".openingHours`,
+		Msg:    "Monday is listed more than once",
+	}}, problems)
+}
+
+func (suite *ValidateSuite) TestValidateDuplicateUserNames() {
+	pharmacyPath := suite.writeFile("pharmacies.json", `[]`)
+	userPath := suite.writeFile("users.json", `[
+		{"name": "Yvonne Guerrero", "cashBalance": 100, "purchaseHistories": []},
+		{"name": "Ada Lin", "cashBalance": 50, "purchaseHistories": []},
+		{"name": "Yvonne Guerrero", "cashBalance": 20, "purchaseHistories": []}
+	]`)
+	for _, delta := range []bool{false, true} {
+		_, problems := Source{Format: FormatJSON, Users: userPath, Pharmacies: pharmacyPath, Delta: delta}.Validate()
+		suite.Equal([]Problem{{
+			File:   userPath,
+			Record: `users[2] "Yvonne Guerrero"`,
+			Msg:    "duplicate user name",
+		}}, problems)
+	}
+}
+
+func (suite *ValidateSuite) TestValidateNDJSON() {
+	pharmacyPath := suite.writeFile("pharmacies.ndjson", `{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon 08:00 - 12:00", "masks": [{"name": "MaskT", "price": 5}]}
+{"name": "Keystone", "cashBalance": 20, "openingHours": "Tue 08:00 - 12:00", "masks": []}
+`)
+	userPath := suite.writeFile("users.ndjson", `{"name": "Yvonne Guerrero", "cashBalance": 100, "purchaseHistories": [
+	{"pharmacyName": "Keystone", "maskName": "MaskT", "transactionAmount": 5, "transactionDate": "2021-01-04 15:18:51"}]}`)
+	index, problems := Source{Format: FormatJSON, Users: userPath, Pharmacies: pharmacyPath}.Validate()
+	suite.Equal([]Problem{{
+		File:   userPath,
+		Record: `users[0] "Yvonne Guerrero".purchaseHistories[0].maskName`,
+		Msg:    `pharmacy "Keystone" does not sell mask "MaskT"`,
+	}}, problems)
+	suite.Len(index.pharmacies, 2)
+	suite.True(index.has(index.products, productID("Medlife", "MaskT")))
+	suite.True(index.has(index.users, userID("Yvonne Guerrero")))
+}
+
+func (suite *ValidateSuite) TestValidateBrokenReferencePolicy() {
+	pharmacyPath := suite.writeFile("pharmacies.json", `[{"name": "Medlife", "cashBalance": 10, "openingHours": "Mon 08:00 - 12:00",
+		"masks": [{"name": "MaskT", "price": 5}]}]`)
+	userPath := suite.writeFile("users.json", `[{"name": "Yvonne Guerrero", "cashBalance": 100, "purchaseHistories": [
+		{"pharmacyName": "Medlife", "maskName": "MaskT", "transactionAmount": 5, "transactionDate": "2021-01-04 15:18:51"},
+		{"pharmacyName": "Medlife", "maskName": "Cotton Kiss", "transactionAmount": 5, "transactionDate": "2021-01-05 15:18:51"},
+		{"pharmacyName": "Keystone", "maskName": "MaskT", "transactionAmount": 5, "transactionDate": "2021-01-06 15:18:51"}]}]`)
+	source := Source{Format: FormatJSON, Users: userPath, Pharmacies: pharmacyPath, OnBrokenReference: BrokenSkip}
+
+	index, problems := source.Validate()
+	suite.Empty(problems)
+	suite.Len(index.missing, 2)
+	suite.Equal([]Problem{
+		{File: userPath, Record: `users[0] "Yvonne Guerrero".purchaseHistories[1].maskName`, Msg: `pharmacy "Medlife" does not sell mask "Cotton Kiss"`},
+		{File: userPath, Record: `users[0] "Yvonne Guerrero".purchaseHistories[2].pharmacyName`, Msg: `unknown pharmacy "Keystone"`},
+	}, source.brokenReferences(index))
+
+	source.OnBrokenReference = ""
+	_, problems = source.Validate()
+	suite.Len(problems, 2)
+	source.OnBrokenReference = "ignore"
+	_, problems = source.Validate()
+	suite.Equal([]Problem{{Msg: `unsupported broken reference behavior "ignore"`}}, problems)
+}
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateSuite))
+}
@@ -10,15 +10,21 @@ import (
 	"github.com/google/wire"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/database/spanner"
+	toolboxGRPC "github.com/justdomepaul/toolbox/grpc"
 	"github.com/justdomepaul/toolbox/jwt"
 	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/services"
 	"github.com/justdomepaul/toolbox/stringtool"
 	zapLogger "github.com/justdomepaul/toolbox/zap"
 	"go.uber.org/zap"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
 	"net/http"
+	"phantom_mask/internal/auth"
 	"phantom_mask/internal/handler"
+	"phantom_mask/internal/rpc"
+	"phantom_mask/internal/search"
 	"phantom_mask/internal/storage"
 	spannerDB "phantom_mask/internal/storage/spanner"
 )
@@ -33,15 +39,34 @@ var LoggerSet = wire.NewSet(zapLogger.NewLogger)
 
 type Empty struct{}
 
-func RunRestfulServer(logger *zap.Logger, coreOptions config.Set, route *gin.Engine, commonHandler restful.CommonHandler, handlers handler.Set) (Empty, func(), error) {
+func NewSearchIndex(c context.Context, logger *zap.Logger, pharmacy *spannerDB.Pharmacy) (*search.Index, func(), error) {
+	index := search.NewIndex(logger)
+	if err := index.Refresh(c, pharmacy); err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithCancel(c)
+	go index.Watch(ctx, pharmacy)
+	return index, cancel, nil
+}
+
+func NewSearchPharmacy(pharmacy *spannerDB.Pharmacy, index *search.Index) *search.Pharmacy {
+	return search.NewPharmacy(pharmacy, index)
+}
+
+func NewSearchProduct(product *spannerDB.Product, index *search.Index) *search.Product {
+	return search.NewProduct(product, index)
+}
+
+func RunRestfulServer(logger *zap.Logger, coreOptions config.Set, route *gin.Engine, commonHandler restful.CommonHandler, handlers handler.Set, grpcServer *grpc.Server, rpcServices rpc.Set) (Empty, func(), error) {
 	handler.AddRoutes(route, commonHandler, handlers)
 	pprof.Register(route)
+	rpc.Register(grpcServer, rpcServices)
 
 	h2s := &http2.Server{}
 
 	httpServer := &http.Server{
 		Addr:    stringtool.StringJoin(":" + coreOptions.Server.Port),
-		Handler: h2c.NewHandler(route, h2s),
+		Handler: h2c.NewHandler(rpc.Multiplex(grpcServer, route), h2s),
 	}
 
 	go func(s *http.Server) {
@@ -66,6 +91,7 @@ func RunRestfulServer(logger *zap.Logger, coreOptions config.Set, route *gin.Eng
 				zap.Error(err),
 			)
 		}
+		grpcServer.Stop()
 	}, nil
 }
 
@@ -78,18 +104,21 @@ func RestfulRunner() (Empty, func(), error) {
 			config.NewServer,
 			config.NewSpanner,
 			config.NewJWT,
+			config.NewGRPC,
 		),
 		LoggerSet,
 		spanner.NewExtendSpannerDatabase,
 		wire.NewSet(
-			wire.NewSet(spannerDB.NewPharmacy, wire.Bind(new(storage.IPharmacy), new(*spannerDB.Pharmacy))),
+			wire.NewSet(NewSearchIndex),
+			wire.NewSet(spannerDB.NewPharmacy, NewSearchPharmacy, wire.Bind(new(storage.IPharmacy), new(*search.Pharmacy))),
 			wire.NewSet(spannerDB.NewPharmacyInfo, wire.Bind(new(storage.IPharmacyInfo), new(*spannerDB.PharmacyInfo))),
-			wire.NewSet(spannerDB.NewProduct, wire.Bind(new(storage.IProduct), new(*spannerDB.Product))),
+			wire.NewSet(spannerDB.NewProduct, NewSearchProduct, wire.Bind(new(storage.IProduct), new(*search.Product))),
 			wire.NewSet(spannerDB.NewUser, wire.Bind(new(storage.IUser), new(*spannerDB.User))),
 			wire.NewSet(spannerDB.NewPurchaseHistory, wire.Bind(new(storage.IPurchaseHistory), new(*spannerDB.PurchaseHistory))),
+			wire.NewSet(spannerDB.NewMask, wire.Bind(new(storage.IMask), new(*spannerDB.Mask))),
 			wire.Struct(new(spannerDB.Set), "*")),
 		wire.NewSet(jwt.NewEHS384JWTFromOptions, wire.Bind(new(jwt.IJWT), new(*jwt.EHS384JWT))),
-		wire.NewSet(restful.NewBasicGuardValidator, wire.Bind(new(restful.GuarderValidator), new(*restful.BasicGuardValidator))),
+		wire.NewSet(auth.NewIssuer, wire.Bind(new(restful.GuarderValidator), new(*auth.Issuer))),
 		wire.NewSet(restful.NewJWTGuarder),
 		wire.NewSet(restful.NewGin),
 		wire.Value(restful.CommonHandler{
@@ -100,8 +129,21 @@ func RestfulRunner() (Empty, func(), error) {
 		wire.NewSet(
 			handler.NewPharmacy,
 			handler.NewTransaction,
+			handler.NewGraphQL,
+			handler.NewAuth,
+			handler.NewUser,
 			wire.Struct(new(handler.Set), "*")),
 		wire.NewSet(restful.NewRender),
+		wire.NewSet(
+			rpc.NewGuard,
+			wire.Bind(new(services.Authenticate), new(*rpc.Guard))),
+		wire.NewSet(toolboxGRPC.CreateServer),
+		wire.NewSet(
+			rpc.NewPharmacy,
+			rpc.NewSearch,
+			rpc.NewTransaction,
+			rpc.NewReport,
+			wire.Struct(new(rpc.Set), "*")),
 		RunRestfulServer,
 	)))
 }
@@ -1,6 +1,6 @@
 // Code generated by Wire. DO NOT EDIT.
 
-//go:generate go run github.com/google/wire/cmd/wire
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
 //go:build !wireinject
 // +build !wireinject
 
@@ -13,6 +13,7 @@ import (
 	"github.com/google/wire"
 	"github.com/justdomepaul/toolbox/config"
 	"github.com/justdomepaul/toolbox/database/spanner"
+	"github.com/justdomepaul/toolbox/grpc"
 	"github.com/justdomepaul/toolbox/jwt"
 	"github.com/justdomepaul/toolbox/restful"
 	"github.com/justdomepaul/toolbox/stringtool"
@@ -20,8 +21,12 @@ import (
 	zap2 "go.uber.org/zap"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	grpc2 "google.golang.org/grpc"
 	"net/http"
+	"phantom_mask/internal/auth"
 	"phantom_mask/internal/handler"
+	"phantom_mask/internal/rpc"
+	"phantom_mask/internal/search"
 	spanner2 "phantom_mask/internal/storage/spanner"
 )
 
@@ -43,8 +48,8 @@ func RestfulRunner() (Empty, func(), error) {
 	if err != nil {
 		return Empty{}, nil, err
 	}
-	basicGuardValidator := restful.NewBasicGuardValidator(ehs384JWT)
-	jwtGuarder := restful.NewJWTGuarder(configJWT, basicGuardValidator)
+	issuer := auth.NewIssuer(ehs384JWT)
+	jwtGuarder := restful.NewJWTGuarder(configJWT, issuer)
 	engine, err := restful.NewGin(set, render, jwtGuarder)
 	if err != nil {
 		return Empty{}, nil, err
@@ -56,37 +61,105 @@ func RestfulRunner() (Empty, func(), error) {
 		return Empty{}, nil, err
 	}
 	pharmacy := spanner2.NewPharmacy(logger, iSession)
+	context := ctx()
+	index, cleanup2, err := NewSearchIndex(context, logger, pharmacy)
+	if err != nil {
+		cleanup()
+		return Empty{}, nil, err
+	}
+	searchPharmacy := NewSearchPharmacy(pharmacy, index)
 	pharmacyInfo := spanner2.NewPharmacyInfo(logger, iSession)
 	product := spanner2.NewProduct(logger, iSession)
+	searchProduct := NewSearchProduct(product, index)
 	user := spanner2.NewUser(logger, iSession)
 	purchaseHistory := spanner2.NewPurchaseHistory(logger, iSession)
+	mask := spanner2.NewMask(logger, iSession)
 	spannerSet := spanner2.Set{
-		Pharmacy:        pharmacy,
+		Pharmacy:        searchPharmacy,
 		PharmacyInfo:    pharmacyInfo,
-		Product:         product,
+		Product:         searchProduct,
 		User:            user,
 		PurchaseHistory: purchaseHistory,
+		Mask:            mask,
 	}
-	handlerPharmacy, err := handler.NewPharmacy(logger, spannerSet)
+	handlerPharmacy, err := handler.NewPharmacy(logger, spannerSet, issuer)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return Empty{}, nil, err
 	}
-	transaction, err := handler.NewTransaction(logger, spannerSet)
+	transaction, err := handler.NewTransaction(logger, spannerSet, issuer)
 	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
+	}
+	graphQL, err := handler.NewGraphQL(logger, spannerSet, issuer)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
+	}
+	handlerAuth, err := handler.NewAuth(logger, issuer)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
+	}
+	handlerUser, err := handler.NewUser(logger, spannerSet, issuer)
+	if err != nil {
+		cleanup2()
 		cleanup()
 		return Empty{}, nil, err
 	}
 	handlerSet := handler.Set{
 		Pharmacy:    handlerPharmacy,
 		Transaction: transaction,
+		GraphQL:     graphQL,
+		Auth:        handlerAuth,
+		User:        handlerUser,
+	}
+	configGRPC := config.NewGRPC(set)
+	guard := rpc.NewGuard(issuer)
+	server := grpc.CreateServer(logger, configGRPC, guard)
+	rpcPharmacy, err := rpc.NewPharmacy(logger, spannerSet)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
 	}
-	empty, cleanup2, err := RunRestfulServer(logger, set, engine, commonHandler, handlerSet)
+	search, err := rpc.NewSearch(logger, spannerSet)
 	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
+	}
+	rpcTransaction, err := rpc.NewTransaction(logger, spannerSet, issuer)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
+	}
+	report, err := rpc.NewReport(logger, spannerSet)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return Empty{}, nil, err
+	}
+	rpcSet := rpc.Set{
+		Pharmacy:    rpcPharmacy,
+		Search:      search,
+		Transaction: rpcTransaction,
+		Report:      report,
+	}
+	empty, cleanup3, err := RunRestfulServer(logger, set, engine, commonHandler, handlerSet, server, rpcSet)
+	if err != nil {
+		cleanup2()
 		cleanup()
 		return Empty{}, nil, err
 	}
 	return empty, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -112,15 +185,34 @@ var LoggerSet = wire.NewSet(zap.NewLogger)
 
 type Empty struct{}
 
-func RunRestfulServer(logger *zap2.Logger, coreOptions config.Set, route *gin.Engine, commonHandler restful.CommonHandler, handlers handler.Set) (Empty, func(), error) {
+func NewSearchIndex(c context.Context, logger *zap2.Logger, pharmacy *spanner2.Pharmacy) (*search.Index, func(), error) {
+	index := search.NewIndex(logger)
+	if err := index.Refresh(c, pharmacy); err != nil {
+		return nil, nil, err
+	}
+	ctx2, cancel := context.WithCancel(c)
+	go index.Watch(ctx2, pharmacy)
+	return index, cancel, nil
+}
+
+func NewSearchPharmacy(pharmacy *spanner2.Pharmacy, index *search.Index) *search.Pharmacy {
+	return search.NewPharmacy(pharmacy, index)
+}
+
+func NewSearchProduct(product *spanner2.Product, index *search.Index) *search.Product {
+	return search.NewProduct(product, index)
+}
+
+func RunRestfulServer(logger *zap2.Logger, coreOptions config.Set, route *gin.Engine, commonHandler restful.CommonHandler, handlers handler.Set, grpcServer *grpc2.Server, rpcServices rpc.Set) (Empty, func(), error) {
 	handler.AddRoutes(route, commonHandler, handlers)
 	pprof.Register(route)
+	rpc.Register(grpcServer, rpcServices)
 
 	h2s := &http2.Server{}
 
 	httpServer := &http.Server{
 		Addr:    stringtool.StringJoin(":" + coreOptions.Server.Port),
-		Handler: h2c.NewHandler(route, h2s),
+		Handler: h2c.NewHandler(rpc.Multiplex(grpcServer, route), h2s),
 	}
 
 	go func(s *http.Server) {
@@ -136,5 +228,6 @@ func RunRestfulServer(logger *zap2.Logger, coreOptions config.Set, route *gin.En
 		if err := httpServer.Shutdown(ctx2); err != nil {
 			logger.Warn("restful server Failed to Shutdown", zap2.String("system", coreOptions.Core.SystemName), zap2.Error(err))
 		}
+		grpcServer.Stop()
 	}, nil
 }
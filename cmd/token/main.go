@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"phantom_mask/internal/auth"
+)
+
+var (
+	system = "Issue Token"
+)
+
+var (
+	role       = flag.String("role", string(auth.RoleAdmin), "role of the token, customer, pharmacy_staff or admin")
+	subject    = flag.String("subject", "", "user id of a customer, anything naming a pharmacy staff or an admin")
+	pharmacyID = flag.String("pharmacy", "", "pharmacy id of a pharmacy staff")
+)
+
+// main prints a token signed with the JWT key of the restful server, the way to issue the first admin token.
+func main() {
+	flag.Parse()
+
+	defer errorhandler.PanicErrorHandler(system, "issue token interrupt => \n")
+
+	_, cleanup, err := Runner()
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+}
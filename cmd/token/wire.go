@@ -0,0 +1,34 @@
+//go:build wireinject
+
+package main
+
+import (
+	"fmt"
+	"github.com/google/wire"
+	"github.com/justdomepaul/toolbox/config"
+	"github.com/justdomepaul/toolbox/jwt"
+	"phantom_mask/internal/auth"
+)
+
+type Empty struct{}
+
+func Run(issuer *auth.Issuer) (Empty, func(), error) {
+	token, err := issuer.Issue(auth.NewClaims(auth.Role(*role), *subject, *pharmacyID))
+	if err != nil {
+		return Empty{}, nil, err
+	}
+	fmt.Println(token.AccessToken)
+	return Empty{}, func() {}, nil
+}
+
+func Runner() (Empty, func(), error) {
+	panic(wire.Build(wire.NewSet(
+		wire.NewSet(
+			config.NewSet,
+			config.NewJWT,
+		),
+		wire.NewSet(jwt.NewEHS384JWTFromOptions, wire.Bind(new(jwt.IJWT), new(*jwt.EHS384JWT))),
+		auth.NewIssuer,
+		Run,
+	)))
+}
@@ -0,0 +1,3 @@
+ALTER TABLE Product DROP COLUMN PackSize;
+ALTER TABLE Product DROP COLUMN Color;
+ALTER TABLE Product DROP COLUMN Brand;
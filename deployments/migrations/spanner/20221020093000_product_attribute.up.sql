@@ -0,0 +1,3 @@
+ALTER TABLE Product ADD COLUMN Brand STRING(MAX);
+ALTER TABLE Product ADD COLUMN Color STRING(MAX);
+ALTER TABLE Product ADD COLUMN PackSize INT64;
@@ -0,0 +1,3 @@
+DROP INDEX ProductMaskID;
+ALTER TABLE Product DROP COLUMN MaskID;
+DROP TABLE Mask;
@@ -0,0 +1,10 @@
+CREATE TABLE Mask (
+    MaskID       BYTES(16)           NOT NULL,
+    Name         STRING(MAX)         NOT NULL,
+    Brand        STRING(MAX),
+    Color        STRING(MAX),
+    PackSize     INT64,
+    CreatedTime  TIMESTAMP           NOT NULL
+) PRIMARY KEY(MaskID);
+ALTER TABLE Product ADD COLUMN MaskID BYTES(16);
+CREATE INDEX ProductMaskID ON Product(MaskID);
@@ -0,0 +1,2 @@
+DROP INDEX CredentialEmail;
+DROP TABLE Credential;
@@ -0,0 +1,8 @@
+CREATE TABLE Credential (
+    UID          BYTES(16)           NOT NULL,
+    Email        STRING(MAX)         NOT NULL,
+    PasswordHash STRING(MAX)         NOT NULL,
+    CreatedTime  TIMESTAMP           NOT NULL
+) PRIMARY KEY(UID),
+  INTERLEAVE IN PARENT User ON DELETE CASCADE;
+CREATE UNIQUE INDEX CredentialEmail ON Credential(Email);
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/definition"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	jwtTool "github.com/justdomepaul/toolbox/jwt"
+	"phantom_mask/internal/entity"
+	"strings"
+	"time"
+)
+
+// Role is what the bearer of a token is allowed to do.
+type Role string
+
+const (
+	// RoleCustomer purchases as the user of the token subject only
+	RoleCustomer Role = "customer"
+	// RolePharmacyStaff manages the opening hours and products of the pharmacy of the token only
+	RolePharmacyStaff Role = "pharmacy_staff"
+	// RoleAdmin is allowed everything
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) valid() bool {
+	return r == RoleCustomer || r == RolePharmacyStaff || r == RoleAdmin
+}
+
+const (
+	TokenType = "Bearer"
+	// TokenTTL is how long the issued tokens are valid for
+	TokenTTL = time.Hour
+	// MaxTokenAge is how long after it is issued a token and its refreshes are valid for
+	MaxTokenAge = 24 * time.Hour
+)
+
+var (
+	ErrTokenRequired = fmt.Errorf("%w: bearer token required", errorhandler.ErrUnauthenticated)
+	ErrInvalidToken  = fmt.Errorf("%w: invalid token", errorhandler.ErrUnauthenticated)
+	ErrTokenTooOld   = fmt.Errorf("%w: token issued more than MaxTokenAge ago", errorhandler.ErrUnauthenticated)
+	ErrRoleDenied    = fmt.Errorf("%w: role not allowed", errorhandler.ErrNoPermission)
+	ErrNotOwner      = fmt.Errorf("%w: resource of another user or pharmacy", errorhandler.ErrNoPermission)
+)
+
+// Claims are the claims of the issued tokens, Subject is the user id of a customer and PharmacyID the pharmacy of a
+// pharmacy staff.
+type Claims struct {
+	Role       Role   `json:"role"`
+	PharmacyID string `json:"pharmacy_id,omitempty"`
+	*jwt.Claims
+}
+
+func NewClaims(role Role, subject, pharmacyID string) *Claims {
+	return &Claims{
+		Role:       role,
+		PharmacyID: pharmacyID,
+		Claims:     jwtTool.NewClaimsBuilder().WithSubject(subject).WithIssuedAt().Build(),
+	}
+}
+
+func (c *Claims) ExpiresAfter(d time.Duration) {
+	c.Expiry = jwt.NewNumericDate(jwtTool.Now().Add(d))
+}
+
+func (c *Claims) GetExpiresAfter() *jwt.NumericDate {
+	return c.Expiry
+}
+
+// Allow returns ErrRoleDenied unless the role of c is one of roles.
+func (c *Claims) Allow(roles ...Role) error {
+	for _, role := range roles {
+		if c.Role == role {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrRoleDenied, c.Role)
+}
+
+// AllowUser returns ErrNotOwner unless c is an admin or the customer userID.
+func (c *Claims) AllowUser(userID string) error {
+	if c.Role == RoleAdmin || c.Role == RoleCustomer && strings.EqualFold(c.Subject, userID) {
+		return nil
+	}
+	return fmt.Errorf("%w: user %s", ErrNotOwner, userID)
+}
+
+// UserID returns the user id the subject of the customer c is, ErrInvalidToken when it is not one.
+func (c *Claims) UserID() (string, error) {
+	if c.Role != RoleCustomer {
+		return "", fmt.Errorf("%w: %s", ErrRoleDenied, c.Role)
+	}
+	if _, err := uuid.Parse(c.Subject); err != nil {
+		return "", fmt.Errorf("%w: subject %q is not a user id", ErrInvalidToken, c.Subject)
+	}
+	return c.Subject, nil
+}
+
+// Buyer returns the user a purchase naming userID is made for: the customer of c, when userID is empty or theirs, or
+// userID for an admin, who must name one.
+func (c *Claims) Buyer(userID string) (string, error) {
+	if c.Role == RoleAdmin && userID == "" {
+		return "", fmt.Errorf("%w: user_id is required of an admin", errorhandler.ErrInvalidArguments)
+	}
+	if c.Role == RoleAdmin {
+		return userID, nil
+	}
+	if userID != "" {
+		if err := c.AllowUser(userID); err != nil {
+			return "", err
+		}
+	}
+	return c.UserID()
+}
+
+// AllowPharmacy returns ErrNotOwner unless c is an admin or a staff of pharmacyID.
+func (c *Claims) AllowPharmacy(pharmacyID string) error {
+	if c.Role == RoleAdmin || c.Role == RolePharmacyStaff && strings.EqualFold(c.PharmacyID, pharmacyID) {
+		return nil
+	}
+	return fmt.Errorf("%w: pharmacy %s", ErrNotOwner, pharmacyID)
+}
+
+func NewIssuer(jwt jwtTool.IJWT) *Issuer {
+	return &Issuer{
+		jwt: jwt,
+		ttl: TokenTTL,
+	}
+}
+
+// Issuer issues and verifies the tokens of the roles.
+type Issuer struct {
+	jwt jwtTool.IJWT
+	ttl time.Duration
+}
+
+// Issue issues a token of claims expiring after TokenTTL.
+func (i *Issuer) Issue(claims *Claims) (entity.Token, error) {
+	return i.issue(claims, i.ttl)
+}
+
+// Refresh issues a token of the claims of a verified token, keeping the time it was issued at so that the refreshes
+// of a token expire MaxTokenAge after it at the latest. It returns ErrTokenTooOld past that.
+func (i *Issuer) Refresh(claims *Claims) (entity.Token, error) {
+	if claims.IssuedAt == nil {
+		return entity.Token{}, fmt.Errorf("%w: issued at is missing", ErrInvalidToken)
+	}
+	deadline := claims.IssuedAt.Time().Add(MaxTokenAge)
+	ttl := deadline.Sub(jwtTool.Now())
+	if ttl < time.Second {
+		return entity.Token{}, fmt.Errorf("%w: issued at %s", ErrTokenTooOld, claims.IssuedAt.Time().UTC().Format(time.RFC3339))
+	}
+	if ttl > i.ttl {
+		ttl = i.ttl
+	}
+	refreshed := NewClaims(claims.Role, claims.Subject, claims.PharmacyID)
+	refreshed.IssuedAt = claims.IssuedAt
+	return i.issue(refreshed, ttl)
+}
+
+func (i *Issuer) issue(claims *Claims, ttl time.Duration) (entity.Token, error) {
+	if !claims.Role.valid() {
+		return entity.Token{}, fmt.Errorf("%w: unknown role %q", errorhandler.ErrInvalidArguments, claims.Role)
+	}
+	claims.ExpiresAfter(ttl)
+	token, err := i.jwt.GenerateToken(claims)
+	if err != nil {
+		return entity.Token{}, err
+	}
+	return entity.Token{
+		AccessToken: token,
+		TokenType:   TokenType,
+		ExpiresIn:   int64(ttl.Seconds()),
+	}, nil
+}
+
+// Parse returns the claims of token, ErrInvalidToken when it is malformed, forged, expired or of an unknown role.
+func (i *Issuer) Parse(token string) (*Claims, error) {
+	claims := &Claims{Claims: &jwt.Claims{}}
+	if err := i.jwt.VerifyToken(token, claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+	if !claims.Role.valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidToken, claims.Role)
+	}
+	return claims, nil
+}
+
+// Verify verifies token as a restful.GuarderValidator, keeping its claims in c for FromGin.
+func (i *Issuer) Verify(c *gin.Context, token string) error {
+	claims, err := i.Parse(token)
+	if err != nil {
+		return errorhandler.NewErrAuthenticate(err)
+	}
+	c.Set(definition.AuthTokenKey, claims)
+	return nil
+}
+
+// FromGin returns the claims Verify kept in c, nil when the request is not authenticated.
+func FromGin(c *gin.Context) *Claims {
+	value, ok := c.Get(definition.AuthTokenKey)
+	if !ok {
+		return nil
+	}
+	claims, _ := value.(*Claims)
+	return claims
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"errors"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	jwtTool "github.com/justdomepaul/toolbox/jwt"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+const (
+	testUserID     = "9a7b330a-a736-41e5-b1a5-6b51ac0a2a19"
+	testPharmacyID = "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+)
+
+type AuthSuite struct {
+	suite.Suite
+	issuer *Issuer
+}
+
+func (suite *AuthSuite) SetupTest() {
+	tool, err := jwtTool.NewEHS384JWT("test secret")
+	suite.Require().NoError(err)
+	suite.issuer = NewIssuer(tool)
+}
+
+func (suite *AuthSuite) TestIssueParse() {
+	token, err := suite.issuer.Issue(NewClaims(RolePharmacyStaff, "staff", testPharmacyID))
+	suite.Require().NoError(err)
+	suite.Equal(TokenType, token.TokenType)
+	suite.Equal(int64(3600), token.ExpiresIn)
+
+	claims, err := suite.issuer.Parse(token.AccessToken)
+	suite.Require().NoError(err)
+	suite.Equal(RolePharmacyStaff, claims.Role)
+	suite.Equal("staff", claims.Subject)
+	suite.Equal(testPharmacyID, claims.PharmacyID)
+
+	_, err = suite.issuer.Issue(NewClaims("root", "me", ""))
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func (suite *AuthSuite) TestParseInvalid() {
+	other, err := jwtTool.NewEHS384JWT("other secret")
+	suite.Require().NoError(err)
+	forged, err := NewIssuer(other).Issue(NewClaims(RoleAdmin, "me", ""))
+	suite.Require().NoError(err)
+
+	defer func() { jwtTool.Now = time.Now }()
+	jwtTool.Now = func() time.Time { return time.Now().Add(-2 * TokenTTL) }
+	expired, err := suite.issuer.Issue(NewClaims(RoleCustomer, testUserID, ""))
+	suite.Require().NoError(err)
+
+	for _, token := range []string{"", "not a token", forged.AccessToken, expired.AccessToken} {
+		_, err := suite.issuer.Parse(token)
+		suite.ErrorIs(err, ErrInvalidToken)
+		suite.ErrorIs(err, errorhandler.ErrUnauthenticated)
+	}
+}
+
+func (suite *AuthSuite) TestRefresh() {
+	defer func() { jwtTool.Now = time.Now }()
+	jwtTool.Now = func() time.Time { return time.Now().Add(-MaxTokenAge + 30*time.Minute) }
+	old := NewClaims(RolePharmacyStaff, "staff", testPharmacyID)
+	jwtTool.Now = func() time.Time { return time.Now().Add(-MaxTokenAge - time.Minute) }
+	tooOld := NewClaims(RolePharmacyStaff, "staff", testPharmacyID)
+	jwtTool.Now = time.Now
+
+	token, err := suite.issuer.Refresh(NewClaims(RolePharmacyStaff, "staff", testPharmacyID))
+	suite.Require().NoError(err)
+	suite.Equal(int64(TokenTTL.Seconds()), token.ExpiresIn)
+
+	token, err = suite.issuer.Refresh(old)
+	suite.Require().NoError(err)
+	suite.InDelta(int64(30*time.Minute/time.Second), token.ExpiresIn, 2)
+	claims, err := suite.issuer.Parse(token.AccessToken)
+	suite.Require().NoError(err)
+	suite.Equal(RolePharmacyStaff, claims.Role)
+	suite.Equal(testPharmacyID, claims.PharmacyID)
+	suite.Equal(old.IssuedAt.Time(), claims.IssuedAt.Time())
+
+	_, err = suite.issuer.Refresh(tooOld)
+	suite.ErrorIs(err, ErrTokenTooOld)
+	suite.ErrorIs(err, errorhandler.ErrUnauthenticated)
+}
+
+func (suite *AuthSuite) TestAllow() {
+	customer := NewClaims(RoleCustomer, testUserID, "")
+	staff := NewClaims(RolePharmacyStaff, "staff", testPharmacyID)
+	admin := NewClaims(RoleAdmin, "ops", "")
+
+	suite.NoError(customer.Allow(RoleCustomer, RoleAdmin))
+	suite.ErrorIs(staff.Allow(RoleCustomer, RoleAdmin), ErrRoleDenied)
+
+	suite.NoError(customer.AllowUser(testUserID))
+	suite.NoError(customer.AllowUser("9A7B330A-A736-41E5-B1A5-6B51AC0A2A19"))
+	suite.ErrorIs(customer.AllowUser(testPharmacyID), ErrNotOwner)
+	suite.ErrorIs(staff.AllowUser("staff"), ErrNotOwner)
+	suite.NoError(admin.AllowUser(testUserID))
+
+	suite.NoError(staff.AllowPharmacy(testPharmacyID))
+	suite.ErrorIs(staff.AllowPharmacy(testUserID), ErrNotOwner)
+	suite.ErrorIs(customer.AllowPharmacy(""), ErrNotOwner)
+	suite.NoError(admin.AllowPharmacy(testPharmacyID))
+	suite.True(errors.Is(ErrNotOwner, errorhandler.ErrNoPermission))
+}
+
+func (suite *AuthSuite) TestBuyer() {
+	customer := NewClaims(RoleCustomer, testUserID, "")
+	admin := NewClaims(RoleAdmin, "ops", "")
+
+	for _, userID := range []string{"", testUserID, "9A7B330A-A736-41E5-B1A5-6B51AC0A2A19"} {
+		buyer, err := customer.Buyer(userID)
+		suite.NoError(err)
+		suite.Equal(testUserID, buyer)
+	}
+	_, err := customer.Buyer(testPharmacyID)
+	suite.ErrorIs(err, ErrNotOwner)
+	_, err = NewClaims(RoleCustomer, "not a user id", "").Buyer("")
+	suite.ErrorIs(err, ErrInvalidToken)
+	_, err = NewClaims(RolePharmacyStaff, "staff", testPharmacyID).Buyer("")
+	suite.ErrorIs(err, ErrRoleDenied)
+
+	buyer, err := admin.Buyer(testUserID)
+	suite.NoError(err)
+	suite.Equal(testUserID, buyer)
+	_, err = admin.Buyer("")
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func (suite *AuthSuite) TestPassword() {
+	defer func(cost int) { PasswordCost = cost }(PasswordCost)
+	PasswordCost = bcrypt.MinCost
+
+	hash, err := HashPassword("correct horse")
+	suite.Require().NoError(err)
+	suite.NotEqual("correct horse", hash)
+	suite.NoError(CheckPassword(hash, "correct horse"))
+	suite.Equal(ErrInvalidCredentials, CheckPassword(hash, "wrong horse"))
+	suite.ErrorIs(CheckPassword("not a hash", "correct horse"), errorhandler.ErrUnauthenticated)
+	suite.Equal(ErrInvalidCredentials, CheckNoPassword("correct horse"))
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
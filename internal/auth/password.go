@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"fmt"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidCredentials is the error of a login of an unknown email or a wrong password, telling neither apart.
+var ErrInvalidCredentials = fmt.Errorf("%w: invalid email or password", errorhandler.ErrUnauthenticated)
+
+// PasswordCost is the bcrypt cost of the hashed passwords.
+var PasswordCost = bcrypt.DefaultCost
+
+// dummyHash is a bcrypt hash of bcrypt.DefaultCost whose password was thrown away.
+const dummyHash = "$2a$10$jurKYOOjjGf5xPNhsI24M.DQ2KhE8TokizdtFjkFrymi0EjxD.2LO"
+
+// HashPassword hashes password with bcrypt, which ignores the bytes of password past 72.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword returns ErrInvalidCredentials unless password is the one of hash.
+func CheckPassword(hash, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// CheckNoPassword returns ErrInvalidCredentials after checking password against dummyHash, so that the login of an
+// unknown email takes as long as the one of a wrong password.
+func CheckNoPassword(password string) error {
+	_ = bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+	return ErrInvalidCredentials
+}
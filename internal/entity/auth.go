@@ -0,0 +1,15 @@
+package entity
+
+// TokenJSON is the body issuing a token, PharmacyID is required for a pharmacy staff.
+type TokenJSON struct {
+	Role       string `json:"role" validate:"required,oneof=customer pharmacy_staff admin"`
+	Subject    string `json:"subject" validate:"required"`
+	PharmacyID string `json:"pharmacy_id" validate:"required_if=Role pharmacy_staff,omitempty,uuid"`
+}
+
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is how many seconds the token is valid for
+	ExpiresIn int64 `json:"expires_in"`
+}
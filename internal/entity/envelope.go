@@ -0,0 +1,23 @@
+package entity
+
+// Envelope is the body of every /v2 response, Data is null on error and Pagination is null unless Data is a page.
+type Envelope[T any] struct {
+	Data       T              `json:"data"`
+	Pagination *Pagination    `json:"pagination"`
+	Error      *EnvelopeError `json:"error"`
+}
+
+type Pagination struct {
+	Count         int64  `json:"count"`
+	Row           int64  `json:"row"`
+	Page          int64  `json:"page"`
+	NextPageToken string `json:"next_page_token"`
+}
+
+type EnvelopeError struct {
+	// Code is the stable code of the error, the same as the code of its Problem
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// InvalidParams are the request fields failing to parse or validate, null for the other errors
+	InvalidParams []InvalidParam `json:"invalid_params"`
+}
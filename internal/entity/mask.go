@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"github.com/justdomepaul/toolbox/entity"
+	"time"
+)
+
+// Mask is the canonical catalogue entry shared by every pharmacy selling the same mask.
+// PRIMARY KEY(MaskID)
+type Mask struct {
+	MaskID      []byte    `spanner:"MaskID" json:"mask_id,omitempty" validate:"required,max=16"`
+	Name        string    `spanner:"Name" json:"name,omitempty" validate:"required"`
+	Brand       string    `spanner:"Brand" json:"brand,omitempty"`
+	Color       string    `spanner:"Color" json:"color,omitempty"`
+	PackSize    int64     `spanner:"PackSize" json:"pack_size,omitempty"`
+	CreatedTime time.Time `spanner:"CreatedTime" json:"created_time,omitempty"`
+}
+
+type MaskList struct {
+	entity.CommonListResponse
+	Masks []*Mask `spanner:"Masks" json:"masks,omitempty"`
+}
+
+type MaskItemJSON struct {
+	*Mask
+	MaskID string `json:"mask_id,omitempty"`
+}
+
+type MaskListJSON struct {
+	entity.CommonListResponse
+	Masks []*MaskItemJSON `json:"masks,omitempty"`
+}
+
+type MaskItemV2 struct {
+	MaskID      string    `json:"mask_id"`
+	Name        string    `json:"name"`
+	Brand       string    `json:"brand"`
+	Color       string    `json:"color"`
+	PackSize    int64     `json:"pack_size"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+type MaskTransaction struct {
+	MaskID            []byte  `spanner:"MaskID" json:"mask_id,omitempty"`
+	Name              string  `spanner:"Name" json:"name,omitempty"`
+	Total             int64   `spanner:"Total" json:"total,omitempty"`
+	TransactionAmount float64 `spanner:"TransactionAmount" json:"transaction_amount,omitempty"`
+}
+
+type MaskTransactionList struct {
+	MaskTransactions []*MaskTransaction `spanner:"MaskTransactions" json:"mask_transactions,omitempty"`
+}
+
+type MaskTransactionJSON struct {
+	*MaskTransaction
+	MaskID string `json:"mask_id,omitempty"`
+}
+
+type MaskTransactionListJSON struct {
+	MaskTransactions []*MaskTransactionJSON `json:"mask_transactions,omitempty"`
+}
+
+type MaskTransactionV2 struct {
+	MaskID            string  `json:"mask_id"`
+	Name              string  `json:"name"`
+	Total             int64   `json:"total"`
+	TransactionAmount float64 `json:"transaction_amount"`
+}
@@ -28,6 +28,39 @@ type PharmacyListJSON struct {
 	Pharmacies []*PharmacyItemJSON `json:"pharmacies,omitempty"`
 }
 
+type PharmacyProductCount struct {
+	UID          []byte    `spanner:"UID" json:"uid,omitempty"`
+	Name         string    `spanner:"Name" json:"name,omitempty"`
+	CashBalance  float64   `spanner:"CashBalance" json:"cash_balance,omitempty"`
+	CreatedTime  time.Time `spanner:"CreatedTime" json:"created_time,omitempty"`
+	ProductCount int64     `spanner:"ProductCount" json:"product_count"`
+}
+
+type PharmacyProductCountList struct {
+	entity.CommonListResponse
+	Pharmacies    []*PharmacyProductCount `spanner:"Pharmacies" json:"pharmacies,omitempty"`
+	NextPageToken string                  `spanner:"-" json:"next_page_token,omitempty"`
+}
+
+type PharmacyProductCountItemJSON struct {
+	*PharmacyProductCount
+	UID string `json:"uid,omitempty"`
+}
+
+type PharmacyProductCountListJSON struct {
+	entity.CommonListResponse
+	Pharmacies    []*PharmacyProductCountItemJSON `json:"pharmacies,omitempty"`
+	NextPageToken string                          `spanner:"-" json:"next_page_token,omitempty"`
+}
+
+type PharmacyProductCountItemV2 struct {
+	UID          string    `json:"uid"`
+	Name         string    `json:"name"`
+	CashBalance  float64   `json:"cash_balance"`
+	CreatedTime  time.Time `json:"created_time"`
+	ProductCount int64     `json:"product_count"`
+}
+
 type PharmacySpecifyTimestamp struct {
 	UID         []byte    `spanner:"UID" json:"uid,omitempty"`
 	Name        string    `spanner:"Name" json:"name,omitempty"`
@@ -40,7 +73,8 @@ type PharmacySpecifyTimestamp struct {
 
 type PharmacySpecifyTimestampList struct {
 	entity.CommonListResponse
-	Pharmacies []*PharmacySpecifyTimestamp `spanner:"Pharmacies" json:"pharmacy_specify_timestamp_items,omitempty"`
+	Pharmacies    []*PharmacySpecifyTimestamp `spanner:"Pharmacies" json:"pharmacy_specify_timestamp_items,omitempty"`
+	NextPageToken string                      `spanner:"-" json:"next_page_token,omitempty"`
 }
 
 type PharmacySpecifyItemJSON struct {
@@ -50,7 +84,18 @@ type PharmacySpecifyItemJSON struct {
 
 type PharmacySpecifyListJSON struct {
 	entity.CommonListResponse
-	Pharmacies []*PharmacySpecifyItemJSON `json:"pharmacies,omitempty"`
+	Pharmacies    []*PharmacySpecifyItemJSON `json:"pharmacies,omitempty"`
+	NextPageToken string                     `spanner:"-" json:"next_page_token,omitempty"`
+}
+
+type PharmacySpecifyItemV2 struct {
+	UID         string    `json:"uid"`
+	Name        string    `json:"name"`
+	CashBalance float64   `json:"cash_balance"`
+	CreatedTime time.Time `json:"created_time"`
+	Day         int64     `json:"day"`
+	OpenHour    float64   `json:"open_hour"`
+	CloseHour   float64   `json:"close_hour"`
 }
 
 type PharmacyProduct struct {
@@ -60,20 +105,94 @@ type PharmacyProduct struct {
 	CashBalance  float64 `spanner:"CashBalance" json:"cash_balance,omitempty"`
 	ProductName  string  `spanner:"ProductName" json:"product_name,omitempty"`
 	Price        float64 `spanner:"Price" json:"price,omitempty"`
+	Brand        string  `spanner:"Brand" json:"brand,omitempty"`
+	Color        string  `spanner:"Color" json:"color,omitempty"`
+	PackSize     int64   `spanner:"PackSize" json:"pack_size,omitempty"`
+	Score        float64 `spanner:"-" json:"score,omitempty"`
 }
 
 type PharmacyProductList struct {
 	entity.CommonListResponse
 	PharmacyProducts []*PharmacyProduct `spanner:"PharmacyProducts" json:"pharmacy_products,omitempty"`
+	NextPageToken    string             `spanner:"-" json:"next_page_token,omitempty"`
 }
 
 type PharmacyProductJSON struct {
 	*PharmacyProduct
-	UID       string `json:"uid,omitempty"`
-	ProductID string `json:"product_id,omitempty"`
+	UID          string  `json:"uid,omitempty"`
+	ProductID    string  `json:"product_id,omitempty"`
+	PricePerMask float64 `json:"price_per_mask,omitempty"`
 }
 
 type PharmacyProductListJSON struct {
 	entity.CommonListResponse
 	PharmacyProducts []*PharmacyProductJSON `json:"pharmacy_products,omitempty"`
+	NextPageToken    string                 `spanner:"-" json:"next_page_token,omitempty"`
+}
+
+type PharmacyProductV2 struct {
+	UID          string  `json:"uid"`
+	ProductID    string  `json:"product_id"`
+	PharmacyName string  `json:"pharmacy_name"`
+	CashBalance  float64 `json:"cash_balance"`
+	ProductName  string  `json:"product_name"`
+	Price        float64 `json:"price"`
+	Brand        string  `json:"brand"`
+	Color        string  `json:"color"`
+	PackSize     int64   `json:"pack_size"`
+	Score        float64 `json:"score"`
+	PricePerMask float64 `json:"price_per_mask"`
+}
+
+type PharmacyPriceOffer struct {
+	UID          []byte  `spanner:"UID" json:"uid,omitempty"`
+	PharmacyName string  `spanner:"PharmacyName" json:"pharmacy_name,omitempty"`
+	ProductID    []byte  `spanner:"ProductID" json:"product_id,omitempty"`
+	ProductName  string  `spanner:"ProductName" json:"product_name,omitempty"`
+	Price        float64 `spanner:"Price" json:"price,omitempty"`
+	PackSize     int64   `spanner:"PackSize" json:"pack_size,omitempty"`
+	IsOpen       bool    `spanner:"IsOpen" json:"is_open"`
+}
+
+type ProductPriceComparison struct {
+	Name   string                `json:"name,omitempty"`
+	Offers []*PharmacyPriceOffer `json:"offers,omitempty"`
+}
+
+type ProductPriceComparisonList struct {
+	entity.CommonListResponse
+	Products []*ProductPriceComparison `json:"products,omitempty"`
+}
+
+type PharmacyPriceOfferJSON struct {
+	*PharmacyPriceOffer
+	UID          string  `json:"uid,omitempty"`
+	ProductID    string  `json:"product_id,omitempty"`
+	PricePerMask float64 `json:"price_per_mask,omitempty"`
+}
+
+type ProductPriceComparisonJSON struct {
+	Name   string                    `json:"name,omitempty"`
+	Offers []*PharmacyPriceOfferJSON `json:"offers,omitempty"`
+}
+
+type ProductPriceComparisonListJSON struct {
+	entity.CommonListResponse
+	Products []*ProductPriceComparisonJSON `json:"products,omitempty"`
+}
+
+type PharmacyPriceOfferV2 struct {
+	UID          string  `json:"uid"`
+	PharmacyName string  `json:"pharmacy_name"`
+	ProductID    string  `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	Price        float64 `json:"price"`
+	PackSize     int64   `json:"pack_size"`
+	PricePerMask float64 `json:"price_per_mask"`
+	IsOpen       bool    `json:"is_open"`
+}
+
+type ProductPriceComparisonV2 struct {
+	Name   string                  `json:"name"`
+	Offers []*PharmacyPriceOfferV2 `json:"offers"`
 }
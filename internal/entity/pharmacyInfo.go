@@ -7,3 +7,21 @@ type PharmacyInfo struct {
 	OpenHour  float64 `spanner:"OpenHour" json:"open_hour,omitempty"`
 	CloseHour float64 `spanner:"CloseHour" json:"close_hour,omitempty"`
 }
+
+// OpeningHourJSON is an opening hours row, Day is 0 for Sunday and the hours are decimal hours of the day.
+// CloseHour is after OpenHour, past 24 when the pharmacy closes the next day.
+type OpeningHourJSON struct {
+	Day       int64   `json:"day" validate:"min=0,max=6"`
+	OpenHour  float64 `json:"open_hour" validate:"min=0,max=24"`
+	CloseHour float64 `json:"close_hour" validate:"gtfield=OpenHour,max=48"`
+}
+
+// OpeningHoursJSON is the body replacing every opening hours row of a pharmacy, a row per day at most.
+type OpeningHoursJSON struct {
+	OpeningHours []OpeningHourJSON `json:"opening_hours" validate:"unique=Day,dive"`
+}
+
+type OpeningHoursV2 struct {
+	PharmacyID   string            `json:"pharmacy_id"`
+	OpeningHours []OpeningHourJSON `json:"opening_hours"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+// Problem is the RFC 7807 problem details body of an error.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	// Code is the stable machine readable code of the error, the same as the code of the /v2 envelope error
+	Code string `json:"code"`
+	// InvalidParams are the request fields failing to parse or validate
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+type InvalidParam struct {
+	// Name is the name of the field in the path, the query or the JSON body
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
@@ -11,21 +11,62 @@ type Product struct {
 	ProductID   []byte    `spanner:"ProductID" json:"product_id,omitempty" validate:"required,max=16"`
 	Name        string    `spanner:"Name" json:"name,omitempty" validate:"required"`
 	Price       float64   `spanner:"Price" json:"price,omitempty" validate:"required"`
+	Brand       string    `spanner:"Brand" json:"brand,omitempty"`
+	Color       string    `spanner:"Color" json:"color,omitempty"`
+	PackSize    int64     `spanner:"PackSize" json:"pack_size,omitempty"`
+	MaskID      []byte    `spanner:"MaskID" json:"mask_id,omitempty"`
 	CreatedTime time.Time `spanner:"CreatedTime" json:"created_time,omitempty"`
 }
 
 type ProductList struct {
 	entity.CommonListResponse
-	Products []*Product `spanner:"Products" json:"products,omitempty"`
+	Products      []*Product `spanner:"Products" json:"products,omitempty"`
+	NextPageToken string     `spanner:"-" json:"next_page_token,omitempty"`
 }
 
 type ProductItemJSON struct {
 	*Product
-	UID       string `json:"uid,omitempty"`
-	ProductID string `json:"product_id,omitempty"`
+	UID          string  `json:"uid,omitempty"`
+	ProductID    string  `json:"product_id,omitempty"`
+	MaskID       string  `json:"mask_id,omitempty"`
+	PricePerMask float64 `json:"price_per_mask,omitempty"`
 }
 
 type ProductListJSON struct {
 	entity.CommonListResponse
-	Products []*ProductItemJSON `json:"products,omitempty"`
+	Products      []*ProductItemJSON `json:"products,omitempty"`
+	NextPageToken string             `spanner:"-" json:"next_page_token,omitempty"`
+}
+
+type ProductItemV2 struct {
+	UID          string    `json:"uid"`
+	ProductID    string    `json:"product_id"`
+	Name         string    `json:"name"`
+	Price        float64   `json:"price"`
+	Brand        string    `json:"brand"`
+	Color        string    `json:"color"`
+	PackSize     int64     `json:"pack_size"`
+	MaskID       string    `json:"mask_id"`
+	CreatedTime  time.Time `json:"created_time"`
+	PricePerMask float64   `json:"price_per_mask"`
+}
+
+// ProductPutJSON is the body putting a product of a pharmacy, the brand, color and pack size are parsed from Name
+// when none of them is set.
+type ProductPutJSON struct {
+	Name     string  `json:"name" validate:"required"`
+	Price    float64 `json:"price" validate:"gt=0"`
+	Brand    string  `json:"brand"`
+	Color    string  `json:"color"`
+	PackSize int64   `json:"pack_size" validate:"min=0"`
+}
+
+type ProductPutV2 struct {
+	UID       string  `json:"uid"`
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name"`
+	Price     float64 `json:"price"`
+	Brand     string  `json:"brand"`
+	Color     string  `json:"color"`
+	PackSize  int64   `json:"pack_size"`
 }
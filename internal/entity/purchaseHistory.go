@@ -18,3 +18,20 @@ type PurchaseHistoryList struct {
 	entity.CommonListResponse
 	PurchaseHistories []*PurchaseHistory `spanner:"PurchaseHistories" json:"purchase_histories,omitempty"`
 }
+
+// PurchaseJSON is the body of a purchase, the buyer is the customer of the token and UserID is required of an admin
+// purchasing on behalf of a user only.
+type PurchaseJSON struct {
+	UserID     string `json:"user_id,omitempty" validate:"omitempty,uuid"`
+	PharmacyID string `json:"pharmacy_id,omitempty" validate:"required,uuid"`
+	ProductID  string `json:"product_id,omitempty" validate:"required,uuid"`
+	Quantity   int    `json:"quantity,omitempty" validate:"required,min=1"`
+}
+
+// PurchaseV2 is the purchase made by a /v2 PurchaseJSON.
+type PurchaseV2 struct {
+	UserID     string `json:"user_id"`
+	PharmacyID string `json:"pharmacy_id"`
+	ProductID  string `json:"product_id"`
+	Quantity   int    `json:"quantity"`
+}
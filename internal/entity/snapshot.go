@@ -0,0 +1,11 @@
+package entity
+
+// Snapshot holds every stored row of the tables the importer writes, read at the same timestamp.
+// The mask catalogue is left out since it is derived from the product names.
+type Snapshot struct {
+	Pharmacies        []*Pharmacy
+	PharmacyInfos     []*PharmacyInfo
+	Products          []*Product
+	Users             []*User
+	PurchaseHistories []*PurchaseHistory
+}
@@ -37,7 +37,71 @@ type TopTransactionAmountListJSON struct {
 	TopTransactionAmountUsers []*TopTransactionAmountUserJSON `json:"top_transaction_amount_users,omitempty"`
 }
 
+type TopTransactionAmountUserV2 struct {
+	UID               string  `json:"uid"`
+	Name              string  `json:"name"`
+	TransactionAmount float64 `json:"transaction_amount"`
+}
+
 type TransactionTotal struct {
 	Total             int64   `spanner:"Total" json:"total,omitempty"`
 	TransactionAmount float64 `spanner:"TransactionAmount" json:"transaction_amount,omitempty"`
 }
+
+type TransactionTotalV2 struct {
+	Total             int64   `json:"total"`
+	TransactionAmount float64 `json:"transaction_amount"`
+}
+
+// Credential is the login of a registered user, the imported users have none.
+// PRIMARY KEY(UID), INTERLEAVE IN PARENT User
+type Credential struct {
+	UID          []byte    `spanner:"UID" json:"uid,omitempty" validate:"required,max=16"`
+	Email        string    `spanner:"Email" json:"email,omitempty" validate:"required,email"`
+	PasswordHash string    `spanner:"PasswordHash" json:"-" validate:"required"`
+	CreatedTime  time.Time `spanner:"CreatedTime" json:"created_time,omitempty"`
+}
+
+// Profile is the user along with the email of its credential, empty for the imported users.
+type Profile struct {
+	UID         []byte    `spanner:"UID" json:"uid,omitempty"`
+	Name        string    `spanner:"Name" json:"name,omitempty"`
+	Email       string    `spanner:"Email" json:"email,omitempty"`
+	CashBalance float64   `spanner:"CashBalance" json:"cash_balance,omitempty"`
+	CreatedTime time.Time `spanner:"CreatedTime" json:"created_time,omitempty"`
+}
+
+type ProfileJSON struct {
+	*Profile
+	UID string `json:"uid,omitempty"`
+}
+
+type ProfileV2 struct {
+	UID         string    `json:"uid"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	CashBalance float64   `json:"cash_balance"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+// RegisterJSON is the body registering a user, bcrypt ignores the bytes of Password past 72.
+type RegisterJSON struct {
+	Name     string `json:"name" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginJSON struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type ProfilePutJSON struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// PasswordPutJSON is the body changing the password, Password is the current one.
+type PasswordPutJSON struct {
+	Password    string `json:"password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=72"`
+}
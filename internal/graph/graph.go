@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/timestamp"
+	"github.com/justdomepaul/toolbox/utils"
+	"phantom_mask/internal/auth"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strconv"
+	"time"
+)
+
+var (
+	GetNowTimestamp = timestamp.GetNowTimestamp
+)
+
+// schemaSDL connects the pharmacies, their opening hours and products, the masks, the users and their purchase histories.
+//
+//go:embed schema.graphql
+var schemaSDL string
+
+// Request is a GraphQL request as the JSON body of a POST.
+type Request struct {
+	Query         string                 `json:"query" validate:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// New parses the schema and resolves it over db.
+func New(db spannerDB.Set) (*Graph, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{db: db})
+	if err != nil {
+		return nil, err
+	}
+	return &Graph{
+		schema: schema,
+		db:     db,
+	}, nil
+}
+
+type Graph struct {
+	schema *graphql.Schema
+	db     spannerDB.Set
+}
+
+// Exec runs req for the bearer of claims with loaders of its own, the reads of the fields are batched within the
+// request only.
+func (g *Graph) Exec(ctx context.Context, claims *auth.Claims, req Request) *graphql.Response {
+	ctx = withClaims(withLoaders(ctx, newLoaders(g.db)), claims)
+	return g.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+type claimsKey struct{}
+
+func withClaims(ctx context.Context, claims *auth.Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// allowUser refuses the user uid, with its cash balance and purchase histories, unless the claims of the request are of
+// that customer or of an admin, as /user/v1/me does.
+func allowUser(ctx context.Context, uid []byte) error {
+	claims, _ := ctx.Value(claimsKey{}).(*auth.Claims)
+	if claims == nil {
+		return auth.ErrTokenRequired
+	}
+	return claims.AllowUser(utils.FromUUID(uid))
+}
+
+func parseID(id graphql.ID) ([]byte, error) {
+	uid, err := uuid.Parse(string(id))
+	if err != nil {
+		return nil, err
+	}
+	return uid[:], nil
+}
+
+// toTime converts t, leaving the zero time null.
+func toTime(t time.Time) *graphql.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: t}
+}
+
+// Timestamp is a utc0 millisecond timestamp, which overflows the 32 bits GraphQL Int.
+type Timestamp int64
+
+func (Timestamp) ImplementsGraphQLType(name string) bool {
+	return name == "Timestamp"
+}
+
+func (t *Timestamp) UnmarshalGraphQL(input interface{}) error {
+	switch value := input.(type) {
+	case int:
+		*t = Timestamp(value)
+	case int32:
+		*t = Timestamp(value)
+	case int64:
+		*t = Timestamp(value)
+	case float64:
+		*t = Timestamp(value)
+	case string:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+		}
+		*t = Timestamp(parsed)
+	default:
+		return fmt.Errorf("%w: wrong type for Timestamp: %T", errorhandler.ErrInvalidArguments, input)
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/justdomepaul/toolbox/entity"
+	"github.com/justdomepaul/toolbox/utils"
+	"github.com/stretchr/testify/suite"
+	"phantom_mask/internal/auth"
+	internalEntity "phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	testPharmacyIDs = []string{
+		"1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+		"2c5f39cb-3fb2-11d2-883f-0016d3cca427",
+		"3d6a4adc-4fc3-11d2-883f-0016d3cca427",
+	}
+	testUserIDs = []string{
+		"9a7b330a-a736-41e5-b1a5-6b51ac0a2a19",
+		"8b6c221b-b847-41e5-b1a5-6b51ac0a2a19",
+	}
+	testMaskID      = "6fa459ea-ee8a-3ca4-894e-db77e160355e"
+	testCreatedTime = time.Date(2022, 4, 15, 8, 30, 0, 0, time.UTC)
+)
+
+// counter keeps how many times each storage method is called.
+type counter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *counter) count(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[method]++
+}
+
+func productIDOf(pharmacyID string) []byte {
+	productID := utils.ParseUUID(pharmacyID)
+	productID[0] = 0xff
+	return productID
+}
+
+type fakePharmacy struct {
+	storage.IPharmacy
+	*counter
+}
+
+func (f fakePharmacy) ListSpecifyTime(ctx context.Context, row, page uint64, specifyTimestamp int64, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*internalEntity.PharmacySpecifyTimestampList, error) {
+	f.count("Pharmacy.ListSpecifyTime")
+	resp := &internalEntity.PharmacySpecifyTimestampList{
+		CommonListResponse: entity.CommonListResponse{Count: int64(len(testPharmacyIDs)), Row: int64(row), Page: int64(page)},
+	}
+	for _, id := range testPharmacyIDs {
+		resp.Pharmacies = append(resp.Pharmacies, &internalEntity.PharmacySpecifyTimestamp{
+			UID:         utils.ParseUUID(id),
+			Name:        "Pharmacy " + id[:2],
+			CashBalance: 100,
+			CreatedTime: testCreatedTime,
+		})
+	}
+	return resp, nil
+}
+
+func (f fakePharmacy) ListByIDs(ctx context.Context, pharmacyIDs [][]byte) ([]*internalEntity.Pharmacy, error) {
+	f.count("Pharmacy.ListByIDs")
+	var resp []*internalEntity.Pharmacy
+	for _, id := range pharmacyIDs {
+		resp = append(resp, &internalEntity.Pharmacy{
+			UID:         id,
+			Name:        "Pharmacy " + utils.FromUUID(id)[:2],
+			CashBalance: 100,
+		})
+	}
+	return resp, nil
+}
+
+type fakePharmacyInfo struct {
+	storage.IPharmacyInfo
+	*counter
+}
+
+func (f fakePharmacyInfo) ListByPharmacies(ctx context.Context, pharmacyIDs [][]byte) ([]*internalEntity.PharmacyInfo, error) {
+	f.count("PharmacyInfo.ListByPharmacies")
+	var resp []*internalEntity.PharmacyInfo
+	for _, id := range pharmacyIDs {
+		resp = append(resp,
+			&internalEntity.PharmacyInfo{UID: id, Day: 1, OpenHour: 8, CloseHour: 18},
+			&internalEntity.PharmacyInfo{UID: id, Day: 2, OpenHour: 8, CloseHour: 12},
+		)
+	}
+	return resp, nil
+}
+
+type fakeProduct struct {
+	storage.IProduct
+	*counter
+}
+
+func (f fakeProduct) product(uid, productID []byte) *internalEntity.Product {
+	return &internalEntity.Product{
+		UID:       uid,
+		ProductID: productID,
+		Name:      "Cotton Kiss (green) (3 per pack)",
+		Price:     9,
+		PackSize:  3,
+		MaskID:    utils.ParseUUID(testMaskID),
+	}
+}
+
+func (f fakeProduct) ListByPharmacies(ctx context.Context, pharmacyIDs [][]byte) ([]*internalEntity.Product, error) {
+	f.count("Product.ListByPharmacies")
+	var resp []*internalEntity.Product
+	for _, id := range pharmacyIDs {
+		resp = append(resp, f.product(id, productIDOf(utils.FromUUID(id))))
+	}
+	return resp, nil
+}
+
+func (f fakeProduct) ListByKeys(ctx context.Context, keys []storage.ProductKey) ([]*internalEntity.Product, error) {
+	f.count("Product.ListByKeys")
+	var resp []*internalEntity.Product
+	for _, key := range keys {
+		resp = append(resp, f.product(key.UID, key.ProductID))
+	}
+	return resp, nil
+}
+
+type fakeUser struct {
+	storage.IUser
+	*counter
+}
+
+func (f fakeUser) ListTopTransactionAmount(ctx context.Context, topNumber, startTime, endTime int64) (*internalEntity.TopTransactionAmountList, error) {
+	f.count("User.ListTopTransactionAmount")
+	resp := &internalEntity.TopTransactionAmountList{}
+	for _, id := range testUserIDs {
+		resp.TopTransactionAmountUsers = append(resp.TopTransactionAmountUsers, &internalEntity.TopTransactionAmountUser{
+			UID:               utils.ParseUUID(id),
+			TransactionAmount: 50,
+		})
+	}
+	return resp, nil
+}
+
+func (f fakeUser) ListByIDs(ctx context.Context, userIDs [][]byte) ([]*internalEntity.User, error) {
+	f.count("User.ListByIDs")
+	var resp []*internalEntity.User
+	for _, id := range userIDs {
+		resp = append(resp, &internalEntity.User{UID: id, Name: "User " + utils.FromUUID(id)[:2], CashBalance: 10})
+	}
+	return resp, nil
+}
+
+type fakePurchaseHistory struct {
+	storage.IPurchaseHistory
+	*counter
+}
+
+// ListByUsers lists a purchase from every pharmacy for each user.
+func (f fakePurchaseHistory) ListByUsers(ctx context.Context, userIDs [][]byte) ([]*internalEntity.PurchaseHistory, error) {
+	f.count("PurchaseHistory.ListByUsers")
+	var resp []*internalEntity.PurchaseHistory
+	for _, id := range userIDs {
+		for _, pharmacyID := range testPharmacyIDs {
+			resp = append(resp, &internalEntity.PurchaseHistory{
+				UID:               id,
+				PharmacyUID:       utils.ParseUUID(pharmacyID),
+				ProductID:         productIDOf(pharmacyID),
+				TransactionAmount: 9,
+				TransactionDate:   testCreatedTime,
+			})
+		}
+	}
+	return resp, nil
+}
+
+type fakeMask struct {
+	storage.IMask
+	*counter
+}
+
+func (f fakeMask) ListByIDs(ctx context.Context, maskIDs [][]byte) ([]*internalEntity.Mask, error) {
+	f.count("Mask.ListByIDs")
+	var resp []*internalEntity.Mask
+	for _, id := range maskIDs {
+		resp = append(resp, &internalEntity.Mask{MaskID: id, Name: "cotton kiss (green) (3 per pack)", PackSize: 3})
+	}
+	return resp, nil
+}
+
+type GraphSuite struct {
+	suite.Suite
+	ctx     context.Context
+	claims  *auth.Claims
+	counter *counter
+	graph   *Graph
+}
+
+func (suite *GraphSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.claims = auth.NewClaims(auth.RoleAdmin, "ops", "")
+	suite.counter = &counter{calls: map[string]int{}}
+	graph, err := New(spannerDB.Set{
+		Pharmacy:        fakePharmacy{counter: suite.counter},
+		PharmacyInfo:    fakePharmacyInfo{counter: suite.counter},
+		Product:         fakeProduct{counter: suite.counter},
+		User:            fakeUser{counter: suite.counter},
+		PurchaseHistory: fakePurchaseHistory{counter: suite.counter},
+		Mask:            fakeMask{counter: suite.counter},
+	})
+	suite.NoError(err)
+	suite.graph = graph
+}
+
+func (suite *GraphSuite) exec(query string, variables map[string]interface{}, data interface{}) {
+	resp := suite.graph.Exec(suite.ctx, suite.claims, Request{Query: query, Variables: variables})
+	suite.Empty(resp.Errors)
+	suite.NoError(json.Unmarshal(resp.Data, data))
+}
+
+func (suite *GraphSuite) TestNestedPharmacyFieldsAreBatched() {
+	var data struct {
+		Pharmacies struct {
+			Count      int
+			Pharmacies []struct {
+				ID           string
+				OpeningHours []struct{ Day int }
+				Products     []struct {
+					PricePerMask float64
+					Mask         struct{ Name string }
+					Pharmacy     struct{ Name string }
+				}
+			}
+		}
+	}
+	suite.exec(`query($openAt: Timestamp) {
+  pharmacies(openAt: $openAt, row: 5) {
+    count
+    pharmacies {
+      id
+      openingHours { day }
+      products { pricePerMask mask { name } pharmacy { name } }
+    }
+  }
+}`, map[string]interface{}{"openAt": 1665000000000}, &data)
+
+	suite.Equal(3, data.Pharmacies.Count)
+	suite.Len(data.Pharmacies.Pharmacies, 3)
+	for i, pharmacy := range data.Pharmacies.Pharmacies {
+		suite.Equal(testPharmacyIDs[i], pharmacy.ID)
+		suite.Len(pharmacy.OpeningHours, 2)
+		suite.Len(pharmacy.Products, 1)
+		suite.Equal(3.0, pharmacy.Products[0].PricePerMask)
+		suite.Equal("cotton kiss (green) (3 per pack)", pharmacy.Products[0].Mask.Name)
+		suite.Equal("Pharmacy "+testPharmacyIDs[i][:2], pharmacy.Products[0].Pharmacy.Name)
+	}
+	suite.Equal(map[string]int{
+		"Pharmacy.ListSpecifyTime":      1,
+		"PharmacyInfo.ListByPharmacies": 1,
+		"Product.ListByPharmacies":      1,
+		"Mask.ListByIDs":                1,
+		"Pharmacy.ListByIDs":            1,
+	}, suite.counter.calls)
+}
+
+func (suite *GraphSuite) TestNestedUserFieldsAreBatched() {
+	var data struct {
+		TopUsers []struct {
+			User struct {
+				Name              string
+				PurchaseHistories []struct {
+					Pharmacy struct{ ID string }
+					Product  struct{ Name string }
+					User     struct{ ID string }
+				}
+			}
+		}
+	}
+	suite.exec(`{
+  topUsers(range: {start: "0", end: "1665000000000"}) {
+    user {
+      name
+      purchaseHistories { pharmacy { id } product { name } user { id } }
+    }
+  }
+}`, nil, &data)
+
+	suite.Len(data.TopUsers, 2)
+	for i, topUser := range data.TopUsers {
+		suite.Equal("User "+testUserIDs[i][:2], topUser.User.Name)
+		suite.Len(topUser.User.PurchaseHistories, len(testPharmacyIDs))
+		for j, history := range topUser.User.PurchaseHistories {
+			suite.Equal(testPharmacyIDs[j], history.Pharmacy.ID)
+			suite.Equal("Cotton Kiss (green) (3 per pack)", history.Product.Name)
+			suite.Equal(testUserIDs[i], history.User.ID)
+		}
+	}
+	suite.Equal(map[string]int{
+		"User.ListTopTransactionAmount": 1,
+		"User.ListByIDs":                1,
+		"PurchaseHistory.ListByUsers":   1,
+		"Pharmacy.ListByIDs":            1,
+		"Product.ListByKeys":            1,
+	}, suite.counter.calls)
+}
+
+func (suite *GraphSuite) TestInvalidArguments() {
+	resp := suite.graph.Exec(suite.ctx, suite.claims, Request{Query: `{ pharmacy(id: "not-a-uuid") { name } }`})
+	suite.Len(resp.Errors, 1)
+	suite.Contains(resp.Errors[0].Message, "invalid")
+
+	resp = suite.graph.Exec(suite.ctx, suite.claims, Request{Query: `{ masks(page: 0) { count } }`})
+	suite.Len(resp.Errors, 1)
+	suite.Empty(suite.counter.calls)
+}
+
+func (suite *GraphSuite) TestUsersOfOthersAreRefused() {
+	query := `query($id: ID!) { user(id: $id) { cashBalance purchaseHistories { transactionAmount } } }`
+	testCases := []struct {
+		Label  string
+		Claims *auth.Claims
+		Error  error
+	}{
+		{Label: "Anonymous", Claims: nil, Error: auth.ErrTokenRequired},
+		{Label: "Another customer", Claims: auth.NewClaims(auth.RoleCustomer, testUserIDs[1], ""), Error: auth.ErrNotOwner},
+		{Label: "Pharmacy staff", Claims: auth.NewClaims(auth.RolePharmacyStaff, "staff", testPharmacyIDs[0]), Error: auth.ErrNotOwner},
+	}
+	for _, tc := range testCases {
+		suite.counter.calls = map[string]int{}
+		resp := suite.graph.Exec(suite.ctx, tc.Claims, Request{Query: query, Variables: map[string]interface{}{"id": testUserIDs[0]}})
+		suite.Require().Len(resp.Errors, 1, tc.Label)
+		suite.ErrorIs(resp.Errors[0].Err, tc.Error, tc.Label)
+		suite.JSONEq(`{"user":null}`, string(resp.Data), tc.Label)
+		suite.Empty(suite.counter.calls, tc.Label)
+	}
+
+	resp := suite.graph.Exec(suite.ctx, auth.NewClaims(auth.RoleCustomer, "some one", ""), Request{Query: `{
+  topUsers(range: {start: "0"}) { transactionAmount user { cashBalance } }
+}`})
+	suite.Len(resp.Errors, 2)
+	suite.NotContains(string(resp.Data), "cashBalance")
+	suite.Zero(suite.counter.calls["User.ListByIDs"])
+
+	var data struct {
+		User struct {
+			CashBalance       float64
+			PurchaseHistories []struct{ TransactionAmount float64 }
+		}
+	}
+	suite.claims = auth.NewClaims(auth.RoleCustomer, testUserIDs[0], "")
+	suite.exec(query, map[string]interface{}{"id": testUserIDs[0]}, &data)
+	suite.Len(data.User.PurchaseHistories, len(testPharmacyIDs))
+}
+
+func TestGraphSuite(t *testing.T) {
+	suite.Run(t, new(GraphSuite))
+}
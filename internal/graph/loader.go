@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Loader batches the reads of a request. Prime and Load queue their keys, the first Load finding queued keys fetches
+// all of them in a single call, so a list primes the keys of its items and their loads share one read. Every key is
+// fetched at most once per Loader, a key left out by the fetch loads as the zero value.
+type Loader[K comparable, V any] struct {
+	fetch   func(ctx context.Context, keys []K) (map[K]V, error)
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+}
+
+// NewLoader method
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		results: map[K]*result[V]{},
+	}
+}
+
+// Prime queues keys for the next fetch.
+func (l *Loader[K, V]) Prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		l.queue(key)
+	}
+}
+
+// Load returns the value of key, fetching it along with every queued key unless it is already fetched or being fetched.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r := l.queue(key)
+	batch := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(batch) != 0 {
+		l.dispatch(ctx, batch)
+	}
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) queue(key K) *result[V] {
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+	r := &result[V]{done: make(chan struct{})}
+	l.results[key] = r
+	l.pending = append(l.pending, key)
+	return r
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, keys []K) {
+	var (
+		values map[K]V
+		err    error
+	)
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for _, key := range keys {
+			r := l.results[key]
+			r.value, r.err = values[key], err
+			close(r.done)
+		}
+	}()
+	values, err = l.fetch(ctx, keys)
+}
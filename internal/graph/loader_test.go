@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type LoaderSuite struct {
+	suite.Suite
+	ctx     context.Context
+	mu      sync.Mutex
+	batches [][]string
+	loader  *Loader[string, string]
+}
+
+func (suite *LoaderSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.batches = nil
+	suite.loader = NewLoader(func(ctx context.Context, keys []string) (map[string]string, error) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+		sorted := append([]string{}, keys...)
+		sort.Strings(sorted)
+		suite.batches = append(suite.batches, sorted)
+		values := map[string]string{}
+		for _, key := range keys {
+			if key != "missing" {
+				values[key] = "value of " + key
+			}
+		}
+		return values, nil
+	})
+}
+
+func (suite *LoaderSuite) TestPrimedKeysShareOneFetch() {
+	suite.loader.Prime("a", "b", "c")
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			value, err := suite.loader.Load(suite.ctx, key)
+			suite.NoError(err)
+			suite.Equal("value of "+key, value)
+		}(key)
+	}
+	wg.Wait()
+	suite.Equal([][]string{{"a", "b", "c"}}, suite.batches)
+}
+
+func (suite *LoaderSuite) TestFetchedKeysAreCached() {
+	_, err := suite.loader.Load(suite.ctx, "a")
+	suite.NoError(err)
+	suite.loader.Prime("a")
+	_, err = suite.loader.Load(suite.ctx, "a")
+	suite.NoError(err)
+	value, err := suite.loader.Load(suite.ctx, "missing")
+	suite.NoError(err)
+	suite.Empty(value)
+	suite.Equal([][]string{{"a"}, {"missing"}}, suite.batches)
+}
+
+func (suite *LoaderSuite) TestFetchErrorReachesEveryLoad() {
+	errFetch := errors.New("fetch failed")
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]string, error) {
+		return nil, errFetch
+	})
+	loader.Prime("a", "b")
+	_, err := loader.Load(suite.ctx, "a")
+	suite.ErrorIs(err, errFetch)
+	_, err = loader.Load(suite.ctx, "b")
+	suite.ErrorIs(err, errFetch)
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderSuite))
+}
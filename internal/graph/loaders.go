@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"context"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+)
+
+// productKey is the comparable form of storage.ProductKey.
+type productKey struct {
+	UID       string
+	ProductID string
+}
+
+// loaders holds the Loader of every edge of the graph for a request, keyed by the string of the ids. A fetch primes the
+// loaders of the edges leaving what it read, so every level of a query reads each table once.
+type loaders struct {
+	pharmacy          *Loader[string, *entity.Pharmacy]
+	openingHours      *Loader[string, []*entity.PharmacyInfo]
+	products          *Loader[string, []*entity.Product]
+	product           *Loader[productKey, *entity.Product]
+	user              *Loader[string, *entity.User]
+	purchaseHistories *Loader[string, []*entity.PurchaseHistory]
+	mask              *Loader[string, *entity.Mask]
+}
+
+func newLoaders(db spannerDB.Set) *loaders {
+	l := &loaders{}
+	l.pharmacy = NewLoader(func(ctx context.Context, keys []string) (map[string]*entity.Pharmacy, error) {
+		items, err := db.Pharmacy.ListByIDs(ctx, toIDs(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]*entity.Pharmacy{}
+		for _, item := range items {
+			values[string(item.UID)] = item
+			l.primePharmacy(item.UID)
+		}
+		return values, nil
+	})
+	l.openingHours = NewLoader(func(ctx context.Context, keys []string) (map[string][]*entity.PharmacyInfo, error) {
+		items, err := db.PharmacyInfo.ListByPharmacies(ctx, toIDs(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string][]*entity.PharmacyInfo{}
+		for _, item := range items {
+			values[string(item.UID)] = append(values[string(item.UID)], item)
+		}
+		return values, nil
+	})
+	l.products = NewLoader(func(ctx context.Context, keys []string) (map[string][]*entity.Product, error) {
+		items, err := db.Product.ListByPharmacies(ctx, toIDs(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string][]*entity.Product{}
+		for _, item := range items {
+			values[string(item.UID)] = append(values[string(item.UID)], item)
+			l.primeProduct(item)
+		}
+		return values, nil
+	})
+	l.product = NewLoader(func(ctx context.Context, keys []productKey) (map[productKey]*entity.Product, error) {
+		productKeys := make([]storage.ProductKey, 0, len(keys))
+		for _, key := range keys {
+			productKeys = append(productKeys, storage.ProductKey{UID: []byte(key.UID), ProductID: []byte(key.ProductID)})
+		}
+		items, err := db.Product.ListByKeys(ctx, productKeys)
+		if err != nil {
+			return nil, err
+		}
+		values := map[productKey]*entity.Product{}
+		for _, item := range items {
+			values[productKey{UID: string(item.UID), ProductID: string(item.ProductID)}] = item
+			l.primeProduct(item)
+		}
+		return values, nil
+	})
+	l.user = NewLoader(func(ctx context.Context, keys []string) (map[string]*entity.User, error) {
+		items, err := db.User.ListByIDs(ctx, toIDs(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]*entity.User{}
+		for _, item := range items {
+			values[string(item.UID)] = item
+			l.primeUser(item.UID)
+		}
+		return values, nil
+	})
+	l.purchaseHistories = NewLoader(func(ctx context.Context, keys []string) (map[string][]*entity.PurchaseHistory, error) {
+		items, err := db.PurchaseHistory.ListByUsers(ctx, toIDs(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string][]*entity.PurchaseHistory{}
+		for _, item := range items {
+			values[string(item.UID)] = append(values[string(item.UID)], item)
+			l.primePurchaseHistory(item)
+		}
+		return values, nil
+	})
+	l.mask = NewLoader(func(ctx context.Context, keys []string) (map[string]*entity.Mask, error) {
+		items, err := db.Mask.ListByIDs(ctx, toIDs(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]*entity.Mask{}
+		for _, item := range items {
+			values[string(item.MaskID)] = item
+		}
+		return values, nil
+	})
+	return l
+}
+
+func (l *loaders) primePharmacy(uid []byte) {
+	l.openingHours.Prime(string(uid))
+	l.products.Prime(string(uid))
+}
+
+func (l *loaders) primeProduct(item *entity.Product) {
+	l.pharmacy.Prime(string(item.UID))
+	if len(item.MaskID) != 0 {
+		l.mask.Prime(string(item.MaskID))
+	}
+}
+
+func (l *loaders) primeUser(uid []byte) {
+	l.purchaseHistories.Prime(string(uid))
+}
+
+func (l *loaders) primePurchaseHistory(item *entity.PurchaseHistory) {
+	l.pharmacy.Prime(string(item.PharmacyUID))
+	l.product.Prime(productKey{UID: string(item.PharmacyUID), ProductID: string(item.ProductID)})
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func toIDs(keys []string) [][]byte {
+	ids := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, []byte(key))
+	}
+	return ids
+}
@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+)
+
+// resolver resolves the Query type, the lists it returns prime the loaders of their items.
+type resolver struct {
+	db spannerDB.Set
+}
+
+type timeRange struct {
+	Start Timestamp
+	End   *Timestamp
+}
+
+// bounds reads the start and end of the range, the end being now when unset.
+func (t timeRange) bounds() (int64, int64) {
+	if t.End == nil {
+		return int64(t.Start), GetNowTimestamp()
+	}
+	return int64(t.Start), int64(*t.End)
+}
+
+// listArgs checks page and row and reads the cursor of pageToken.
+func listArgs(page, row int32, pageToken *string) (uint64, uint64, storage.Cursor, error) {
+	if page < 1 || row < 1 {
+		return 0, 0, storage.Cursor{}, fmt.Errorf("%w: page and row start from 1", errorhandler.ErrInvalidArguments)
+	}
+	cursor := storage.Cursor{}
+	if pageToken != nil {
+		cursor.Token = *pageToken
+	}
+	return uint64(row), uint64(page), cursor, nil
+}
+
+func (r *resolver) Pharmacy(ctx context.Context, args struct{ ID graphql.ID }) (*pharmacyResolver, error) {
+	uid, err := parseID(args.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	return loadPharmacy(ctx, uid)
+}
+
+func (r *resolver) Pharmacies(ctx context.Context, args struct {
+	OpenAt    *Timestamp
+	Page      int32
+	Row       int32
+	PageToken *string
+}) (*pharmacyPageResolver, error) {
+	row, page, cursor, err := listArgs(args.Page, args.Row, args.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	openAt := GetNowTimestamp()
+	if args.OpenAt != nil {
+		openAt = int64(*args.OpenAt)
+	}
+	result, err := r.db.Pharmacy.ListSpecifyTime(ctx, row, page, openAt, storage.PharmacyNameASC, storage.PharmacyListCondition{}, cursor)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	resp := &pharmacyPageResolver{
+		pageInfo: pageInfo{count: result.Count, page: result.Page, row: result.Row, nextPageToken: result.NextPageToken},
+	}
+	for _, item := range result.Pharmacies {
+		l.primePharmacy(item.UID)
+		resp.pharmacies = append(resp.pharmacies, &pharmacyResolver{pharmacy: &entity.Pharmacy{
+			UID:         item.UID,
+			Name:        item.Name,
+			CashBalance: item.CashBalance,
+			CreatedTime: item.CreatedTime,
+		}})
+	}
+	return resp, nil
+}
+
+func (r *resolver) Search(ctx context.Context, args struct {
+	Name      string
+	Page      int32
+	Row       int32
+	PageToken *string
+}) (*searchPageResolver, error) {
+	row, page, cursor, err := listArgs(args.Page, args.Row, args.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.db.Pharmacy.ListPharmacyMixProduct(ctx, row, page, args.Name, storage.Relevance, storage.PharmacyListCondition{}, cursor)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	resp := &searchPageResolver{
+		pageInfo: pageInfo{count: result.Count, page: result.Page, row: result.Row, nextPageToken: result.NextPageToken},
+	}
+	for _, item := range result.PharmacyProducts {
+		l.pharmacy.Prime(string(item.UID))
+		l.product.Prime(productKey{UID: string(item.UID), ProductID: string(item.ProductID)})
+		resp.results = append(resp.results, &searchResultResolver{item: item})
+	}
+	return resp, nil
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	uid, err := parseID(args.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	return loadUser(ctx, uid)
+}
+
+func (r *resolver) Masks(ctx context.Context, args struct {
+	Page int32
+	Row  int32
+}) (*maskPageResolver, error) {
+	row, page, _, err := listArgs(args.Page, args.Row, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.db.Mask.List(ctx, row, page, storage.ProductNameASC)
+	if err != nil {
+		return nil, err
+	}
+	resp := &maskPageResolver{
+		pageInfo: pageInfo{count: result.Count, page: result.Page, row: result.Row},
+	}
+	for _, item := range result.Masks {
+		resp.masks = append(resp.masks, &maskResolver{mask: item})
+	}
+	return resp, nil
+}
+
+func (r *resolver) TopUsers(ctx context.Context, args struct {
+	Range timeRange
+	Top   int32
+}) ([]*topUserResolver, error) {
+	startTime, endTime := args.Range.bounds()
+	result, err := r.db.User.ListTopTransactionAmount(ctx, int64(args.Top), startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	resp := make([]*topUserResolver, 0, len(result.TopTransactionAmountUsers))
+	for _, item := range result.TopTransactionAmountUsers {
+		l.user.Prime(string(item.UID))
+		resp = append(resp, &topUserResolver{item: item})
+	}
+	return resp, nil
+}
+
+func (r *resolver) TransactionTotal(ctx context.Context, args struct{ Range timeRange }) (*transactionTotalResolver, error) {
+	startTime, endTime := args.Range.bounds()
+	result, err := r.db.User.GetTransactionTotal(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionTotalResolver{item: result}, nil
+}
+
+func (r *resolver) MaskTransactions(ctx context.Context, args struct{ Range timeRange }) ([]*maskTransactionResolver, error) {
+	startTime, endTime := args.Range.bounds()
+	result, err := r.db.Mask.ListTransactionAmount(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	resp := make([]*maskTransactionResolver, 0, len(result.MaskTransactions))
+	for _, item := range result.MaskTransactions {
+		l.mask.Prime(string(item.MaskID))
+		resp = append(resp, &maskTransactionResolver{item: item})
+	}
+	return resp, nil
+}
@@ -0,0 +1,275 @@
+package graph
+
+import (
+	"context"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/justdomepaul/toolbox/utils"
+	"phantom_mask/internal/entity"
+	internalUtils "phantom_mask/internal/utils"
+)
+
+func toID(uid []byte) graphql.ID {
+	return graphql.ID(utils.FromUUID(uid))
+}
+
+func loadPharmacy(ctx context.Context, uid []byte) (*pharmacyResolver, error) {
+	item, err := loadersFrom(ctx).pharmacy.Load(ctx, string(uid))
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return &pharmacyResolver{pharmacy: item}, nil
+}
+
+func loadProduct(ctx context.Context, uid, productID []byte) (*productResolver, error) {
+	item, err := loadersFrom(ctx).product.Load(ctx, productKey{UID: string(uid), ProductID: string(productID)})
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return &productResolver{product: item}, nil
+}
+
+func loadUser(ctx context.Context, uid []byte) (*userResolver, error) {
+	if err := allowUser(ctx, uid); err != nil {
+		return nil, err
+	}
+	item, err := loadersFrom(ctx).user.Load(ctx, string(uid))
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return &userResolver{user: item}, nil
+}
+
+func loadMask(ctx context.Context, maskID []byte) (*maskResolver, error) {
+	if len(maskID) == 0 {
+		return nil, nil
+	}
+	item, err := loadersFrom(ctx).mask.Load(ctx, string(maskID))
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return &maskResolver{mask: item}, nil
+}
+
+// pageInfo resolves the paging fields shared by the pages.
+type pageInfo struct {
+	count         int64
+	page          int64
+	row           int64
+	nextPageToken string
+}
+
+func (p pageInfo) Count() int32 { return int32(p.count) }
+
+func (p pageInfo) Page() int32 { return int32(p.page) }
+
+func (p pageInfo) Row() int32 { return int32(p.row) }
+
+func (p pageInfo) NextPageToken() *string {
+	if p.nextPageToken == "" {
+		return nil
+	}
+	return &p.nextPageToken
+}
+
+type pharmacyPageResolver struct {
+	pageInfo
+	pharmacies []*pharmacyResolver
+}
+
+func (r *pharmacyPageResolver) Pharmacies() []*pharmacyResolver { return r.pharmacies }
+
+type searchPageResolver struct {
+	pageInfo
+	results []*searchResultResolver
+}
+
+func (r *searchPageResolver) Results() []*searchResultResolver { return r.results }
+
+type maskPageResolver struct {
+	pageInfo
+	masks []*maskResolver
+}
+
+func (r *maskPageResolver) Masks() []*maskResolver { return r.masks }
+
+type pharmacyResolver struct {
+	pharmacy *entity.Pharmacy
+}
+
+func (r *pharmacyResolver) ID() graphql.ID { return toID(r.pharmacy.UID) }
+
+func (r *pharmacyResolver) Name() string { return r.pharmacy.Name }
+
+func (r *pharmacyResolver) CashBalance() float64 { return r.pharmacy.CashBalance }
+
+func (r *pharmacyResolver) CreatedTime() *graphql.Time { return toTime(r.pharmacy.CreatedTime) }
+
+func (r *pharmacyResolver) OpeningHours(ctx context.Context) ([]*openingHoursResolver, error) {
+	items, err := loadersFrom(ctx).openingHours.Load(ctx, string(r.pharmacy.UID))
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*openingHoursResolver, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, &openingHoursResolver{info: item})
+	}
+	return resp, nil
+}
+
+func (r *pharmacyResolver) Products(ctx context.Context) ([]*productResolver, error) {
+	items, err := loadersFrom(ctx).products.Load(ctx, string(r.pharmacy.UID))
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*productResolver, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, &productResolver{product: item})
+	}
+	return resp, nil
+}
+
+type openingHoursResolver struct {
+	info *entity.PharmacyInfo
+}
+
+func (r *openingHoursResolver) Day() int32 { return int32(r.info.Day) }
+
+func (r *openingHoursResolver) OpenHour() float64 { return r.info.OpenHour }
+
+func (r *openingHoursResolver) CloseHour() float64 { return r.info.CloseHour }
+
+type productResolver struct {
+	product *entity.Product
+}
+
+func (r *productResolver) ID() graphql.ID { return toID(r.product.ProductID) }
+
+func (r *productResolver) Name() string { return r.product.Name }
+
+func (r *productResolver) Price() float64 { return r.product.Price }
+
+func (r *productResolver) Brand() string { return r.product.Brand }
+
+func (r *productResolver) Color() string { return r.product.Color }
+
+func (r *productResolver) PackSize() int32 { return int32(r.product.PackSize) }
+
+func (r *productResolver) PricePerMask() float64 {
+	return internalUtils.PricePerMask(r.product.Price, r.product.PackSize)
+}
+
+func (r *productResolver) CreatedTime() *graphql.Time { return toTime(r.product.CreatedTime) }
+
+func (r *productResolver) Pharmacy(ctx context.Context) (*pharmacyResolver, error) {
+	return loadPharmacy(ctx, r.product.UID)
+}
+
+func (r *productResolver) Mask(ctx context.Context) (*maskResolver, error) {
+	return loadMask(ctx, r.product.MaskID)
+}
+
+type maskResolver struct {
+	mask *entity.Mask
+}
+
+func (r *maskResolver) ID() graphql.ID { return toID(r.mask.MaskID) }
+
+func (r *maskResolver) Name() string { return r.mask.Name }
+
+func (r *maskResolver) Brand() string { return r.mask.Brand }
+
+func (r *maskResolver) Color() string { return r.mask.Color }
+
+func (r *maskResolver) PackSize() int32 { return int32(r.mask.PackSize) }
+
+func (r *maskResolver) CreatedTime() *graphql.Time { return toTime(r.mask.CreatedTime) }
+
+type userResolver struct {
+	user *entity.User
+}
+
+func (r *userResolver) ID() graphql.ID { return toID(r.user.UID) }
+
+func (r *userResolver) Name() string { return r.user.Name }
+
+func (r *userResolver) CashBalance() float64 { return r.user.CashBalance }
+
+func (r *userResolver) CreatedTime() *graphql.Time { return toTime(r.user.CreatedTime) }
+
+func (r *userResolver) PurchaseHistories(ctx context.Context) ([]*purchaseHistoryResolver, error) {
+	items, err := loadersFrom(ctx).purchaseHistories.Load(ctx, string(r.user.UID))
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*purchaseHistoryResolver, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, &purchaseHistoryResolver{history: item})
+	}
+	return resp, nil
+}
+
+type purchaseHistoryResolver struct {
+	history *entity.PurchaseHistory
+}
+
+func (r *purchaseHistoryResolver) TransactionAmount() float64 { return r.history.TransactionAmount }
+
+func (r *purchaseHistoryResolver) TransactionDate() graphql.Time {
+	return graphql.Time{Time: r.history.TransactionDate}
+}
+
+func (r *purchaseHistoryResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.history.UID)
+}
+
+func (r *purchaseHistoryResolver) Pharmacy(ctx context.Context) (*pharmacyResolver, error) {
+	return loadPharmacy(ctx, r.history.PharmacyUID)
+}
+
+func (r *purchaseHistoryResolver) Product(ctx context.Context) (*productResolver, error) {
+	return loadProduct(ctx, r.history.PharmacyUID, r.history.ProductID)
+}
+
+type searchResultResolver struct {
+	item *entity.PharmacyProduct
+}
+
+func (r *searchResultResolver) Score() float64 { return r.item.Score }
+
+func (r *searchResultResolver) Pharmacy(ctx context.Context) (*pharmacyResolver, error) {
+	return loadPharmacy(ctx, r.item.UID)
+}
+
+func (r *searchResultResolver) Product(ctx context.Context) (*productResolver, error) {
+	return loadProduct(ctx, r.item.UID, r.item.ProductID)
+}
+
+type topUserResolver struct {
+	item *entity.TopTransactionAmountUser
+}
+
+func (r *topUserResolver) TransactionAmount() float64 { return r.item.TransactionAmount }
+
+func (r *topUserResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.item.UID)
+}
+
+type transactionTotalResolver struct {
+	item *entity.TransactionTotal
+}
+
+func (r *transactionTotalResolver) Total() int32 { return int32(r.item.Total) }
+
+func (r *transactionTotalResolver) TransactionAmount() float64 { return r.item.TransactionAmount }
+
+type maskTransactionResolver struct {
+	item *entity.MaskTransaction
+}
+
+func (r *maskTransactionResolver) Total() int32 { return int32(r.item.Total) }
+
+func (r *maskTransactionResolver) TransactionAmount() float64 { return r.item.TransactionAmount }
+
+func (r *maskTransactionResolver) Mask(ctx context.Context) (*maskResolver, error) {
+	return loadMask(ctx, r.item.MaskID)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/definition"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/utils"
+	"go.uber.org/zap"
+	"net/http"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/openapi"
+	"strings"
+)
+
+// guard authenticates the bearer token of the Authorization header of the request, and lets it through when the role
+// of the token is one of roles, or any role when roles is empty. The token is not read from the query, which ends up
+// in the access logs.
+func guard(issuer *auth.Issuer, roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := c.GetHeader(definition.AuthorizationKey)
+		if !strings.HasPrefix(authorization, definition.AuthorizationType) {
+			c.Header("WWW-Authenticate", auth.TokenType)
+			panic(errorhandler.NewErrAuthenticate(auth.ErrTokenRequired))
+		}
+		token := strings.TrimPrefix(authorization, definition.AuthorizationType)
+		if err := issuer.Verify(c, token); err != nil {
+			c.Header("WWW-Authenticate", auth.TokenType)
+			panic(err)
+		}
+		if len(roles) == 0 {
+			return
+		}
+		if err := auth.FromGin(c).Allow(roles...); err != nil {
+			panic(errorhandler.NewErrPermissionDeny(err))
+		}
+	}
+}
+
+// ownPharmacy lets the staff of the :PharmacyID pharmacy and the admins through, it runs after guard.
+func ownPharmacy() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := auth.FromGin(c).AllowPharmacy(c.Param("PharmacyID")); err != nil {
+			panic(errorhandler.NewErrPermissionDeny(err))
+		}
+	}
+}
+
+// buyer returns the user the purchase naming userID is made for, see auth.Claims.Buyer, it runs after guard.
+func buyer(c *gin.Context, userID string) string {
+	buyer, err := auth.FromGin(c).Buyer(userID)
+	switch {
+	case errors.Is(err, errorhandler.ErrUnauthenticated):
+		c.Header("WWW-Authenticate", auth.TokenType)
+		panic(errorhandler.NewErrAuthenticate(err))
+	case errors.Is(err, errorhandler.ErrNoPermission):
+		panic(errorhandler.NewErrPermissionDeny(err))
+	case err != nil:
+		panic(errorhandler.NewErrVariable(err))
+	}
+	return buyer
+}
+
+// customerID returns the user id of the customer token of the request, it runs after guard.
+func customerID(c *gin.Context) []byte {
+	userID, err := auth.FromGin(c).UserID()
+	if errors.Is(err, errorhandler.ErrNoPermission) {
+		panic(errorhandler.NewErrPermissionDeny(err))
+	}
+	if err != nil {
+		c.Header("WWW-Authenticate", auth.TokenType)
+		panic(errorhandler.NewErrAuthenticate(err))
+	}
+	return utils.ParseUUID(userID)
+}
+
+// roleDocs documents the roles a route is guarded with.
+func roleDocs(roles ...auth.Role) []string {
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, string(role))
+	}
+	return result
+}
+
+func NewAuth(
+	logger *zap.Logger,
+	issuer *auth.Issuer,
+) (*Auth, error) {
+	return &Auth{
+		logger: logger,
+		issuer: issuer,
+	}, nil
+}
+
+type Auth struct {
+	logger *zap.Logger
+	issuer *auth.Issuer
+}
+
+func (h *Auth) BindRoute(route *gin.Engine) {
+	adminGroup := route.Group("/auth")
+	{
+		v1Group := adminGroup.Group("/v1")
+		v1Group.POST("/token", guard(h.issuer, auth.RoleAdmin), h.IssueToken)
+		v1Group.POST("/token/refresh", guard(h.issuer), h.RefreshToken)
+
+		v2Group := adminGroup.Group("/v2", envelopeErrors())
+		v2Group.POST("/token", guard(h.issuer, auth.RoleAdmin), h.IssueTokenV2)
+		v2Group.POST("/token/refresh", guard(h.issuer), h.RefreshTokenV2)
+	}
+}
+
+// Docs documents the routes BindRoute registers.
+func (h *Auth) Docs() []openapi.Route {
+	routes := []openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     "/auth/v1/token",
+			Summary:  "Issue a token of a role, admin only",
+			Tag:      "auth",
+			Body:     entity.TokenJSON{},
+			Response: entity.Token{},
+			Errors: problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeInvalidUUID, CodeUnauthenticated,
+				CodePermissionDenied),
+			Roles: roleDocs(auth.RoleAdmin),
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/auth/v1/token/refresh",
+			Summary:  "Issue a token of the same claims as the valid bearer token, until 24 hours after the first of them was issued",
+			Tag:      "auth",
+			Response: entity.Token{},
+			Errors:   problemDocs(CodeUnauthenticated),
+			Roles:    roleDocs(auth.RoleCustomer, auth.RolePharmacyStaff, auth.RoleAdmin),
+		},
+	}
+	return append(routes,
+		asV2(routes[0], entity.Envelope[entity.Token]{}),
+		asV2(routes[1], entity.Envelope[entity.Token]{}),
+	)
+}
+
+// IssueToken issues a token of the role, subject and pharmacy of the body.
+func (h *Auth) IssueToken(c *gin.Context) {
+	c.JSON(http.StatusOK, h.issueToken(c))
+}
+
+// issueToken decodes the body of IssueToken and IssueTokenV2 and issues its token.
+func (h *Auth) issueToken(c *gin.Context) entity.Token {
+	req := entity.TokenJSON{}
+	bindJSON(c, &req)
+
+	token, err := h.issuer.Issue(auth.NewClaims(auth.Role(req.Role), req.Subject, req.PharmacyID))
+	if err != nil {
+		panic(errorhandler.NewErrServerExecute(err))
+	}
+	return token
+}
+
+// RefreshToken issues a token of the claims of the bearer token, expiring TokenTTL from now or auth.MaxTokenAge after
+// the first token of the claims was issued, whichever comes first.
+func (h *Auth) RefreshToken(c *gin.Context) {
+	c.JSON(http.StatusOK, h.refreshToken(c))
+}
+
+func (h *Auth) refreshToken(c *gin.Context) entity.Token {
+	token, err := h.issuer.Refresh(auth.FromGin(c))
+	if errors.Is(err, errorhandler.ErrUnauthenticated) {
+		c.Header("WWW-Authenticate", auth.TokenType)
+		panic(errorhandler.NewErrAuthenticate(err))
+	}
+	if err != nil {
+		panic(errorhandler.NewErrServerExecute(err))
+	}
+	return token
+}
+
+// IssueTokenV2 is IssueToken replying within an Envelope.
+func (h *Auth) IssueTokenV2(c *gin.Context) {
+	reply(c, h.issueToken(c))
+}
+
+// RefreshTokenV2 is RefreshToken replying within an Envelope.
+func (h *Auth) RefreshTokenV2(c *gin.Context) {
+	reply(c, h.refreshToken(c))
+}
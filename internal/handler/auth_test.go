@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/jwt"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/utils"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strings"
+	"testing"
+)
+
+func newTestIssuer() *auth.Issuer {
+	tool, err := jwt.NewEHS384JWT("test secret")
+	if err != nil {
+		panic(err)
+	}
+	return auth.NewIssuer(tool)
+}
+
+// otherPharmacyID is a pharmacy the pharmacy staff tokens of testPharmacyID do not manage.
+const otherPharmacyID = "00000000-0000-0000-0000-00000000000a"
+
+// bearer is the Authorization header of a token of role.
+func bearer(issuer *auth.Issuer, role auth.Role, subject, pharmacyID string) string {
+	token, err := issuer.Issue(auth.NewClaims(role, subject, pharmacyID))
+	if err != nil {
+		panic(err)
+	}
+	return auth.TokenType + " " + token.AccessToken
+}
+
+type fakePharmacyInfo struct {
+	storage.IPharmacyInfo
+	replaced *[]entity.PharmacyInfo
+}
+
+func (f fakePharmacyInfo) Replace(ctx context.Context, pharmacyID []byte, inputs []entity.PharmacyInfo) error {
+	*f.replaced = inputs
+	return nil
+}
+
+// fakePharmacyIDs knows the pharmacies of ids.
+type fakePharmacyIDs struct {
+	storage.IPharmacy
+	ids []string
+}
+
+func (f fakePharmacyIDs) ListByIDs(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.Pharmacy, error) {
+	var result []*entity.Pharmacy
+	for _, pharmacyID := range pharmacyIDs {
+		for _, id := range f.ids {
+			if utils.FromUUID(pharmacyID) == id {
+				result = append(result, &entity.Pharmacy{UID: pharmacyID})
+			}
+		}
+	}
+	return result, nil
+}
+
+type fakeProductUpsert struct {
+	fakeProduct
+	upserted *entity.Product
+}
+
+func (f fakeProductUpsert) Upsert(ctx context.Context, input entity.Product) error {
+	*f.upserted = input
+	return nil
+}
+
+type AuthSuite struct {
+	suite.Suite
+	route    *gin.Engine
+	issuer   *auth.Issuer
+	replaced []entity.PharmacyInfo
+	upserted entity.Product
+}
+
+func (suite *AuthSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.issuer = newTestIssuer()
+	suite.replaced = nil
+	suite.upserted = entity.Product{}
+	db := spannerDB.Set{
+		Mask:         fakeMask{},
+		Pharmacy:     fakePharmacyIDs{ids: []string{testPharmacyID, otherPharmacyID}},
+		PharmacyInfo: fakePharmacyInfo{replaced: &suite.replaced},
+		Product:      fakeProductUpsert{upserted: &suite.upserted},
+	}
+	reply := func(c *gin.Context) {}
+	suite.route = gin.New()
+	suite.route.Use(errorhandler.GinPanicErrorHandler("test", ""))
+	pharmacy, _ := NewPharmacy(nil, db, suite.issuer)
+	transaction, _ := NewTransaction(nil, db, suite.issuer)
+	authHandler, _ := NewAuth(nil, suite.issuer)
+	graphQL, _ := NewGraphQL(nil, db, suite.issuer)
+	AddRoutes(suite.route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, Set{
+		Pharmacy:    pharmacy,
+		Transaction: transaction,
+		GraphQL:     graphQL,
+		Auth:        authHandler,
+		User:        &User{},
+	})
+}
+
+func (suite *AuthSuite) request(method, url, authorization, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	suite.route.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *AuthSuite) TestGuard() {
+	hours := `{"opening_hours":[{"day":1,"open_hour":8,"close_hour":17.5}]}`
+	testCases := []struct {
+		Label         string
+		Method        string
+		URL           string
+		Authorization string
+		Body          string
+		Status        int
+		Code          string
+	}{
+		{Label: "Public read", Method: http.MethodGet, URL: "/pharmacy/v1/mask", Status: http.StatusOK},
+		{Label: "No token", Method: http.MethodPost, URL: "/transaction/v1/purchase", Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusUnauthorized, Code: CodeUnauthenticated},
+		{Label: "Token in the query", Method: http.MethodPost, URL: "/transaction/v1/purchase?tk=" + strings.TrimPrefix(bearer(suite.issuer, auth.RoleAdmin, "ops", ""), auth.TokenType+" "), Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusUnauthorized, Code: CodeUnauthenticated},
+		{Label: "Forged token", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: "Bearer forged", Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusUnauthorized, Code: CodeUnauthenticated},
+		{Label: "Customer purchases as themselves", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RoleCustomer, testUserID, ""), Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusOK},
+		{Label: "Customer purchases as another user", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RoleCustomer, "00000000-0000-0000-0000-00000000000b", ""), Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusForbidden, Code: CodePermissionDenied},
+		{Label: "Customer purchases as the token", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RoleCustomer, testUserID, ""), Body: purchaseBody("", "00000000-0000-0000-0000-000000000004"), Status: http.StatusOK},
+		{Label: "Customer token of no user", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RoleCustomer, "me", ""), Body: purchaseBody("", "00000000-0000-0000-0000-000000000004"), Status: http.StatusUnauthorized, Code: CodeUnauthenticated},
+		{Label: "Admin purchases for a user", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RoleAdmin, "ops", ""), Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusOK},
+		{Label: "Admin purchases for nobody", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RoleAdmin, "ops", ""), Body: purchaseBody("", "00000000-0000-0000-0000-000000000004"), Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Staff purchases", Method: http.MethodPost, URL: "/transaction/v1/purchase", Authorization: bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID), Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"), Status: http.StatusForbidden, Code: CodePermissionDenied},
+		{Label: "Staff replaces their hours", Method: http.MethodPut, URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Authorization: bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID), Body: hours, Status: http.StatusOK},
+		{Label: "Staff replaces the hours of another pharmacy", Method: http.MethodPut, URL: "/pharmacy/v1/" + otherPharmacyID + "/opening_hours", Authorization: bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID), Body: hours, Status: http.StatusForbidden, Code: CodePermissionDenied},
+		{Label: "Customer replaces hours", Method: http.MethodPut, URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Authorization: bearer(suite.issuer, auth.RoleCustomer, testUserID, ""), Body: hours, Status: http.StatusForbidden, Code: CodePermissionDenied},
+		{Label: "Admin replaces hours", Method: http.MethodPut, URL: "/pharmacy/v1/" + otherPharmacyID + "/opening_hours", Authorization: bearer(suite.issuer, auth.RoleAdmin, "ops", ""), Body: hours, Status: http.StatusOK},
+		{Label: "Invalid hours", Method: http.MethodPut, URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Authorization: bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID), Body: `{"opening_hours":[{"day":7}]}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Customer issues a token", Method: http.MethodPost, URL: "/auth/v1/token", Authorization: bearer(suite.issuer, auth.RoleCustomer, testUserID, ""), Body: `{"role":"admin","subject":"me"}`, Status: http.StatusForbidden, Code: CodePermissionDenied},
+	}
+	for _, testCase := range testCases {
+		suite.Run(testCase.Label, func() {
+			w := suite.request(testCase.Method, testCase.URL, testCase.Authorization, testCase.Body)
+			suite.Equal(testCase.Status, w.Code)
+			if testCase.Code == "" {
+				return
+			}
+			problem := entity.Problem{}
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+			suite.Equal(testCase.Code, problem.Code)
+			if testCase.Status == http.StatusUnauthorized {
+				suite.Equal(auth.TokenType, w.Header().Get("WWW-Authenticate"))
+			}
+
+			// v2 guards the same
+			w = suite.request(testCase.Method, strings.Replace(testCase.URL, "/v1/", "/v2/", 1), testCase.Authorization, testCase.Body)
+			suite.Equal(testCase.Status, w.Code)
+			resp := entity.Envelope[any]{}
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			suite.Require().NotNil(resp.Error)
+			suite.Equal(testCase.Code, resp.Error.Code)
+		})
+	}
+}
+
+func (suite *AuthSuite) TestGraphQLUser() {
+	body := `{"query":"{ user(id: \"` + testUserID + `\") { cashBalance purchaseHistories { transactionAmount } } }"}`
+
+	w := suite.request(http.MethodPost, GraphQLPath, "", body)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+	suite.Equal(auth.TokenType, w.Header().Get("WWW-Authenticate"))
+
+	w = suite.request(http.MethodPost, GraphQLPath, bearer(suite.issuer, auth.RoleCustomer, "00000000-0000-0000-0000-00000000000b", ""), body)
+	suite.Equal(http.StatusOK, w.Code)
+	resp := struct {
+		Data   map[string]interface{}
+		Errors []struct{ Message string }
+	}{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Nil(resp.Data["user"])
+	suite.Require().Len(resp.Errors, 1)
+	suite.Contains(resp.Errors[0].Message, auth.ErrNotOwner.Error())
+}
+
+func (suite *AuthSuite) TestPutOpeningHours() {
+	w := suite.request(http.MethodPut, "/pharmacy/v2/"+testPharmacyID+"/opening_hours",
+		bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID),
+		`{"opening_hours":[{"day":0,"open_hour":8,"close_hour":17.5},{"day":6,"open_hour":10,"close_hour":14}]}`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal([]entity.PharmacyInfo{
+		{Day: 0, OpenHour: 8, CloseHour: 17.5},
+		{Day: 6, OpenHour: 10, CloseHour: 14},
+	}, suite.replaced)
+	resp := entity.Envelope[entity.OpeningHoursV2]{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Equal(testPharmacyID, resp.Data.PharmacyID)
+	suite.Len(resp.Data.OpeningHours, 2)
+
+	testCases := []struct {
+		Label  string
+		URL    string
+		Body   string
+		Status int
+		Code   string
+	}{
+		{Label: "Overnight", URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Body: `{"opening_hours":[{"day":5,"open_hour":20,"close_hour":26}]}`, Status: http.StatusOK},
+		{Label: "Closing before opening", URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Body: `{"opening_hours":[{"day":5,"open_hour":20,"close_hour":8}]}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Closing past the next day", URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Body: `{"opening_hours":[{"day":5,"open_hour":20,"close_hour":50}]}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Duplicated day", URL: "/pharmacy/v1/" + testPharmacyID + "/opening_hours", Body: `{"opening_hours":[{"day":1,"open_hour":8,"close_hour":12},{"day":1,"open_hour":14,"close_hour":18}]}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Unknown pharmacy", URL: "/pharmacy/v1/00000000-0000-0000-0000-00000000000c/opening_hours", Body: `{"opening_hours":[]}`, Status: http.StatusNotFound, Code: CodePharmacyNotFound},
+	}
+	for _, testCase := range testCases {
+		suite.Run(testCase.Label, func() {
+			w := suite.request(http.MethodPut, testCase.URL, bearer(suite.issuer, auth.RoleAdmin, "ops", ""), testCase.Body)
+			suite.Equal(testCase.Status, w.Code)
+			if testCase.Code == "" {
+				return
+			}
+			problem := entity.Problem{}
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+			suite.Equal(testCase.Code, problem.Code)
+		})
+	}
+}
+
+func (suite *AuthSuite) TestPutProduct() {
+	productID := "00000000-0000-0000-0000-000000000005"
+	w := suite.request(http.MethodPut, "/pharmacy/v2/"+testPharmacyID+"/product/"+productID,
+		bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID),
+		`{"name":"True Barrier (green) (3 per pack)","price":13.7}`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("True Barrier (green) (3 per pack)", suite.upserted.Name)
+	resp := entity.Envelope[entity.ProductPutV2]{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Equal(entity.ProductPutV2{
+		UID:       testPharmacyID,
+		ProductID: productID,
+		Name:      "True Barrier (green) (3 per pack)",
+		Price:     13.7,
+		Brand:     suite.upserted.Brand,
+		Color:     suite.upserted.Color,
+		PackSize:  suite.upserted.PackSize,
+	}, resp.Data)
+	suite.Equal(int64(3), resp.Data.PackSize)
+
+	w = suite.request(http.MethodPut, "/pharmacy/v1/"+testPharmacyID+"/product/"+productID,
+		bearer(suite.issuer, auth.RolePharmacyStaff, "staff", testPharmacyID), `{"name":"free","price":0}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	suite.upserted = entity.Product{}
+	w = suite.request(http.MethodPut, "/pharmacy/v1/00000000-0000-0000-0000-00000000000c/product/"+productID,
+		bearer(suite.issuer, auth.RoleAdmin, "ops", ""), `{"name":"True Barrier (green) (3 per pack)","price":13.7}`)
+	suite.Equal(http.StatusNotFound, w.Code)
+	problem := entity.Problem{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+	suite.Equal(CodePharmacyNotFound, problem.Code)
+	suite.Empty(suite.upserted.Name)
+}
+
+func (suite *AuthSuite) TestIssueToken() {
+	w := suite.request(http.MethodPost, "/auth/v1/token", bearer(suite.issuer, auth.RoleAdmin, "ops", ""),
+		`{"role":"pharmacy_staff","subject":"staff","pharmacy_id":"`+testPharmacyID+`"}`)
+	suite.Equal(http.StatusOK, w.Code)
+	token := entity.Token{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &token))
+	suite.Equal(auth.TokenType, token.TokenType)
+	suite.Equal(int64(auth.TokenTTL.Seconds()), token.ExpiresIn)
+	claims, err := suite.issuer.Parse(token.AccessToken)
+	suite.Require().NoError(err)
+	suite.Equal(auth.RolePharmacyStaff, claims.Role)
+	suite.Equal("staff", claims.Subject)
+	suite.Equal(testPharmacyID, claims.PharmacyID)
+
+	// a staff token is of a pharmacy
+	w = suite.request(http.MethodPost, "/auth/v1/token", bearer(suite.issuer, auth.RoleAdmin, "ops", ""),
+		`{"role":"pharmacy_staff","subject":"staff"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	w = suite.request(http.MethodPost, "/auth/v2/token/refresh", auth.TokenType+" "+token.AccessToken, "")
+	suite.Equal(http.StatusOK, w.Code)
+	refreshed := entity.Envelope[entity.Token]{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &refreshed))
+	claims, err = suite.issuer.Parse(refreshed.Data.AccessToken)
+	suite.Require().NoError(err)
+	suite.Equal(auth.RolePharmacyStaff, claims.Role)
+	suite.Equal(testPharmacyID, claims.PharmacyID)
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// validate names the fields of its errors after their param, query or json tag.
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, key := range []string{"param", "query", "json"} {
+			if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+// bindError reports the request fields failing to parse or validate, it is ErrInvalidUUID when every one of them is
+// a malformed uuid and ErrInvalidArguments otherwise.
+type bindError struct {
+	params []entity.InvalidParam
+	uuid   bool
+}
+
+func (e *bindError) Error() string {
+	terms := make([]string, 0, len(e.params))
+	for _, param := range e.params {
+		terms = append(terms, param.Name+" "+param.Reason)
+	}
+	return strings.Join(terms, ", ")
+}
+
+func (e *bindError) Is(target error) bool {
+	if target == ErrInvalidUUID {
+		return e.uuid
+	}
+	return target == errorhandler.ErrInvalidArguments
+}
+
+func (e *bindError) add(name, reason string, uuid bool) {
+	e.uuid = (len(e.params) == 0 || e.uuid) && uuid
+	e.params = append(e.params, entity.InvalidParam{Name: name, Reason: reason})
+}
+
+// boundKey keeps the structs bind and bindJSON decoded the request to within the gin context, so the documentation of
+// the routes can be checked against them.
+const boundKey = "handler.bound"
+
+// bound is a struct the request was decoded to, from the body or from the path and query.
+type bound struct {
+	dst  interface{}
+	body bool
+}
+
+func addBound(c *gin.Context, dst interface{}, body bool) {
+	items, _ := c.Get(boundKey)
+	list, _ := items.([]bound)
+	c.Set(boundKey, append(list, bound{dst: dst, body: body}))
+}
+
+// bind sets the fields of dst tagged `param:"name"` from the path and `query:"name"` from the query, leaving the
+// fields absent from the request as dst has them, then validates dst with its `validate` tags.
+// Embedded structs are bound as well, a pointer field is allocated when present in the request only.
+func bind(c *gin.Context, dst interface{}) {
+	addBound(c, dst, false)
+	bindErr := &bindError{}
+	bindFields(c, reflect.ValueOf(dst).Elem(), bindErr)
+	if len(bindErr.params) == 0 {
+		validateFields(dst, bindErr)
+	}
+	if len(bindErr.params) > 0 {
+		panic(errorhandler.NewErrVariable(bindErr))
+	}
+}
+
+// bindJSON decodes the JSON body to dst and validates dst with its `validate` tags.
+func bindJSON(c *gin.Context, dst interface{}) {
+	addBound(c, dst, true)
+	defer c.Request.Body.Close()
+	if err := json.NewDecoder(c.Request.Body).Decode(dst); err != nil {
+		panic(errorhandler.NewErrJSONUnmarshal(err))
+	}
+	bindErr := &bindError{}
+	validateFields(dst, bindErr)
+	if len(bindErr.params) > 0 {
+		panic(errorhandler.NewErrVariable(bindErr))
+	}
+}
+
+func bindFields(c *gin.Context, value reflect.Value, bindErr *bindError) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			bindFields(c, value.Field(i), bindErr)
+			continue
+		}
+		var (
+			name  string
+			input string
+			ok    bool
+		)
+		if name = field.Tag.Get("param"); name != "" {
+			input = c.Param(name)
+			ok = input != ""
+		} else if name = field.Tag.Get("query"); name != "" {
+			input, ok = c.GetQuery(name)
+		}
+		if !ok {
+			continue
+		}
+		target := value.Field(i)
+		if target.Kind() == reflect.Pointer {
+			target.Set(reflect.New(field.Type.Elem()))
+			target = target.Elem()
+		}
+		if reason := setField(target, input); reason != "" {
+			bindErr.add(name, reason, false)
+		}
+	}
+}
+
+// setField parses input to field, returning why it fails to.
+func setField(field reflect.Value, input string) string {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(input)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(input)
+		if err != nil {
+			return "must be a boolean"
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(input, 0, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint64:
+		parsed, err := strconv.ParseUint(input, 0, 64)
+		if err != nil {
+			return "must be an unsigned integer"
+		}
+		field.SetUint(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		field.SetFloat(parsed)
+	default:
+		panic(errorhandler.NewErrServerExecute(fmt.Errorf("unsupported request field kind %s", field.Kind())))
+	}
+	return ""
+}
+
+func validateFields(dst interface{}, bindErr *bindError) {
+	err := validate.Struct(dst)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		if err != nil {
+			panic(errorhandler.NewErrVariable(err))
+		}
+		return
+	}
+	for _, fieldError := range fieldErrors {
+		bindErr.add(fieldError.Field(), validationReason(fieldError), fieldError.Tag() == "uuid")
+	}
+}
+
+func validationReason(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "uuid":
+		return "must be a UUID"
+	case "oneof":
+		return "must be one of " + fieldError.Param()
+	case "min":
+		return "must be at least " + fieldError.Param()
+	case "max":
+		return "must be at most " + fieldError.Param()
+	case "gtfield":
+		return "must be greater than " + fieldError.Param()
+	case "unique":
+		return "must not repeat " + fieldError.Param()
+	}
+	return "fails " + fieldError.Tag()
+}
+
+// pageQuery is the page and row query of the paginated lists.
+type pageQuery struct {
+	Page uint64 `query:"page" validate:"min=1"`
+	Row  uint64 `query:"row" validate:"min=1"`
+}
+
+func defaultPageQuery() pageQuery {
+	return pageQuery{Page: 1, Row: 10}
+}
+
+// cursorQuery is the page_token and with_count query of the keyset paginated lists.
+type cursorQuery struct {
+	PageToken string `query:"page_token"`
+	WithCount bool   `query:"with_count"`
+}
+
+func defaultCursorQuery() cursorQuery {
+	return cursorQuery{WithCount: true}
+}
+
+func (q cursorQuery) cursor() storage.Cursor {
+	return storage.Cursor{
+		Token:        q.PageToken,
+		WithoutCount: !q.WithCount,
+	}
+}
+
+// sortFilterQuery is the sort and filter query, restricted to the whitelisted fields of each list.
+type sortFilterQuery struct {
+	Sort   string `query:"sort"`
+	Filter string `query:"filter"`
+}
+
+// productFilterQuery is the brand, color and pack_size query narrowing the products of a list.
+type productFilterQuery struct {
+	Brand    string `query:"brand"`
+	Color    string `query:"color"`
+	PackSize *int64 `query:"pack_size"`
+}
+
+// timeRangeQuery is the date range of the transaction reports, ending now by default.
+type timeRangeQuery struct {
+	StartTime int64 `query:"utc0_millisecond_start_timestamp"`
+	EndTime   int64 `query:"utc0_millisecond_end_timestamp"`
+}
+
+func defaultTimeRangeQuery() timeRangeQuery {
+	return timeRangeQuery{EndTime: GetNowTimestamp()}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/entity"
+	"strings"
+	"testing"
+)
+
+type bindTestQuery struct {
+	pageQuery
+	ID       string   `param:"ID" validate:"required,uuid"`
+	Sorted   string   `query:"sorted" validate:"oneof=name price"`
+	Ratio    float64  `query:"ratio"`
+	OpenOnly bool     `query:"open_only"`
+	PackSize *int64   `query:"pack_size"`
+	Price    *float64 `query:"price"`
+}
+
+type BindSuite struct {
+	suite.Suite
+	route *gin.Engine
+	bound bindTestQuery
+}
+
+func (suite *BindSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.route = gin.New()
+	suite.route.Use(problemErrors())
+	suite.route.GET("/item/:ID", func(c *gin.Context) {
+		suite.bound = bindTestQuery{pageQuery: defaultPageQuery(), Sorted: "name"}
+		bind(c, &suite.bound)
+	})
+	suite.route.POST("/item", func(c *gin.Context) {
+		bindJSON(c, &entity.PurchaseJSON{})
+	})
+}
+
+func (suite *BindSuite) serve(method, url, body string) (int, entity.Problem) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	suite.route.ServeHTTP(w, req)
+	problem := entity.Problem{}
+	if w.Body.Len() > 0 {
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+	}
+	return w.Code, problem
+}
+
+func (suite *BindSuite) TestBind() {
+	status, _ := suite.serve(http.MethodGet, "/item/"+testUserID+"?row=5&ratio=0.5&open_only=true&pack_size=3", "")
+	suite.Equal(http.StatusOK, status)
+	packSize := int64(3)
+	suite.Equal(bindTestQuery{
+		pageQuery: pageQuery{Page: 1, Row: 5},
+		ID:        testUserID,
+		Sorted:    "name",
+		Ratio:     0.5,
+		OpenOnly:  true,
+		PackSize:  &packSize,
+	}, suite.bound)
+}
+
+func (suite *BindSuite) TestInvalidParams() {
+	testCases := []struct {
+		Label  string
+		Method string
+		URL    string
+		Body   string
+		Code   string
+		Params []entity.InvalidParam
+	}{
+		{
+			Label:  "Unparsable query",
+			Method: http.MethodGet,
+			URL:    "/item/" + testUserID + "?page=first&open_only=maybe&price=free",
+			Code:   CodeInvalidArgument,
+			Params: []entity.InvalidParam{
+				{Name: "page", Reason: "must be an unsigned integer"},
+				{Name: "open_only", Reason: "must be a boolean"},
+				{Name: "price", Reason: "must be a number"},
+			},
+		},
+		{
+			Label:  "Invalid query",
+			Method: http.MethodGet,
+			URL:    "/item/" + testUserID + "?row=0&sorted=score",
+			Code:   CodeInvalidArgument,
+			Params: []entity.InvalidParam{
+				{Name: "row", Reason: "must be at least 1"},
+				{Name: "sorted", Reason: "must be one of name price"},
+			},
+		},
+		{
+			Label:  "Malformed path uuid",
+			Method: http.MethodGet,
+			URL:    "/item/1b4e28ba-2fa1",
+			Code:   CodeInvalidUUID,
+			Params: []entity.InvalidParam{{Name: "ID", Reason: "must be a UUID"}},
+		},
+		{
+			Label:  "Malformed body uuid",
+			Method: http.MethodPost,
+			URL:    "/item",
+			Body:   `{"user_id":"` + strings.ToUpper(testUserID) + `","pharmacy_id":"` + testPharmacyID + `","product_id":"x","quantity":1}`,
+			Code:   CodeInvalidUUID,
+			Params: []entity.InvalidParam{
+				{Name: "user_id", Reason: "must be a UUID"},
+				{Name: "product_id", Reason: "must be a UUID"},
+			},
+		},
+		{
+			Label:  "Missing body fields",
+			Method: http.MethodPost,
+			URL:    "/item",
+			Body:   `{"user_id":"x"}`,
+			Code:   CodeInvalidArgument,
+			Params: []entity.InvalidParam{
+				{Name: "user_id", Reason: "must be a UUID"},
+				{Name: "pharmacy_id", Reason: "is required"},
+				{Name: "product_id", Reason: "is required"},
+				{Name: "quantity", Reason: "is required"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		suite.Run(testCase.Label, func() {
+			status, problem := suite.serve(testCase.Method, testCase.URL, testCase.Body)
+			suite.Equal(http.StatusBadRequest, status)
+			suite.Equal(testCase.Code, problem.Code)
+			suite.Equal(testCase.Params, problem.InvalidParams)
+		})
+	}
+}
+
+func (suite *BindSuite) TestBindErrorIs() {
+	bindErr := &bindError{}
+	bindErr.add("user_id", "must be a UUID", true)
+	suite.ErrorIs(bindErr, ErrInvalidUUID)
+	suite.ErrorIs(bindErr, errorhandler.ErrInvalidArguments)
+	bindErr.add("quantity", "is required", false)
+	suite.NotErrorIs(bindErr, ErrInvalidUUID)
+	suite.ErrorIs(bindErr, errorhandler.ErrInvalidArguments)
+}
+
+func TestBindSuite(t *testing.T) {
+	suite.Run(t, new(BindSuite))
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/entity"
+	"net/http"
+	internalEntity "phantom_mask/internal/entity"
+	"phantom_mask/internal/openapi"
+	"strings"
+)
+
+// envelopeErrors replies the errors the /v2 handlers panic with within an Envelope, with the status and code of their
+// problem.
+func envelopeErrors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer recoverProblem(c, func(problem internalEntity.Problem) {
+			c.JSON(problem.Status, internalEntity.Envelope[any]{
+				Error: &internalEntity.EnvelopeError{
+					Code:          problem.Code,
+					Message:       problem.Detail,
+					InvalidParams: problem.InvalidParams,
+				},
+			})
+		})
+		c.Next()
+	}
+}
+
+func reply[T any](c *gin.Context, data T) {
+	c.JSON(http.StatusOK, internalEntity.Envelope[T]{Data: data})
+}
+
+// replyPage replies the page of a list, data being an empty list rather than null when there is no row.
+func replyPage[T any](c *gin.Context, data []T, page entity.CommonListResponse, nextPageToken string) {
+	if data == nil {
+		data = []T{}
+	}
+	c.JSON(http.StatusOK, internalEntity.Envelope[[]T]{
+		Data: data,
+		Pagination: &internalEntity.Pagination{
+			Count:         page.Count,
+			Row:           page.Row,
+			Page:          page.Page,
+			NextPageToken: nextPageToken,
+		},
+	})
+}
+
+// asV2 documents the /v2 counterpart of the /v1 route, replying response and its errors within an Envelope.
+func asV2(route openapi.Route, response interface{}) openapi.Route {
+	route.Path = strings.Replace(route.Path, "/v1/", "/v2/", 1)
+	route.Response = response
+	errs := make([]openapi.ErrorResponse, 0, len(route.Errors))
+	for _, item := range route.Errors {
+		item.ContentType = ""
+		item.Body = internalEntity.Envelope[any]{}
+		errs = append(errs, item)
+	}
+	route.Errors = errs
+	return route
+}
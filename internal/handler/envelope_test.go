@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/entity"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	internalEntity "phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"testing"
+)
+
+type fakeMask struct {
+	storage.IMask
+}
+
+// List lists no mask, the zero values must still be replied.
+func (f fakeMask) List(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum) (*internalEntity.MaskList, error) {
+	if page > 100 {
+		return nil, errors.New("spanner unavailable")
+	}
+	return &internalEntity.MaskList{
+		CommonListResponse: entity.CommonListResponse{Row: int64(row), Page: int64(page)},
+	}, nil
+}
+
+type fakeUser struct {
+	storage.IUser
+}
+
+func (f fakeUser) GetTransactionTotal(ctx context.Context, startTime, endTime int64) (*internalEntity.TransactionTotal, error) {
+	return &internalEntity.TransactionTotal{}, nil
+}
+
+type EnvelopeSuite struct {
+	suite.Suite
+	route *gin.Engine
+}
+
+func (suite *EnvelopeSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	db := spannerDB.Set{Mask: fakeMask{}, User: fakeUser{}}
+	reply := func(c *gin.Context) {}
+	suite.route = gin.New()
+	suite.route.Use(errorhandler.GinPanicErrorHandler("test", ""))
+	pharmacy, _ := NewPharmacy(nil, db, newTestIssuer())
+	transaction, _ := NewTransaction(nil, db, newTestIssuer())
+	AddRoutes(suite.route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, Set{
+		Pharmacy:    pharmacy,
+		Transaction: transaction,
+		GraphQL:     &GraphQL{},
+		Auth:        &Auth{},
+		User:        &User{},
+	})
+}
+
+func (suite *EnvelopeSuite) get(url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	suite.route.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *EnvelopeSuite) TestList() {
+	w := suite.get("/pharmacy/v2/mask?page=2&row=5")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{
+  "data": [],
+  "pagination": {"count": 0, "row": 5, "page": 2, "next_page_token": ""},
+  "error": null
+}`, w.Body.String())
+
+	// v1 is replied as before
+	w = suite.get("/pharmacy/v1/mask?page=2&row=5")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"Row": 5, "Page": 2}`, w.Body.String())
+}
+
+func (suite *EnvelopeSuite) TestZeroValues() {
+	w := suite.get("/transaction/v2/transaction/product")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"data": {"total": 0, "transaction_amount": 0}, "pagination": null, "error": null}`, w.Body.String())
+}
+
+func (suite *EnvelopeSuite) TestError() {
+	testCases := []struct {
+		Label  string
+		URL    string
+		Status int
+		Code   string
+	}{
+		{Label: "Invalid argument", URL: "/pharmacy/v2/mask?page=first", Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Storage failure", URL: "/pharmacy/v2/mask?page=101", Status: http.StatusInternalServerError, Code: CodeStorageFailure},
+	}
+	for _, testCase := range testCases {
+		suite.Run(testCase.Label, func() {
+			w := suite.get(testCase.URL)
+			suite.Equal(testCase.Status, w.Code)
+			suite.Contains(w.Header().Get("Content-Type"), "application/json")
+			resp := internalEntity.Envelope[any]{}
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			suite.Nil(resp.Data)
+			suite.Nil(resp.Pagination)
+			suite.Require().NotNil(resp.Error)
+			suite.Equal(testCase.Code, resp.Error.Code)
+			suite.NotEmpty(resp.Error.Message)
+		})
+	}
+}
+
+func TestEnvelopeSuite(t *testing.T) {
+	suite.Run(t, new(EnvelopeSuite))
+}
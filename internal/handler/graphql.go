@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+	"net/http"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/graph"
+	"phantom_mask/internal/openapi"
+	spannerDB "phantom_mask/internal/storage/spanner"
+)
+
+const GraphQLPath = "/graphql"
+
+func NewGraphQL(
+	logger *zap.Logger,
+	db spannerDB.Set,
+	issuer *auth.Issuer,
+) (*GraphQL, error) {
+	schema, err := graph.New(db)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphQL{
+		logger: logger,
+		graph:  schema,
+		issuer: issuer,
+	}, nil
+}
+
+type GraphQL struct {
+	logger *zap.Logger
+	graph  *graph.Graph
+	issuer *auth.Issuer
+}
+
+func (h *GraphQL) BindRoute(route *gin.Engine) {
+	route.POST(GraphQLPath, guard(h.issuer), h.Query)
+}
+
+// Docs documents the routes BindRoute registers.
+func (h *GraphQL) Docs() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     GraphQLPath,
+			Summary:  "Query pharmacies, opening hours, products, masks, users, purchase histories and reports as a graph",
+			Tag:      "graphql",
+			Body:     graph.Request{},
+			Response: graphql.Response{},
+			Errors:   problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeUnauthenticated),
+			Roles:    roleDocs(auth.RoleCustomer, auth.RolePharmacyStaff, auth.RoleAdmin),
+		},
+	}
+}
+
+// Query runs a GraphQL request for the bearer of the token, the errors of its fields, including the users other than
+// the customer of the token unless an admin asks, are reported within the response as GraphQL does.
+func (h *GraphQL) Query(c *gin.Context) {
+	req := graph.Request{}
+	bindJSON(c, &req)
+	c.JSON(http.StatusOK, h.graph.Exec(c.Request.Context(), auth.FromGin(c), req))
+}
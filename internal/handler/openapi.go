@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"embed"
+	"github.com/gin-gonic/gin"
+	"io/fs"
+	"net/http"
+	"phantom_mask/internal/openapi"
+	"phantom_mask/internal/storage"
+	"sort"
+	"strings"
+)
+
+const (
+	OpenAPIPath       = "/openapi.json"
+	SwaggerPath       = "/swagger"
+	SwaggerAssetsPath = "/swagger/assets"
+)
+
+// swaggerHTML is the Swagger UI page rendering OpenAPIPath.
+//
+//go:embed swagger.html
+var swaggerHTML []byte
+
+// swaggerAssets are the js and css of swagger-ui-dist 4.15.5 (Apache-2.0) the page loads from SwaggerAssetsPath, so
+// the UI works without reaching a CDN.
+//
+//go:embed swagger
+var swaggerAssets embed.FS
+
+// NewOpenAPI documents every route AddRoutes registers except the documentation ones.
+func NewOpenAPI(handlers Set) *openapi.Document {
+	routes := []openapi.Route{
+		{Method: http.MethodGet, Path: "/ping", Summary: "Health check", Tag: "common", Response: ""},
+		{Method: http.MethodGet, Path: "/metrics", Summary: "Prometheus metrics", Tag: "common", Response: ""},
+	}
+	routes = append(routes, handlers.Pharmacy.Docs()...)
+	routes = append(routes, handlers.Transaction.Docs()...)
+	routes = append(routes, handlers.GraphQL.Docs()...)
+	routes = append(routes, handlers.Auth.Docs()...)
+	routes = append(routes, handlers.User.Docs()...)
+	return openapi.New("Phantom Mask API", "1.0.0", routes...)
+}
+
+func bindOpenAPI(route *gin.Engine, doc *openapi.Document) {
+	route.GET(OpenAPIPath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	})
+	route.GET(SwaggerPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerHTML)
+	})
+	assets, _ := fs.Sub(swaggerAssets, "swagger")
+	route.StaticFS(SwaggerAssetsPath, http.FS(assets))
+}
+
+// pageParams documents pageQuery.
+func pageParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("page", "page number, from 1", uint64(1)),
+		openapi.QueryParam("row", "rows per page", uint64(10)),
+	}
+}
+
+// cursorParams documents cursorQuery.
+func cursorParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("page_token", "next_page_token of the previous page, continues right after it and ignores page", ""),
+		openapi.QueryParam("with_count", "false leaves Count out instead of counting every matching row", true),
+	}
+}
+
+// sortFilterParams documents sortFilterQuery.
+func sortFilterParams(sortFields, filterFields map[string]storage.ListField) []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("sort", "comma separated fields, - prefixed for descending, among "+fieldNames(sortFields), ""),
+		openapi.QueryParam("filter", "comma separated \"field operator value\" terms, operator among = != > >= < <= ~, field among "+fieldNames(filterFields), ""),
+	}
+}
+
+func fieldNames(fields map[string]storage.ListField) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func joinParams(groups ...[]openapi.Parameter) []openapi.Parameter {
+	var result []openapi.Parameter
+	for _, group := range groups {
+		result = append(result, group...)
+	}
+	return result
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/openapi"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type OpenAPISuite struct {
+	suite.Suite
+	route    *gin.Engine
+	handlers Set
+}
+
+func (suite *OpenAPISuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	reply := func(c *gin.Context) {}
+	suite.route = gin.New()
+	suite.handlers = Set{Pharmacy: &Pharmacy{}, Transaction: &Transaction{}, GraphQL: &GraphQL{}, Auth: &Auth{}, User: &User{}}
+	AddRoutes(suite.route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, suite.handlers)
+}
+
+// TestRoutesMatchSpec fails when a route is registered without being documented or documented without being registered.
+func (suite *OpenAPISuite) TestRoutesMatchSpec() {
+	registered := map[string]bool{}
+	for _, route := range suite.route.Routes() {
+		if route.Path == OpenAPIPath || route.Path == SwaggerPath || strings.HasPrefix(route.Path, SwaggerAssetsPath) {
+			continue
+		}
+		registered[route.Method+" "+openapi.Path(route.Path)] = true
+	}
+	documented := map[string]bool{}
+	for path, item := range NewOpenAPI(suite.handlers).Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	suite.Equal(registered, documented)
+}
+
+// boundFields are the fields of the structs a request is bound to.
+type boundFields struct {
+	query map[string]bool
+	param map[string]bool
+	body  []reflect.Type
+	// invalid is whether a field is validated or parsed from text, so the request may be refused as invalid_argument
+	invalid bool
+	uuid    bool
+}
+
+func (f *boundFields) add(t reflect.Type, fromText bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, tagged := field.Tag.Get("query"), false
+		if name != "" {
+			f.query[name], tagged = true, true
+		}
+		if name = field.Tag.Get("param"); name != "" {
+			f.param[name], tagged = true, true
+		}
+		kind := field.Type.Kind()
+		if kind == reflect.Pointer {
+			kind = field.Type.Elem().Kind()
+		}
+		if validate := field.Tag.Get("validate"); validate != "" && validate != "-" {
+			f.invalid = true
+			f.uuid = f.uuid || strings.Contains(validate, "uuid")
+		}
+		if fromText && tagged && kind != reflect.String {
+			f.invalid = true
+		}
+		if field.Anonymous || !fromText {
+			f.add(field.Type, fromText)
+		}
+	}
+}
+
+// codes returns the problem codes f may be refused with.
+func (f *boundFields) codes() []string {
+	var codes []string
+	if len(f.body) > 0 {
+		codes = append(codes, CodeMalformedBody)
+	}
+	if f.invalid {
+		codes = append(codes, CodeInvalidArgument)
+	}
+	if f.uuid {
+		codes = append(codes, CodeInvalidUUID)
+	}
+	return codes
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TestRoutesMatchBinding calls every documented route and fails when its query parameters, path parameters and body
+// differ from the structs the handler binds the request to, or when its errors leave out a problem the binding replies.
+func (suite *OpenAPISuite) TestRoutesMatchBinding() {
+	issuer := newTestIssuer()
+	db := spannerDB.Set{}
+	pharmacy, _ := NewPharmacy(nil, db, issuer)
+	transaction, _ := NewTransaction(nil, db, issuer)
+	graphQL, _ := NewGraphQL(nil, db, issuer)
+	authHandler, _ := NewAuth(nil, issuer)
+	user, _ := NewUser(nil, db, issuer)
+	handlers := Set{Pharmacy: pharmacy, Transaction: transaction, GraphQL: graphQL, Auth: authHandler, User: user}
+
+	var fields *boundFields
+	route := gin.New()
+	route.Use(func(c *gin.Context) {
+		c.Next()
+		items, _ := c.Get(boundKey)
+		list, _ := items.([]bound)
+		for _, item := range list {
+			if item.body {
+				fields.body = append(fields.body, reflect.TypeOf(item.dst).Elem())
+			}
+			fields.add(reflect.TypeOf(item.dst), !item.body)
+		}
+	})
+	route.Use(errorhandler.GinPanicErrorHandler("test", ""))
+	reply := func(c *gin.Context) {}
+	AddRoutes(route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, handlers)
+
+	var routes []openapi.Route
+	for _, docs := range [][]openapi.Route{pharmacy.Docs(), transaction.Docs(), graphQL.Docs(), authHandler.Docs(), user.Docs()} {
+		routes = append(routes, docs...)
+	}
+	for _, doc := range routes {
+		label := doc.Method + " " + doc.Path
+		fields = &boundFields{query: map[string]bool{}, param: map[string]bool{}}
+		segments := strings.Split(doc.Path, "/")
+		documentedParams := map[string]bool{}
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				documentedParams[segment[1:]] = true
+				segments[i] = testPharmacyID
+			}
+		}
+		body := ""
+		if doc.Body != nil {
+			body = "{}"
+		}
+		req, _ := http.NewRequest(doc.Method, strings.Join(segments, "/"), strings.NewReader(body))
+		if len(doc.Roles) > 0 {
+			req.Header.Set("Authorization", bearer(issuer, auth.Role(doc.Roles[0]), testUserID, testPharmacyID))
+		}
+		route.ServeHTTP(httptest.NewRecorder(), req)
+
+		documentedQuery := map[string]bool{}
+		for _, param := range doc.Query {
+			documentedQuery[param.Name] = true
+		}
+		suite.Equal(sortedKeys(documentedQuery), sortedKeys(fields.query), label)
+		suite.Equal(sortedKeys(documentedParams), sortedKeys(fields.param), label)
+		if doc.Body == nil {
+			suite.Empty(fields.body, label)
+		} else {
+			suite.Equal([]reflect.Type{reflect.TypeOf(doc.Body)}, fields.body, label)
+		}
+
+		documentedCodes := map[string]bool{}
+		for _, item := range doc.Errors {
+			for _, code := range strings.Split(item.Description, ", ") {
+				documentedCodes[code] = true
+			}
+		}
+		codes := fields.codes()
+		if len(doc.Roles) > 0 {
+			codes = append(codes, CodeUnauthenticated)
+		}
+		for _, code := range codes {
+			suite.True(documentedCodes[code], "%s leaves out %s", label, code)
+		}
+	}
+}
+
+func (suite *OpenAPISuite) TestServeSpec() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, OpenAPIPath, nil)
+	suite.route.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+	doc := &openapi.Document{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), doc))
+	suite.Equal(openapi.Version, doc.OpenAPI)
+	suite.Contains(doc.Paths, "/pharmacy/v1/{PharmacyID}/product")
+	suite.Contains(doc.Components.Schemas, "PurchaseJSON")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, SwaggerPath, nil)
+	suite.route.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), OpenAPIPath)
+	suite.NotContains(w.Body.String(), "https://")
+
+	for _, asset := range []string{"/swagger-ui-bundle.js", "/swagger-ui.css"} {
+		suite.Contains(w.Body.String(), SwaggerAssetsPath+asset)
+		assetW := httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, SwaggerAssetsPath+asset, nil)
+		suite.route.ServeHTTP(assetW, req)
+		suite.Equal(http.StatusOK, assetW.Code, asset)
+		suite.NotEmpty(assetW.Body.Bytes(), asset)
+	}
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPISuite))
+}
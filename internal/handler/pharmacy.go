@@ -3,33 +3,35 @@ package handler
 import (
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/justdomepaul/toolbox/utils"
 	"go.uber.org/zap"
 	"math"
 	"net/http"
+	"phantom_mask/internal/auth"
 	"phantom_mask/internal/entity"
+	"phantom_mask/internal/openapi"
 	"phantom_mask/internal/storage"
 	spannerDB "phantom_mask/internal/storage/spanner"
-	"strconv"
+	internalUtils "phantom_mask/internal/utils"
 )
 
-var MaxInt64Str = strconv.FormatInt(math.MaxInt64, 10)
-
 func NewPharmacy(
 	logger *zap.Logger,
 	db spannerDB.Set,
+	issuer *auth.Issuer,
 ) (*Pharmacy, error) {
 	return &Pharmacy{
 		logger: logger,
 		db:     db,
+		issuer: issuer,
 	}, nil
 }
 
 type Pharmacy struct {
 	logger *zap.Logger
 	db     spannerDB.Set
+	issuer *auth.Issuer
 }
 
 func (h *Pharmacy) BindRoute(route *gin.Engine) {
@@ -38,39 +40,143 @@ func (h *Pharmacy) BindRoute(route *gin.Engine) {
 		v1Group := adminGroup.Group("/v1")
 		v1Group.GET("/", h.ListPharmacy)
 		v1Group.GET("/mix", h.ListMix)
+		v1Group.GET("/mask", h.ListMask)
 		v1Group.GET("/:PharmacyID/product", h.ListProduct)
 		v1Group.GET("/product/price", h.ListByProductPriceRange)
+		v1Group.GET("/product/compare", h.ListProductPriceComparison)
+		v1Group.PUT("/:PharmacyID/opening_hours", guard(h.issuer, auth.RolePharmacyStaff, auth.RoleAdmin), ownPharmacy(), h.PutOpeningHours)
+		v1Group.PUT("/:PharmacyID/product/:ProductID", guard(h.issuer, auth.RolePharmacyStaff, auth.RoleAdmin), ownPharmacy(), h.PutProduct)
+
+		v2Group := adminGroup.Group("/v2", envelopeErrors())
+		v2Group.GET("/", h.ListPharmacyV2)
+		v2Group.GET("/mix", h.ListMixV2)
+		v2Group.GET("/mask", h.ListMaskV2)
+		v2Group.GET("/:PharmacyID/product", h.ListProductV2)
+		v2Group.GET("/product/price", h.ListByProductPriceRangeV2)
+		v2Group.GET("/product/compare", h.ListProductPriceComparisonV2)
+		v2Group.PUT("/:PharmacyID/opening_hours", guard(h.issuer, auth.RolePharmacyStaff, auth.RoleAdmin), ownPharmacy(), h.PutOpeningHoursV2)
+		v2Group.PUT("/:PharmacyID/product/:ProductID", guard(h.issuer, auth.RolePharmacyStaff, auth.RoleAdmin), ownPharmacy(), h.PutProductV2)
 	}
 }
 
-// ListPharmacy :List all pharmacies open at a specific time and on a day of the week if requested.
-func (h *Pharmacy) ListPharmacy(c *gin.Context) {
-	beforeParsePage := c.DefaultQuery("page", "1")
-	page, err := strconv.ParseUint(beforeParsePage, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+// Docs documents the routes BindRoute registers.
+func (h *Pharmacy) Docs() []openapi.Route {
+	timestamp := openapi.QueryParam("specify_utc0_millisecond_timestamp", "utc0 millisecond timestamp", int64(0))
+	productFilters := []openapi.Parameter{
+		openapi.QueryParam("brand", "case insensitive brand", ""),
+		openapi.QueryParam("color", "case insensitive color", ""),
+		openapi.QueryParam("pack_size", "masks per pack", int64(0)),
 	}
-	beforeParseRow := c.DefaultQuery("row", "10")
-	row, err := strconv.ParseUint(beforeParseRow, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
-	}
-
-	beforeParseSpecifyTimestamp := c.DefaultQuery("specify_utc0_millisecond_timestamp", "0")
-	specifyTimestamp, err := strconv.ParseInt(beforeParseSpecifyTimestamp, 10, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+	routes := []openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     "/pharmacy/v1/",
+			Summary:  "List pharmacies open at a specific time",
+			Tag:      "pharmacy",
+			Query:    joinParams(pageParams(), cursorParams(), sortFilterParams(storage.PharmacySortFields, storage.PharmacyFilterFields), []openapi.Parameter{timestamp}),
+			Response: entity.PharmacySpecifyListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/pharmacy/v1/mix",
+			Summary: "Search pharmacies or masks by name",
+			Tag:     "pharmacy",
+			Query: joinParams(pageParams(), cursorParams(), sortFilterParams(storage.MixSortFields, storage.MixFilterFields), []openapi.Parameter{
+				openapi.QueryParam("name", "search term", ""),
+				openapi.QueryParam("sorted", "name or relevance", "name"),
+			}, productFilters),
+			Response: entity.PharmacyProductListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/pharmacy/v1/mask",
+			Summary:  "List the canonical mask catalogue",
+			Tag:      "pharmacy",
+			Query:    pageParams(),
+			Response: entity.MaskListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/pharmacy/v1/:PharmacyID/product",
+			Summary: "List the masks sold by a pharmacy",
+			Tag:     "pharmacy",
+			Query: joinParams(pageParams(), cursorParams(), sortFilterParams(storage.ProductSortFields, storage.ProductFilterFields), []openapi.Parameter{
+				openapi.QueryParam("sorted", "name or price", "name"),
+			}, productFilters),
+			Response: entity.ProductListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeInvalidUUID, CodeStorageFailure),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/pharmacy/v1/product/price",
+			Summary: "List pharmacies by their number of masks within a price range",
+			Tag:     "pharmacy",
+			Query: joinParams(pageParams(), cursorParams(), sortFilterParams(storage.ProductCountSortFields, storage.ProductCountFilterFields), []openapi.Parameter{
+				openapi.QueryParam("min", "lowest price", int64(0)),
+				openapi.QueryParam("max", "highest price", int64(math.MaxInt64)),
+				openapi.QueryParam("count", "number of masks compared with count_operator", int64(0)),
+				openapi.QueryParam("count_operator", "gt, lt or eq", "gt"),
+			}),
+			Response: entity.PharmacyProductCountListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/pharmacy/v1/product/compare",
+			Summary: "Compare the price of a mask across pharmacies",
+			Tag:     "pharmacy",
+			Query: joinParams(pageParams(), []openapi.Parameter{
+				openapi.QueryParam("name", "case insensitive product name", ""),
+				openapi.QueryParam("specify_utc0_millisecond_timestamp", "utc0 millisecond timestamp telling whether a pharmacy is open, now by default", int64(0)),
+				openapi.QueryParam("open_only", "only list the pharmacies open at specify_utc0_millisecond_timestamp", false),
+			}),
+			Response: entity.ProductPriceComparisonListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:   http.MethodPut,
+			Path:     "/pharmacy/v1/:PharmacyID/opening_hours",
+			Summary:  "Replace the opening hours of a pharmacy",
+			Tag:      "pharmacy",
+			Body:     entity.OpeningHoursJSON{},
+			Response: "",
+			Errors: problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeInvalidUUID, CodeUnauthenticated,
+				CodePermissionDenied, CodePharmacyNotFound, CodeStorageFailure),
+			Roles: roleDocs(auth.RolePharmacyStaff, auth.RoleAdmin),
+		},
+		{
+			Method:   http.MethodPut,
+			Path:     "/pharmacy/v1/:PharmacyID/product/:ProductID",
+			Summary:  "Add or update a product a pharmacy sells and its price",
+			Tag:      "pharmacy",
+			Body:     entity.ProductPutJSON{},
+			Response: "",
+			Errors: problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeInvalidUUID, CodeUnauthenticated,
+				CodePermissionDenied, CodePharmacyNotFound, CodeStorageFailure),
+			Roles: roleDocs(auth.RolePharmacyStaff, auth.RoleAdmin),
+		},
 	}
+	return append(routes,
+		asV2(routes[0], entity.Envelope[[]*entity.PharmacySpecifyItemV2]{}),
+		asV2(routes[1], entity.Envelope[[]*entity.PharmacyProductV2]{}),
+		asV2(routes[2], entity.Envelope[[]*entity.MaskItemV2]{}),
+		asV2(routes[3], entity.Envelope[[]*entity.ProductItemV2]{}),
+		asV2(routes[4], entity.Envelope[[]*entity.PharmacyProductCountItemV2]{}),
+		asV2(routes[5], entity.Envelope[[]*entity.ProductPriceComparisonV2]{}),
+		asV2(routes[6], entity.Envelope[entity.OpeningHoursV2]{}),
+		asV2(routes[7], entity.Envelope[entity.ProductPutV2]{}),
+	)
+}
 
-	result, err := h.db.Pharmacy.ListSpecifyTime(c, row, page, specifyTimestamp, storage.PharmacyNameASC)
-	if errors.Is(err, errorhandler.ErrInvalidArguments) {
-		panic(errorhandler.NewErrVariable(err))
-	}
-	if err != nil {
-		panic(errorhandler.NewErrDBExecute(err))
-	}
+// ListPharmacy :List all pharmacies open at a specific time and on a day of the week if requested.
+func (h *Pharmacy) ListPharmacy(c *gin.Context) {
+	result := h.listPharmacy(c)
 	resp := &entity.PharmacySpecifyListJSON{
 		CommonListResponse: result.CommonListResponse,
+		NextPageToken:      result.NextPageToken,
 	}
 	var pharmacies []*entity.PharmacySpecifyItemJSON
 	for _, item := range result.Pharmacies {
@@ -83,28 +189,33 @@ func (h *Pharmacy) ListPharmacy(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
-// Search for pharmacies or masks by name, ranked by relevance to the search term.
-func (h *Pharmacy) ListMix(c *gin.Context) {
-	beforeParsePage := c.DefaultQuery("page", "1")
-	page, err := strconv.ParseUint(beforeParsePage, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
-	}
-	beforeParseRow := c.DefaultQuery("row", "10")
-	row, err := strconv.ParseUint(beforeParseRow, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
-	}
+// listPharmacy parses the query of ListPharmacy and ListPharmacyV2 and runs it.
+func (h *Pharmacy) listPharmacy(c *gin.Context) *entity.PharmacySpecifyTimestampList {
+	query := struct {
+		pageQuery
+		cursorQuery
+		sortFilterQuery
+		SpecifyTimestamp int64 `query:"specify_utc0_millisecond_timestamp"`
+	}{pageQuery: defaultPageQuery(), cursorQuery: defaultCursorQuery()}
+	bind(c, &query)
 
-	result, err := h.db.Pharmacy.ListPharmacyMixProduct(c, row, page, c.Query("name"), storage.PharmacyProduct)
+	condition := parsePharmacyQuery(query.sortFilterQuery, storage.PharmacyListCondition{}, storage.PharmacySortFields, storage.PharmacyFilterFields)
+	result, err := h.db.Pharmacy.ListSpecifyTime(c, query.Row, query.Page, query.SpecifyTimestamp, storage.PharmacyNameASC, condition, query.cursor())
 	if errors.Is(err, errorhandler.ErrInvalidArguments) {
 		panic(errorhandler.NewErrVariable(err))
 	}
 	if err != nil {
 		panic(errorhandler.NewErrDBExecute(err))
 	}
+	return result
+}
+
+// Search for pharmacies or masks by name, sorted by name or, with sorted=relevance, ranked by relevance to the search term.
+func (h *Pharmacy) ListMix(c *gin.Context) {
+	result := h.listMix(c)
 	resp := &entity.PharmacyProductListJSON{
 		CommonListResponse: result.CommonListResponse,
+		NextPageToken:      result.NextPageToken,
 	}
 	var pharmacyProducts []*entity.PharmacyProductJSON
 	for _, item := range result.PharmacyProducts {
@@ -112,109 +223,511 @@ func (h *Pharmacy) ListMix(c *gin.Context) {
 			PharmacyProduct: item,
 			UID:             utils.FromUUID(item.UID),
 			ProductID:       utils.FromUUID(item.ProductID),
+			PricePerMask:    internalUtils.PricePerMask(item.Price, item.PackSize),
 		})
 	}
 	resp.PharmacyProducts = pharmacyProducts
 	c.JSON(http.StatusOK, resp)
 }
 
-// List all masks sold by a given pharmacy, sorted by mask name or price.
-func (h *Pharmacy) ListProduct(c *gin.Context) {
-	valid := struct {
-		PharmacyID string `validate:"required"`
-	}{}
-	beforeParsePage := c.DefaultQuery("page", "1")
-	page, err := strconv.ParseUint(beforeParsePage, 0, 64)
-	if err != nil {
+// listMix parses the query of ListMix and ListMixV2 and runs it.
+func (h *Pharmacy) listMix(c *gin.Context) *entity.PharmacyProductList {
+	query := struct {
+		pageQuery
+		cursorQuery
+		sortFilterQuery
+		productFilterQuery
+		Name   string `query:"name"`
+		Sorted string `query:"sorted" validate:"oneof=name relevance"`
+	}{pageQuery: defaultPageQuery(), cursorQuery: defaultCursorQuery(), Sorted: "name"}
+	bind(c, &query)
+
+	order := storage.PharmacyProduct
+	if query.Sorted == "relevance" {
+		order = storage.Relevance
+	}
+
+	condition := storage.PharmacyListCondition{}
+	if query.Brand != "" {
+		condition = storage.WithPharmacyProductBrand(condition, query.Brand)
+	}
+	if query.Color != "" {
+		condition = storage.WithPharmacyProductColor(condition, query.Color)
+	}
+	if query.PackSize != nil {
+		condition = storage.WithPharmacyProductPackSize(condition, *query.PackSize)
+	}
+	condition = parsePharmacyQuery(query.sortFilterQuery, condition, storage.MixSortFields, storage.MixFilterFields)
+
+	result, err := h.db.Pharmacy.ListPharmacyMixProduct(c, query.Row, query.Page, query.Name, order, condition, query.cursor())
+	if errors.Is(err, errorhandler.ErrInvalidArguments) {
 		panic(errorhandler.NewErrVariable(err))
 	}
-	beforeParseRow := c.DefaultQuery("row", "10")
-	row, err := strconv.ParseUint(beforeParseRow, 0, 64)
 	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+		panic(errorhandler.NewErrDBExecute(err))
 	}
-	sorted := c.DefaultQuery("sorted", "name")
+	return result
+}
 
-	pharmacyID := c.Param("PharmacyID")
-	valid.PharmacyID = pharmacyID
-	if err := validator.New().Struct(&valid); err != nil {
-		panic(errorhandler.NewErrVariable(err))
+// List the canonical mask catalogue shared by every pharmacy, sorted by name.
+func (h *Pharmacy) ListMask(c *gin.Context) {
+	result := h.listMask(c)
+	resp := &entity.MaskListJSON{
+		CommonListResponse: result.CommonListResponse,
 	}
-	var order storage.OrderListEnum
-	switch sorted {
-	case "name":
-		order = storage.ProductNameASC
-	case "price":
-		order = storage.ProductPriceASC
+	var masks []*entity.MaskItemJSON
+	for _, item := range result.Masks {
+		masks = append(masks, &entity.MaskItemJSON{
+			Mask:   item,
+			MaskID: utils.FromUUID(item.MaskID),
+		})
 	}
+	resp.Masks = masks
+	c.JSON(http.StatusOK, resp)
+}
 
-	condition := storage.ProductListCondition{}
-	condition = storage.WithProductSpecifyPharmacy(condition, utils.ParseUUID(pharmacyID))
-	result, err := h.db.Product.List(c, row, page, order, condition)
+// listMask parses the query of ListMask and ListMaskV2 and runs it.
+func (h *Pharmacy) listMask(c *gin.Context) *entity.MaskList {
+	query := defaultPageQuery()
+	bind(c, &query)
+
+	result, err := h.db.Mask.List(c, query.Row, query.Page, storage.ProductNameASC)
 	if errors.Is(err, errorhandler.ErrInvalidArguments) {
 		panic(errorhandler.NewErrVariable(err))
 	}
 	if err != nil {
 		panic(errorhandler.NewErrDBExecute(err))
 	}
+	return result
+}
+
+// parsePharmacyQuery adds the sort and filter query, restricted to the whitelisted sortFields and filterFields, to condition.
+func parsePharmacyQuery(query sortFilterQuery, condition storage.PharmacyListCondition, sortFields, filterFields map[string]storage.ListField) storage.PharmacyListCondition {
+	if query.Sort != "" {
+		sorts, err := storage.ParseSort(query.Sort, sortFields)
+		if err != nil {
+			panic(errorhandler.NewErrVariable(err))
+		}
+		condition = storage.WithPharmacySort(condition, sorts...)
+	}
+	if query.Filter != "" {
+		filters, err := storage.ParseFilter(query.Filter, filterFields)
+		if err != nil {
+			panic(errorhandler.NewErrVariable(err))
+		}
+		condition = storage.WithPharmacyFilter(condition, filters...)
+	}
+	return condition
+}
+
+// parseProductQuery adds the sort and filter query, restricted to the whitelisted sortFields and filterFields, to condition.
+func parseProductQuery(query sortFilterQuery, condition storage.ProductListCondition, sortFields, filterFields map[string]storage.ListField) storage.ProductListCondition {
+	if query.Sort != "" {
+		sorts, err := storage.ParseSort(query.Sort, sortFields)
+		if err != nil {
+			panic(errorhandler.NewErrVariable(err))
+		}
+		condition = storage.WithProductSort(condition, sorts...)
+	}
+	if query.Filter != "" {
+		filters, err := storage.ParseFilter(query.Filter, filterFields)
+		if err != nil {
+			panic(errorhandler.NewErrVariable(err))
+		}
+		condition = storage.WithProductFilter(condition, filters...)
+	}
+	return condition
+}
+
+// fromOptionalUUID formats the id, products created before the catalogue have none and format as empty.
+func fromOptionalUUID(id []byte) string {
+	if len(id) == 0 {
+		return ""
+	}
+	return utils.FromUUID(id)
+}
+
+// List all masks sold by a given pharmacy, sorted by mask name or price.
+func (h *Pharmacy) ListProduct(c *gin.Context) {
+	result := h.listProduct(c)
 	resp := &entity.ProductListJSON{
 		CommonListResponse: result.CommonListResponse,
+		NextPageToken:      result.NextPageToken,
 	}
 	var products []*entity.ProductItemJSON
 	for _, item := range result.Products {
 		products = append(products, &entity.ProductItemJSON{
-			Product:   item,
-			UID:       utils.FromUUID(item.UID),
-			ProductID: utils.FromUUID(item.ProductID),
+			Product:      item,
+			UID:          utils.FromUUID(item.UID),
+			ProductID:    utils.FromUUID(item.ProductID),
+			MaskID:       fromOptionalUUID(item.MaskID),
+			PricePerMask: internalUtils.PricePerMask(item.Price, item.PackSize),
 		})
 	}
 	resp.Products = products
 	c.JSON(http.StatusOK, resp)
 }
 
-// List all pharmacies with more or less than x mask products within a price range.
-func (h *Pharmacy) ListByProductPriceRange(c *gin.Context) {
-	beforeParsePage := c.DefaultQuery("page", "1")
-	page, err := strconv.ParseUint(beforeParsePage, 0, 64)
-	if err != nil {
+// listProduct parses the query of ListProduct and ListProductV2 and runs it.
+func (h *Pharmacy) listProduct(c *gin.Context) *entity.ProductList {
+	query := struct {
+		pageQuery
+		cursorQuery
+		sortFilterQuery
+		productFilterQuery
+		PharmacyID string `param:"PharmacyID" validate:"required,uuid"`
+		Sorted     string `query:"sorted" validate:"oneof=name price"`
+	}{pageQuery: defaultPageQuery(), cursorQuery: defaultCursorQuery(), Sorted: "name"}
+	bind(c, &query)
+
+	order := storage.ProductNameASC
+	if query.Sorted == "price" {
+		order = storage.ProductPriceASC
+	}
+
+	condition := storage.ProductListCondition{}
+	condition = storage.WithProductSpecifyPharmacy(condition, utils.ParseUUID(query.PharmacyID))
+	if query.Brand != "" {
+		condition = storage.WithProductBrand(condition, query.Brand)
+	}
+	if query.Color != "" {
+		condition = storage.WithProductColor(condition, query.Color)
+	}
+	if query.PackSize != nil {
+		condition = storage.WithProductPackSize(condition, *query.PackSize)
+	}
+	condition = parseProductQuery(query.sortFilterQuery, condition, storage.ProductSortFields, storage.ProductFilterFields)
+	result, err := h.db.Product.List(c, query.Row, query.Page, order, condition, query.cursor())
+	if errors.Is(err, errorhandler.ErrInvalidArguments) {
 		panic(errorhandler.NewErrVariable(err))
 	}
-	beforeParseRow := c.DefaultQuery("row", "10")
-	row, err := strconv.ParseUint(beforeParseRow, 0, 64)
 	if err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return result
+}
+
+// List all pharmacies with more or less than x mask products within a price range.
+func (h *Pharmacy) ListByProductPriceRange(c *gin.Context) {
+	result := h.listByProductPriceRange(c)
+	resp := &entity.PharmacyProductCountListJSON{
+		CommonListResponse: result.CommonListResponse,
+		NextPageToken:      result.NextPageToken,
+	}
+	var pharmacies []*entity.PharmacyProductCountItemJSON
+	for _, item := range result.Pharmacies {
+		pharmacies = append(pharmacies, &entity.PharmacyProductCountItemJSON{
+			PharmacyProductCount: item,
+			UID:                  utils.FromUUID(item.UID),
+		})
+	}
+	resp.Pharmacies = pharmacies
+	c.JSON(http.StatusOK, resp)
+}
+
+// listByProductPriceRange parses the query of ListByProductPriceRange and ListByProductPriceRangeV2 and runs it.
+func (h *Pharmacy) listByProductPriceRange(c *gin.Context) *entity.PharmacyProductCountList {
+	query := struct {
+		pageQuery
+		cursorQuery
+		sortFilterQuery
+		Min           int64  `query:"min"`
+		Max           int64  `query:"max"`
+		Count         *int64 `query:"count"`
+		CountOperator string `query:"count_operator" validate:"oneof=gt lt eq"`
+	}{pageQuery: defaultPageQuery(), cursorQuery: defaultCursorQuery(), Max: math.MaxInt64, CountOperator: "gt"}
+	bind(c, &query)
+
+	condition := storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductPriceRange(condition, query.Min, query.Max)
+	if query.Count != nil {
+		switch query.CountOperator {
+		case "gt":
+			condition = storage.WithPharmacyProductCount(condition, storage.CountGreaterThan, *query.Count)
+		case "lt":
+			condition = storage.WithPharmacyProductCount(condition, storage.CountLessThan, *query.Count)
+		case "eq":
+			condition = storage.WithPharmacyProductCount(condition, storage.CountEqual, *query.Count)
+		}
+	}
+	condition = parsePharmacyQuery(query.sortFilterQuery, condition, storage.ProductCountSortFields, storage.ProductCountFilterFields)
+	result, err := h.db.Pharmacy.ListByProductPriceRange(c, query.Row, query.Page, storage.PharmacyNameASC, condition, query.cursor())
+	if errors.Is(err, errorhandler.ErrInvalidArguments) {
 		panic(errorhandler.NewErrVariable(err))
 	}
-	beforeParseMin := c.DefaultQuery("min", "0")
-	min, err := strconv.ParseInt(beforeParseMin, 0, 64)
 	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+		panic(errorhandler.NewErrDBExecute(err))
 	}
+	return result
+}
 
-	beforeParseMax := c.DefaultQuery("max", MaxInt64Str)
-	max, err := strconv.ParseInt(beforeParseMax, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+// Compare the price of the same mask across pharmacies, cheapest first, with whether each pharmacy is open.
+func (h *Pharmacy) ListProductPriceComparison(c *gin.Context) {
+	result := h.listProductPriceComparison(c)
+	resp := &entity.ProductPriceComparisonListJSON{
+		CommonListResponse: result.CommonListResponse,
+	}
+	var products []*entity.ProductPriceComparisonJSON
+	for _, item := range result.Products {
+		product := &entity.ProductPriceComparisonJSON{
+			Name: item.Name,
+		}
+		for _, offer := range item.Offers {
+			product.Offers = append(product.Offers, &entity.PharmacyPriceOfferJSON{
+				PharmacyPriceOffer: offer,
+				UID:                utils.FromUUID(offer.UID),
+				ProductID:          utils.FromUUID(offer.ProductID),
+				PricePerMask:       internalUtils.PricePerMask(offer.Price, offer.PackSize),
+			})
+		}
+		products = append(products, product)
 	}
+	resp.Products = products
+	c.JSON(http.StatusOK, resp)
+}
+
+// listProductPriceComparison parses the query of ListProductPriceComparison and ListProductPriceComparisonV2 and runs it.
+func (h *Pharmacy) listProductPriceComparison(c *gin.Context) *entity.ProductPriceComparisonList {
+	query := struct {
+		pageQuery
+		Name             string `query:"name"`
+		SpecifyTimestamp int64  `query:"specify_utc0_millisecond_timestamp"`
+		OpenOnly         bool   `query:"open_only"`
+	}{pageQuery: defaultPageQuery(), SpecifyTimestamp: GetNowTimestamp()}
+	bind(c, &query)
 
 	condition := storage.PharmacyListCondition{}
-	condition = storage.WithPharmacyProductPriceRange(condition, min, max)
-	result, err := h.db.Pharmacy.ListByProductPriceRange(c, row, page, storage.PharmacyNameASC, condition)
+	if query.OpenOnly {
+		condition = storage.WithPharmacyOpenAt(condition, query.SpecifyTimestamp)
+	}
+	result, err := h.db.Pharmacy.ListProductPriceComparison(c, query.Row, query.Page, query.Name, query.SpecifyTimestamp, condition)
 	if errors.Is(err, errorhandler.ErrInvalidArguments) {
 		panic(errorhandler.NewErrVariable(err))
 	}
 	if err != nil {
 		panic(errorhandler.NewErrDBExecute(err))
 	}
-	resp := &entity.PharmacyListJSON{
-		CommonListResponse: result.CommonListResponse,
+	return result
+}
+
+// PutOpeningHours replaces every opening hours row of a pharmacy, by its staff or an admin.
+func (h *Pharmacy) PutOpeningHours(c *gin.Context) {
+	h.putOpeningHours(c)
+	c.String(http.StatusOK, "ok")
+}
+
+// putOpeningHours decodes the path and body of PutOpeningHours and PutOpeningHoursV2 and replaces the opening hours.
+func (h *Pharmacy) putOpeningHours(c *gin.Context) entity.OpeningHoursV2 {
+	query := struct {
+		PharmacyID string `param:"PharmacyID" validate:"required,uuid"`
+	}{}
+	bind(c, &query)
+	req := entity.OpeningHoursJSON{}
+	bindJSON(c, &req)
+
+	pharmacyID := utils.ParseUUID(query.PharmacyID)
+	h.requirePharmacy(c, pharmacyID)
+
+	inputs := make([]entity.PharmacyInfo, 0, len(req.OpeningHours))
+	for _, item := range req.OpeningHours {
+		inputs = append(inputs, entity.PharmacyInfo{
+			Day:       item.Day,
+			OpenHour:  item.OpenHour,
+			CloseHour: item.CloseHour,
+		})
+	}
+	if err := h.db.PharmacyInfo.Replace(c, pharmacyID, inputs); err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	if req.OpeningHours == nil {
+		req.OpeningHours = []entity.OpeningHourJSON{}
 	}
-	var pharmacies []*entity.PharmacyItemJSON
+	return entity.OpeningHoursV2{
+		PharmacyID:   query.PharmacyID,
+		OpeningHours: req.OpeningHours,
+	}
+}
+
+// requirePharmacy replies storage.ErrPharmacyNotFound unless the pharmacy of pharmacyID exists.
+func (h *Pharmacy) requirePharmacy(c *gin.Context, pharmacyID []byte) {
+	pharmacies, err := h.db.Pharmacy.ListByIDs(c, [][]byte{pharmacyID})
+	if err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	if len(pharmacies) == 0 {
+		panic(errorhandler.NewErrDBExecute(storage.ErrPharmacyNotFound))
+	}
+}
+
+// PutProduct adds a product to those a pharmacy sells or updates it, by its staff or an admin. Only the product and its
+// price are kept, there is no quantity on hand.
+func (h *Pharmacy) PutProduct(c *gin.Context) {
+	h.putProduct(c)
+	c.String(http.StatusOK, "ok")
+}
+
+// putProduct decodes the path and body of PutProduct and PutProductV2 and upserts the product.
+func (h *Pharmacy) putProduct(c *gin.Context) entity.ProductPutV2 {
+	query := struct {
+		PharmacyID string `param:"PharmacyID" validate:"required,uuid"`
+		ProductID  string `param:"ProductID" validate:"required,uuid"`
+	}{}
+	bind(c, &query)
+	req := entity.ProductPutJSON{}
+	bindJSON(c, &req)
+
+	pharmacyID := utils.ParseUUID(query.PharmacyID)
+	h.requirePharmacy(c, pharmacyID)
+
+	input := storage.WithMaskAttribute(entity.Product{
+		UID:       pharmacyID,
+		ProductID: utils.ParseUUID(query.ProductID),
+		Name:      req.Name,
+		Price:     req.Price,
+		Brand:     req.Brand,
+		Color:     req.Color,
+		PackSize:  req.PackSize,
+	})
+	if err := h.db.Product.Upsert(c, input); err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return entity.ProductPutV2{
+		UID:       query.PharmacyID,
+		ProductID: query.ProductID,
+		Name:      input.Name,
+		Price:     input.Price,
+		Brand:     input.Brand,
+		Color:     input.Color,
+		PackSize:  input.PackSize,
+	}
+}
+
+// ListPharmacyV2 is ListPharmacy replying within an Envelope.
+func (h *Pharmacy) ListPharmacyV2(c *gin.Context) {
+	result := h.listPharmacy(c)
+	pharmacies := make([]*entity.PharmacySpecifyItemV2, 0, len(result.Pharmacies))
 	for _, item := range result.Pharmacies {
-		pharmacies = append(pharmacies, &entity.PharmacyItemJSON{
-			Pharmacy: item,
-			UID:      utils.FromUUID(item.UID),
+		pharmacies = append(pharmacies, &entity.PharmacySpecifyItemV2{
+			UID:         utils.FromUUID(item.UID),
+			Name:        item.Name,
+			CashBalance: item.CashBalance,
+			CreatedTime: item.CreatedTime,
+			Day:         item.Day,
+			OpenHour:    item.OpenHour,
+			CloseHour:   item.CloseHour,
 		})
 	}
-	resp.Pharmacies = pharmacies
-	c.JSON(http.StatusOK, resp)
+	replyPage(c, pharmacies, result.CommonListResponse, result.NextPageToken)
+}
+
+// ListMixV2 is ListMix replying within an Envelope.
+func (h *Pharmacy) ListMixV2(c *gin.Context) {
+	result := h.listMix(c)
+	pharmacyProducts := make([]*entity.PharmacyProductV2, 0, len(result.PharmacyProducts))
+	for _, item := range result.PharmacyProducts {
+		pharmacyProducts = append(pharmacyProducts, &entity.PharmacyProductV2{
+			UID:          utils.FromUUID(item.UID),
+			ProductID:    utils.FromUUID(item.ProductID),
+			PharmacyName: item.PharmacyName,
+			CashBalance:  item.CashBalance,
+			ProductName:  item.ProductName,
+			Price:        item.Price,
+			Brand:        item.Brand,
+			Color:        item.Color,
+			PackSize:     item.PackSize,
+			Score:        item.Score,
+			PricePerMask: internalUtils.PricePerMask(item.Price, item.PackSize),
+		})
+	}
+	replyPage(c, pharmacyProducts, result.CommonListResponse, result.NextPageToken)
+}
+
+// ListMaskV2 is ListMask replying within an Envelope.
+func (h *Pharmacy) ListMaskV2(c *gin.Context) {
+	result := h.listMask(c)
+	masks := make([]*entity.MaskItemV2, 0, len(result.Masks))
+	for _, item := range result.Masks {
+		masks = append(masks, &entity.MaskItemV2{
+			MaskID:      utils.FromUUID(item.MaskID),
+			Name:        item.Name,
+			Brand:       item.Brand,
+			Color:       item.Color,
+			PackSize:    item.PackSize,
+			CreatedTime: item.CreatedTime,
+		})
+	}
+	replyPage(c, masks, result.CommonListResponse, "")
+}
+
+// ListProductV2 is ListProduct replying within an Envelope.
+func (h *Pharmacy) ListProductV2(c *gin.Context) {
+	result := h.listProduct(c)
+	products := make([]*entity.ProductItemV2, 0, len(result.Products))
+	for _, item := range result.Products {
+		products = append(products, &entity.ProductItemV2{
+			UID:          utils.FromUUID(item.UID),
+			ProductID:    utils.FromUUID(item.ProductID),
+			Name:         item.Name,
+			Price:        item.Price,
+			Brand:        item.Brand,
+			Color:        item.Color,
+			PackSize:     item.PackSize,
+			MaskID:       fromOptionalUUID(item.MaskID),
+			CreatedTime:  item.CreatedTime,
+			PricePerMask: internalUtils.PricePerMask(item.Price, item.PackSize),
+		})
+	}
+	replyPage(c, products, result.CommonListResponse, result.NextPageToken)
+}
+
+// ListByProductPriceRangeV2 is ListByProductPriceRange replying within an Envelope.
+func (h *Pharmacy) ListByProductPriceRangeV2(c *gin.Context) {
+	result := h.listByProductPriceRange(c)
+	pharmacies := make([]*entity.PharmacyProductCountItemV2, 0, len(result.Pharmacies))
+	for _, item := range result.Pharmacies {
+		pharmacies = append(pharmacies, &entity.PharmacyProductCountItemV2{
+			UID:          utils.FromUUID(item.UID),
+			Name:         item.Name,
+			CashBalance:  item.CashBalance,
+			CreatedTime:  item.CreatedTime,
+			ProductCount: item.ProductCount,
+		})
+	}
+	replyPage(c, pharmacies, result.CommonListResponse, result.NextPageToken)
+}
+
+// ListProductPriceComparisonV2 is ListProductPriceComparison replying within an Envelope.
+func (h *Pharmacy) ListProductPriceComparisonV2(c *gin.Context) {
+	result := h.listProductPriceComparison(c)
+	products := make([]*entity.ProductPriceComparisonV2, 0, len(result.Products))
+	for _, item := range result.Products {
+		product := &entity.ProductPriceComparisonV2{
+			Name:   item.Name,
+			Offers: make([]*entity.PharmacyPriceOfferV2, 0, len(item.Offers)),
+		}
+		for _, offer := range item.Offers {
+			product.Offers = append(product.Offers, &entity.PharmacyPriceOfferV2{
+				UID:          utils.FromUUID(offer.UID),
+				PharmacyName: offer.PharmacyName,
+				ProductID:    utils.FromUUID(offer.ProductID),
+				ProductName:  offer.ProductName,
+				Price:        offer.Price,
+				PackSize:     offer.PackSize,
+				PricePerMask: internalUtils.PricePerMask(offer.Price, offer.PackSize),
+				IsOpen:       offer.IsOpen,
+			})
+		}
+		products = append(products, product)
+	}
+	replyPage(c, products, result.CommonListResponse, "")
+}
+
+// PutOpeningHoursV2 is PutOpeningHours replying the opening hours within an Envelope.
+func (h *Pharmacy) PutOpeningHoursV2(c *gin.Context) {
+	reply(c, h.putOpeningHours(c))
+}
+
+// PutProductV2 is PutProduct replying the product within an Envelope.
+func (h *Pharmacy) PutProductV2(c *gin.Context) {
+	reply(c, h.putProduct(c))
 }
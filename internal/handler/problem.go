@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"net/http"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/openapi"
+	"phantom_mask/internal/storage"
+	"sort"
+	"strings"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:phantom-mask:problem:"
+)
+
+// The stable codes of the errors, clients may rely on them.
+const (
+	CodeInvalidArgument     = "invalid_argument"
+	CodeInvalidUUID         = "invalid_uuid"
+	CodeMalformedBody       = "malformed_body"
+	CodeUnauthenticated     = "unauthenticated"
+	CodePermissionDenied    = "permission_denied"
+	CodeUserNotFound        = "user_not_found"
+	CodeCredentialNotFound  = "credential_not_found"
+	CodeEmailTaken          = "email_taken"
+	CodePharmacyNotFound    = "pharmacy_not_found"
+	CodeProductNotFound     = "product_not_found"
+	CodeInsufficientBalance = "insufficient_balance"
+	CodeStorageFailure      = "storage_failure"
+	CodeInternal            = "internal"
+)
+
+var ErrInvalidUUID = fmt.Errorf("%w: malformed uuid", errorhandler.ErrInvalidArguments)
+
+type problemCode struct {
+	Status int
+	Title  string
+}
+
+var problemCodes = map[string]problemCode{
+	CodeInvalidArgument:     {Status: http.StatusBadRequest, Title: "Invalid argument"},
+	CodeInvalidUUID:         {Status: http.StatusBadRequest, Title: "Malformed UUID"},
+	CodeMalformedBody:       {Status: http.StatusBadRequest, Title: "Malformed JSON body"},
+	CodeUnauthenticated:     {Status: http.StatusUnauthorized, Title: "Missing or invalid bearer token"},
+	CodePermissionDenied:    {Status: http.StatusForbidden, Title: "Permission denied"},
+	CodeUserNotFound:        {Status: http.StatusNotFound, Title: "User not found"},
+	CodeCredentialNotFound:  {Status: http.StatusNotFound, Title: "User has no credential"},
+	CodeEmailTaken:          {Status: http.StatusConflict, Title: "Email already registered"},
+	CodePharmacyNotFound:    {Status: http.StatusNotFound, Title: "Pharmacy not found"},
+	CodeProductNotFound:     {Status: http.StatusNotFound, Title: "Product not found"},
+	CodeInsufficientBalance: {Status: http.StatusUnprocessableEntity, Title: "User cash balance not enough"},
+	CodeStorageFailure:      {Status: http.StatusInternalServerError, Title: "Storage failure"},
+	CodeInternal:            {Status: http.StatusInternalServerError, Title: "Internal error"},
+}
+
+// domainErrors are matched in order with errors.Is, before falling back to the errorhandler type the error was
+// panicked with.
+var domainErrors = []struct {
+	err  error
+	code string
+}{
+	{err: ErrInvalidUUID, code: CodeInvalidUUID},
+	{err: errorhandler.ErrUnauthenticated, code: CodeUnauthenticated},
+	{err: errorhandler.ErrNoPermission, code: CodePermissionDenied},
+	{err: storage.ErrUserNotFound, code: CodeUserNotFound},
+	{err: storage.ErrCredentialNotFound, code: CodeCredentialNotFound},
+	{err: storage.ErrEmailTaken, code: CodeEmailTaken},
+	{err: storage.ErrPharmacyNotFound, code: CodePharmacyNotFound},
+	{err: storage.ErrProductNotFound, code: CodeProductNotFound},
+	{err: storage.ErrInsufficientBalance, code: CodeInsufficientBalance},
+	{err: errorhandler.ErrInvalidArguments, code: CodeInvalidArgument},
+}
+
+// reportCodes maps the names of the errorhandler types to their code.
+var reportCodes = map[string]string{
+	errorhandler.ErrProcessVariable:        CodeInvalidArgument,
+	errorhandler.ErrProcessInvalidArgument: CodeInvalidArgument,
+	errorhandler.ErrJsonUnmarshal:          CodeMalformedBody,
+	errorhandler.ErrDbExecute:              CodeStorageFailure,
+	errorhandler.ErrProcessAuthenticate:    CodeUnauthenticated,
+	errorhandler.ErrProcessPermissionDeny:  CodePermissionDenied,
+}
+
+func toProblem(report errorhandler.IErrorReport) entity.Problem {
+	code, ok := reportCodes[report.GetName()]
+	if !ok {
+		code = CodeInternal
+	}
+	for _, domain := range domainErrors {
+		if errors.Is(report.GetError(), domain.err) {
+			code = domain.code
+			break
+		}
+	}
+	problem := entity.Problem{
+		Type:   problemTypePrefix + code,
+		Title:  problemCodes[code].Title,
+		Status: problemCodes[code].Status,
+		Detail: report.GetError().Error(),
+		Code:   code,
+	}
+	// The storage and internal errors tell about the database or the code, recoverProblem only logs them.
+	if code == CodeStorageFailure || code == CodeInternal {
+		problem.Detail = problem.Title
+	}
+	var bindErr *bindError
+	if errors.As(report.GetError(), &bindErr) {
+		problem.InvalidParams = bindErr.params
+	}
+	return problem
+}
+
+// recoverProblem recovers the error a handler panicked with, as errorhandler.GinPanicErrorHandler does, and replies
+// its problem unless the response is already written.
+func recoverProblem(c *gin.Context, reply func(problem entity.Problem)) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	report, ok := recovered.(errorhandler.IGinErrorReport)
+	if !ok {
+		err, isError := recovered.(error)
+		if !isError {
+			err = fmt.Errorf("%v", recovered)
+		}
+		report = errorhandler.NewErrServerExecute(err)
+	}
+	report.Report("")
+	_ = c.Error(report.GetError())
+	c.Abort()
+	if c.Writer.Written() {
+		return
+	}
+	reply(toProblem(report))
+}
+
+// problemErrors replies the errors the handlers panic with as problem+json.
+func problemErrors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer recoverProblem(c, func(problem entity.Problem) {
+			c.Header("Content-Type", ProblemContentType)
+			c.JSON(problem.Status, problem)
+		})
+		c.Next()
+	}
+}
+
+// problemDocs documents the problems replied with codes, any route may also reply CodeInternal.
+func problemDocs(codes ...string) []openapi.ErrorResponse {
+	byStatus := map[int][]string{}
+	for _, code := range append(codes, CodeInternal) {
+		status := problemCodes[code].Status
+		byStatus[status] = append(byStatus[status], code)
+	}
+	statuses := make([]int, 0, len(byStatus))
+	for status := range byStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	var result []openapi.ErrorResponse
+	for _, status := range statuses {
+		result = append(result, openapi.ErrorResponse{
+			Status:      status,
+			Description: strings.Join(byStatus[status], ", "),
+			ContentType: ProblemContentType,
+			Body:        entity.Problem{},
+		})
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/utils"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strings"
+	"testing"
+)
+
+const (
+	testUserID     = "9a7b330a-a736-41e5-b1a5-6b51ac0a2a19"
+	testPharmacyID = "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+)
+
+// fakeProduct fails the purchases of the products its errs maps the product id to.
+type fakeProduct struct {
+	storage.IProduct
+	errs map[string]error
+}
+
+func (f fakeProduct) Purchase(ctx context.Context, userID, pharmacyID, productID []byte, quantity int) error {
+	return f.errs[utils.FromUUID(productID)]
+}
+
+type ProblemSuite struct {
+	suite.Suite
+	route  *gin.Engine
+	issuer *auth.Issuer
+}
+
+func (suite *ProblemSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	db := spannerDB.Set{
+		Mask: fakeMask{},
+		Product: fakeProduct{errs: map[string]error{
+			"00000000-0000-0000-0000-000000000001": storage.ErrInsufficientBalance,
+			"00000000-0000-0000-0000-000000000002": storage.ErrProductNotFound,
+			"00000000-0000-0000-0000-000000000003": storage.ErrUserNotFound,
+			"00000000-0000-0000-0000-000000000005": errors.New("spanner: code = \"Internal\", desc = \"table Product\""),
+		}},
+	}
+	reply := func(c *gin.Context) {}
+	suite.issuer = newTestIssuer()
+	suite.route = gin.New()
+	suite.route.Use(errorhandler.GinPanicErrorHandler("test", ""))
+	pharmacy, _ := NewPharmacy(nil, db, suite.issuer)
+	transaction, _ := NewTransaction(nil, db, suite.issuer)
+	AddRoutes(suite.route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, Set{
+		Pharmacy:    pharmacy,
+		Transaction: transaction,
+		GraphQL:     &GraphQL{},
+		Auth:        &Auth{},
+		User:        &User{},
+	})
+}
+
+func (suite *ProblemSuite) purchase(version, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/transaction/"+version+"/purchase", strings.NewReader(body))
+	req.Header.Set("Authorization", bearer(suite.issuer, auth.RoleAdmin, "ops", ""))
+	suite.route.ServeHTTP(w, req)
+	return w
+}
+
+// purchaseBody is the body of a purchase, leaving user_id out when userID is empty.
+func purchaseBody(userID, productID string) string {
+	if userID == "" {
+		return `{"pharmacy_id":"` + testPharmacyID + `","product_id":"` + productID + `","quantity":2}`
+	}
+	return `{"user_id":"` + userID + `","pharmacy_id":"` + testPharmacyID + `","product_id":"` + productID + `","quantity":2}`
+}
+
+func (suite *ProblemSuite) TestPurchase() {
+	testCases := []struct {
+		Label  string
+		Body   string
+		Status int
+		Code   string
+	}{
+		{Label: "Insufficient balance", Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000001"), Status: http.StatusUnprocessableEntity, Code: CodeInsufficientBalance},
+		{Label: "Unknown product", Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000002"), Status: http.StatusNotFound, Code: CodeProductNotFound},
+		{Label: "Unknown user", Body: purchaseBody(testUserID, "00000000-0000-0000-0000-000000000003"), Status: http.StatusNotFound, Code: CodeUserNotFound},
+		{Label: "Invalid uuid", Body: purchaseBody("9a7b330a", "00000000-0000-0000-0000-000000000001"), Status: http.StatusBadRequest, Code: CodeInvalidUUID},
+		{Label: "Missing field", Body: `{"user_id":"` + testUserID + `"}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Malformed body", Body: `{"user_id":`, Status: http.StatusBadRequest, Code: CodeMalformedBody},
+	}
+	for _, testCase := range testCases {
+		suite.Run(testCase.Label, func() {
+			w := suite.purchase("v1", testCase.Body)
+			suite.Equal(testCase.Status, w.Code)
+			suite.Equal(ProblemContentType, w.Header().Get("Content-Type"))
+			problem := entity.Problem{}
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+			suite.Equal(testCase.Status, problem.Status)
+			suite.Equal(testCase.Code, problem.Code)
+			suite.Equal(problemTypePrefix+testCase.Code, problem.Type)
+			suite.NotEmpty(problem.Title)
+			suite.NotEmpty(problem.Detail)
+
+			w = suite.purchase("v2", testCase.Body)
+			suite.Equal(testCase.Status, w.Code)
+			resp := entity.Envelope[any]{}
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			suite.Require().NotNil(resp.Error)
+			suite.Equal(testCase.Code, resp.Error.Code)
+		})
+	}
+
+	w := suite.purchase("v1", purchaseBody(testUserID, "00000000-0000-0000-0000-000000000005"))
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	problem := entity.Problem{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+	suite.Equal(CodeStorageFailure, problem.Code)
+	suite.Equal(problem.Title, problem.Detail)
+	suite.NotContains(w.Body.String(), "spanner")
+
+	w = suite.purchase("v2", purchaseBody(testUserID, "00000000-0000-0000-0000-000000000005"))
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.NotContains(w.Body.String(), "spanner")
+
+	w = suite.purchase("v1", purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("ok", w.Body.String())
+}
+
+func (suite *ProblemSuite) TestInvalidPharmacyID() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/pharmacy/v1/not-a-uuid/product", nil)
+	suite.route.ServeHTTP(w, req)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	problem := entity.Problem{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+	suite.Equal(CodeInvalidUUID, problem.Code)
+	suite.Contains(problem.Detail, "PharmacyID")
+}
+
+func (suite *ProblemSuite) TestDocs() {
+	doc := NewOpenAPI(Set{Pharmacy: &Pharmacy{}, Transaction: &Transaction{}, GraphQL: &GraphQL{}, Auth: &Auth{}, User: &User{}})
+	purchase := doc.Paths["/transaction/v1/purchase"]["post"]
+	suite.Contains(purchase.Responses["404"].Description, CodeProductNotFound)
+	suite.Contains(purchase.Responses["422"].Description, CodeInsufficientBalance)
+	suite.Contains(purchase.Responses["422"].Content, ProblemContentType)
+	suite.Contains(doc.Paths["/transaction/v2/purchase"]["post"].Responses["422"].Content, "application/json")
+}
+
+func TestProblemSuite(t *testing.T) {
+	suite.Run(t, new(ProblemSuite))
+}
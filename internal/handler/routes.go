@@ -8,14 +8,22 @@ import (
 type Set struct {
 	Pharmacy    *Pharmacy
 	Transaction *Transaction
+	GraphQL     *GraphQL
+	Auth        *Auth
+	User        *User
 }
 
 func AddRoutes(route *gin.Engine, commonHandler restful.CommonHandler, handlers Set) {
+	route.Use(problemErrors())
 	route.GET("/ping", commonHandler.QuickReply)
 	route.GET("/metrics", commonHandler.PromHTTP)
 
 	handlers.Pharmacy.BindRoute(route)
 	handlers.Transaction.BindRoute(route)
+	handlers.GraphQL.BindRoute(route)
+	handlers.Auth.BindRoute(route)
+	handlers.User.BindRoute(route)
+	bindOpenAPI(route, NewOpenAPI(handlers))
 
 	route.NoRoute(commonHandler.Error404)
 }
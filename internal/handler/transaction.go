@@ -1,18 +1,17 @@
 package handler
 
 import (
-	"encoding/json"
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/justdomepaul/toolbox/timestamp"
 	"github.com/justdomepaul/toolbox/utils"
 	"go.uber.org/zap"
 	"net/http"
+	"phantom_mask/internal/auth"
 	"phantom_mask/internal/entity"
+	"phantom_mask/internal/openapi"
 	spannerDB "phantom_mask/internal/storage/spanner"
-	"strconv"
 )
 
 var (
@@ -22,75 +21,117 @@ var (
 func NewTransaction(
 	logger *zap.Logger,
 	db spannerDB.Set,
+	issuer *auth.Issuer,
 ) (*Transaction, error) {
 	return &Transaction{
 		logger: logger,
 		db:     db,
+		issuer: issuer,
 	}, nil
 }
 
 type Transaction struct {
 	logger *zap.Logger
 	db     spannerDB.Set
+	issuer *auth.Issuer
 }
 
 func (h *Transaction) BindRoute(route *gin.Engine) {
 	adminGroup := route.Group("/transaction")
 	{
 		v1Group := adminGroup.Group("/v1")
-		v1Group.POST("/purchase", h.Purchase)
+		v1Group.POST("/purchase", guard(h.issuer, auth.RoleCustomer, auth.RoleAdmin), h.Purchase)
 		v1Group.GET("/transaction/top", h.ListTransactionTop)
 		v1Group.GET("/transaction/product", h.GetTransactionTotal)
+		v1Group.GET("/transaction/mask", h.ListTransactionByMask)
+
+		v2Group := adminGroup.Group("/v2", envelopeErrors())
+		v2Group.POST("/purchase", guard(h.issuer, auth.RoleCustomer, auth.RoleAdmin), h.PurchaseV2)
+		v2Group.GET("/transaction/top", h.ListTransactionTopV2)
+		v2Group.GET("/transaction/product", h.GetTransactionTotalV2)
+		v2Group.GET("/transaction/mask", h.ListTransactionByMaskV2)
 	}
 }
 
-// Process a user purchases a mask from a pharmacy, and handle all relevant data changes in an atomic transaction.
-func (h *Transaction) Purchase(c *gin.Context) {
-	req := struct {
-		UserID     string `json:"user_id,omitempty" validate:"required"`
-		PharmacyID string `json:"pharmacy_id,omitempty" validate:"required"`
-		ProductID  string `json:"product_id,omitempty" validate:"required"`
-		Quantity   int    `json:"quantity,omitempty" validate:"required,min=1"`
-	}{}
-	defer c.Request.Body.Close()
-	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
-		panic(errorhandler.NewErrJSONUnmarshal(err))
+// Docs documents the routes BindRoute registers.
+func (h *Transaction) Docs() []openapi.Route {
+	timeRange := []openapi.Parameter{
+		openapi.QueryParam("utc0_millisecond_start_timestamp", "utc0 millisecond timestamp the range starts at", int64(0)),
+		openapi.QueryParam("utc0_millisecond_end_timestamp", "utc0 millisecond timestamp the range ends at, now by default", int64(0)),
 	}
-	if err := validator.New().Struct(&req); err != nil {
-		panic(errorhandler.NewErrVariable(err))
+	routes := []openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     "/transaction/v1/purchase",
+			Summary:  "Purchase a mask from a pharmacy",
+			Tag:      "transaction",
+			Body:     entity.PurchaseJSON{},
+			Response: "",
+			Errors: problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeInvalidUUID, CodeUnauthenticated,
+				CodePermissionDenied, CodeUserNotFound, CodePharmacyNotFound, CodeProductNotFound, CodeInsufficientBalance,
+				CodeStorageFailure),
+			Roles: roleDocs(auth.RoleCustomer, auth.RoleAdmin),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/transaction/v1/transaction/top",
+			Summary: "List the top users by transaction amount within a date range",
+			Tag:     "transaction",
+			Query: append([]openapi.Parameter{
+				openapi.QueryParam("top_number", "number of users", int64(10)),
+			}, timeRange...),
+			Response: entity.TopTransactionAmountListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/transaction/v1/transaction/product",
+			Summary:  "Total masks and dollar value of the transactions within a date range",
+			Tag:      "transaction",
+			Query:    timeRange,
+			Response: entity.TransactionTotal{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/transaction/v1/transaction/mask",
+			Summary:  "Transactions within a date range for each canonical mask",
+			Tag:      "transaction",
+			Query:    timeRange,
+			Response: entity.MaskTransactionListJSON{},
+			Errors:   problemDocs(CodeInvalidArgument, CodeStorageFailure),
+		},
 	}
+	return append(routes,
+		asV2(routes[0], entity.Envelope[entity.PurchaseV2]{}),
+		asV2(routes[1], entity.Envelope[[]*entity.TopTransactionAmountUserV2]{}),
+		asV2(routes[2], entity.Envelope[entity.TransactionTotalV2]{}),
+		asV2(routes[3], entity.Envelope[[]*entity.MaskTransactionV2]{}),
+	)
+}
 
-	if err := h.db.Product.Purchase(c, utils.ParseUUID(req.UserID), utils.ParseUUID(req.PharmacyID), utils.ParseUUID(req.ProductID), req.Quantity); err != nil {
-		panic(errorhandler.NewErrGRPCExecute(err))
-	}
+// Process a user purchases a mask from a pharmacy, and handle all relevant data changes in an atomic transaction.
+// The buyer is the customer of the token, an admin names the user purchasing with user_id.
+func (h *Transaction) Purchase(c *gin.Context) {
+	h.purchase(c)
 	c.String(http.StatusOK, "ok")
 }
 
-// The top x users by total transaction amount of masks within a date range.
-func (h *Transaction) ListTransactionTop(c *gin.Context) {
-	beforeParseTopNumber := c.DefaultQuery("top_number", "10")
-	topNumber, err := strconv.ParseInt(beforeParseTopNumber, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
-	}
-	beforeParseStartTime := c.DefaultQuery("utc0_millisecond_start_timestamp", "0")
-	startTime, err := strconv.ParseInt(beforeParseStartTime, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
-	}
-	beforeParseEndTime := c.DefaultQuery("utc0_millisecond_end_timestamp", strconv.FormatInt(GetNowTimestamp(), 10))
-	endTime, err := strconv.ParseInt(beforeParseEndTime, 0, 64)
-	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
-	}
+// purchase decodes the body of Purchase and PurchaseV2 and makes the purchase, replying UserID as the buyer.
+func (h *Transaction) purchase(c *gin.Context) entity.PurchaseJSON {
+	req := entity.PurchaseJSON{}
+	bindJSON(c, &req)
+	req.UserID = buyer(c, req.UserID)
 
-	result, err := h.db.User.ListTopTransactionAmount(c, topNumber, startTime, endTime)
-	if errors.Is(err, errorhandler.ErrInvalidArguments) {
-		panic(errorhandler.NewErrVariable(err))
-	}
-	if err != nil {
+	if err := h.db.Product.Purchase(c, utils.ParseUUID(req.UserID), utils.ParseUUID(req.PharmacyID), utils.ParseUUID(req.ProductID), req.Quantity); err != nil {
 		panic(errorhandler.NewErrDBExecute(err))
 	}
+	return req
+}
+
+// The top x users by total transaction amount of masks within a date range.
+func (h *Transaction) ListTransactionTop(c *gin.Context) {
+	result := h.listTransactionTop(c)
 	resp := &entity.TopTransactionAmountListJSON{}
 	var topTransactionAmountUser []*entity.TopTransactionAmountUserJSON
 	for _, item := range result.TopTransactionAmountUsers {
@@ -103,19 +144,114 @@ func (h *Transaction) ListTransactionTop(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// listTransactionTop parses the query of ListTransactionTop and ListTransactionTopV2 and runs it.
+func (h *Transaction) listTransactionTop(c *gin.Context) *entity.TopTransactionAmountList {
+	query := struct {
+		timeRangeQuery
+		TopNumber int64 `query:"top_number"`
+	}{timeRangeQuery: defaultTimeRangeQuery(), TopNumber: 10}
+	bind(c, &query)
+
+	result, err := h.db.User.ListTopTransactionAmount(c, query.TopNumber, query.StartTime, query.EndTime)
+	if errors.Is(err, errorhandler.ErrInvalidArguments) {
+		panic(errorhandler.NewErrVariable(err))
+	}
+	if err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return result
+}
+
 // The total number of masks and dollar value of transactions within a date range.
 func (h *Transaction) GetTransactionTotal(c *gin.Context) {
-	beforeParseStartTime := c.DefaultQuery("utc0_millisecond_start_timestamp", "0")
-	startTime, err := strconv.ParseInt(beforeParseStartTime, 0, 64)
+	result := h.getTransactionTotal(c)
+	c.JSON(http.StatusOK, result)
+}
+
+// getTransactionTotal parses the query of GetTransactionTotal and GetTransactionTotalV2 and runs it.
+func (h *Transaction) getTransactionTotal(c *gin.Context) *entity.TransactionTotal {
+	query := defaultTimeRangeQuery()
+	bind(c, &query)
+
+	result, err := h.db.User.GetTransactionTotal(c, query.StartTime, query.EndTime)
 	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return result
+}
+
+// The number and dollar value of transactions within a date range for each canonical mask, across all pharmacies.
+func (h *Transaction) ListTransactionByMask(c *gin.Context) {
+	result := h.listTransactionByMask(c)
+	resp := &entity.MaskTransactionListJSON{}
+	var maskTransactions []*entity.MaskTransactionJSON
+	for _, item := range result.MaskTransactions {
+		maskTransactions = append(maskTransactions, &entity.MaskTransactionJSON{
+			MaskTransaction: item,
+			MaskID:          utils.FromUUID(item.MaskID),
+		})
 	}
-	beforeParseEndTime := c.DefaultQuery("utc0_millisecond_end_timestamp", strconv.FormatInt(GetNowTimestamp(), 10))
-	endTime, err := strconv.ParseInt(beforeParseEndTime, 0, 64)
+	resp.MaskTransactions = maskTransactions
+	c.JSON(http.StatusOK, resp)
+}
+
+// listTransactionByMask parses the query of ListTransactionByMask and ListTransactionByMaskV2 and runs it.
+func (h *Transaction) listTransactionByMask(c *gin.Context) *entity.MaskTransactionList {
+	query := defaultTimeRangeQuery()
+	bind(c, &query)
+
+	result, err := h.db.Mask.ListTransactionAmount(c, query.StartTime, query.EndTime)
 	if err != nil {
-		panic(errorhandler.NewErrVariable(err))
+		panic(errorhandler.NewErrDBExecute(err))
 	}
+	return result
+}
 
-	result, err := h.db.User.GetTransactionTotal(c, startTime, endTime)
-	c.JSON(http.StatusOK, result)
+// PurchaseV2 is Purchase replying the purchase made within an Envelope.
+func (h *Transaction) PurchaseV2(c *gin.Context) {
+	req := h.purchase(c)
+	reply(c, entity.PurchaseV2{
+		UserID:     req.UserID,
+		PharmacyID: req.PharmacyID,
+		ProductID:  req.ProductID,
+		Quantity:   req.Quantity,
+	})
+}
+
+// ListTransactionTopV2 is ListTransactionTop replying within an Envelope.
+func (h *Transaction) ListTransactionTopV2(c *gin.Context) {
+	result := h.listTransactionTop(c)
+	users := make([]*entity.TopTransactionAmountUserV2, 0, len(result.TopTransactionAmountUsers))
+	for _, item := range result.TopTransactionAmountUsers {
+		users = append(users, &entity.TopTransactionAmountUserV2{
+			UID:               utils.FromUUID(item.UID),
+			Name:              item.Name,
+			TransactionAmount: item.TransactionAmount,
+		})
+	}
+	reply(c, users)
+}
+
+// GetTransactionTotalV2 is GetTransactionTotal replying within an Envelope.
+func (h *Transaction) GetTransactionTotalV2(c *gin.Context) {
+	result := h.getTransactionTotal(c)
+	reply(c, entity.TransactionTotalV2{
+		Total:             result.Total,
+		TransactionAmount: result.TransactionAmount,
+	})
+}
+
+// ListTransactionByMaskV2 is ListTransactionByMask replying within an Envelope.
+func (h *Transaction) ListTransactionByMaskV2(c *gin.Context) {
+	result := h.listTransactionByMask(c)
+	maskTransactions := make([]*entity.MaskTransactionV2, 0, len(result.MaskTransactions))
+	for _, item := range result.MaskTransactions {
+		maskTransactions = append(maskTransactions, &entity.MaskTransactionV2{
+			MaskID:            utils.FromUUID(item.MaskID),
+			Name:              item.Name,
+			Total:             item.Total,
+			TransactionAmount: item.TransactionAmount,
+		})
+	}
+	reply(c, maskTransactions)
 }
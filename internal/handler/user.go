@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/utils"
+	"go.uber.org/zap"
+	"net/http"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/openapi"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strings"
+)
+
+// normalizeEmail is the email as the credentials keep it, so that its case does not tell two accounts apart.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func NewUser(
+	logger *zap.Logger,
+	db spannerDB.Set,
+	issuer *auth.Issuer,
+) (*User, error) {
+	return &User{
+		logger: logger,
+		db:     db,
+		issuer: issuer,
+	}, nil
+}
+
+type User struct {
+	logger *zap.Logger
+	db     spannerDB.Set
+	issuer *auth.Issuer
+}
+
+func (h *User) BindRoute(route *gin.Engine) {
+	adminGroup := route.Group("/user")
+	{
+		v1Group := adminGroup.Group("/v1")
+		v1Group.POST("/register", h.Register)
+		v1Group.POST("/login", h.Login)
+		v1Group.GET("/me", guard(h.issuer, auth.RoleCustomer), h.GetProfile)
+		v1Group.PUT("/me", guard(h.issuer, auth.RoleCustomer), h.PutProfile)
+		v1Group.PUT("/me/password", guard(h.issuer, auth.RoleCustomer), h.PutPassword)
+
+		v2Group := adminGroup.Group("/v2", envelopeErrors())
+		v2Group.POST("/register", h.RegisterV2)
+		v2Group.POST("/login", h.LoginV2)
+		v2Group.GET("/me", guard(h.issuer, auth.RoleCustomer), h.GetProfileV2)
+		v2Group.PUT("/me", guard(h.issuer, auth.RoleCustomer), h.PutProfileV2)
+		v2Group.PUT("/me/password", guard(h.issuer, auth.RoleCustomer), h.PutPasswordV2)
+	}
+}
+
+// Docs documents the routes BindRoute registers.
+func (h *User) Docs() []openapi.Route {
+	routes := []openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     "/user/v1/register",
+			Summary:  "Register a user with an email and password, starting with no cash balance",
+			Tag:      "user",
+			Body:     entity.RegisterJSON{},
+			Response: entity.ProfileJSON{},
+			Errors:   problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeEmailTaken, CodeStorageFailure),
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/user/v1/login",
+			Summary:  "Issue a customer token of the user of an email and password",
+			Tag:      "user",
+			Body:     entity.LoginJSON{},
+			Response: entity.Token{},
+			Errors:   problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeUnauthenticated, CodeStorageFailure),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/user/v1/me",
+			Summary:  "Profile of the user of the token",
+			Tag:      "user",
+			Response: entity.ProfileJSON{},
+			Errors:   problemDocs(CodeUnauthenticated, CodePermissionDenied, CodeUserNotFound, CodeStorageFailure),
+			Roles:    roleDocs(auth.RoleCustomer),
+		},
+		{
+			Method:   http.MethodPut,
+			Path:     "/user/v1/me",
+			Summary:  "Rename the user of the token",
+			Tag:      "user",
+			Body:     entity.ProfilePutJSON{},
+			Response: entity.ProfileJSON{},
+			Errors: problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeUnauthenticated, CodePermissionDenied,
+				CodeUserNotFound, CodeStorageFailure),
+			Roles: roleDocs(auth.RoleCustomer),
+		},
+		{
+			Method:   http.MethodPut,
+			Path:     "/user/v1/me/password",
+			Summary:  "Change the password of the user of the token, given the current one",
+			Tag:      "user",
+			Body:     entity.PasswordPutJSON{},
+			Response: "",
+			Errors: problemDocs(CodeMalformedBody, CodeInvalidArgument, CodeUnauthenticated, CodePermissionDenied,
+				CodeUserNotFound, CodeCredentialNotFound, CodeStorageFailure),
+			Roles: roleDocs(auth.RoleCustomer),
+		},
+	}
+	return append(routes,
+		asV2(routes[0], entity.Envelope[entity.ProfileV2]{}),
+		asV2(routes[1], entity.Envelope[entity.Token]{}),
+		asV2(routes[2], entity.Envelope[entity.ProfileV2]{}),
+		asV2(routes[3], entity.Envelope[entity.ProfileV2]{}),
+		asV2(routes[4], entity.Envelope[entity.ProfileV2]{}),
+	)
+}
+
+// Register creates a user along with the credential logging it in, the email being case insensitive.
+func (h *User) Register(c *gin.Context) {
+	result := h.register(c)
+	c.JSON(http.StatusOK, &entity.ProfileJSON{Profile: result, UID: utils.FromUUID(result.UID)})
+}
+
+// register decodes the body of Register and RegisterV2 and registers the user.
+func (h *User) register(c *gin.Context) *entity.Profile {
+	req := entity.RegisterJSON{}
+	bindJSON(c, &req)
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		panic(errorhandler.NewErrServerExecute(err))
+	}
+	uid := uuid.New()
+	email := normalizeEmail(req.Email)
+	if err := h.db.User.Register(c, entity.User{
+		UID:  uid[:],
+		Name: req.Name,
+	}, entity.Credential{
+		UID:          uid[:],
+		Email:        email,
+		PasswordHash: hash,
+	}); err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return h.getProfile(c, uid[:])
+}
+
+// Login issues a customer token, whose subject is the user id, of the user of the email and password.
+func (h *User) Login(c *gin.Context) {
+	c.JSON(http.StatusOK, h.login(c))
+}
+
+// login decodes the body of Login and LoginV2 and issues the token, telling neither an unknown email nor a wrong
+// password apart, by their reply or the time it takes.
+func (h *User) login(c *gin.Context) entity.Token {
+	req := entity.LoginJSON{}
+	bindJSON(c, &req)
+
+	credential, err := h.db.User.GetCredential(c, normalizeEmail(req.Email))
+	if errors.Is(err, storage.ErrCredentialNotFound) {
+		panic(errorhandler.NewErrAuthenticate(auth.CheckNoPassword(req.Password)))
+	}
+	if err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	if err := auth.CheckPassword(credential.PasswordHash, req.Password); err != nil {
+		panic(errorhandler.NewErrAuthenticate(err))
+	}
+
+	token, err := h.issuer.Issue(auth.NewClaims(auth.RoleCustomer, utils.FromUUID(credential.UID), ""))
+	if err != nil {
+		panic(errorhandler.NewErrServerExecute(err))
+	}
+	return token
+}
+
+// GetProfile replies the profile of the user of the customer token.
+func (h *User) GetProfile(c *gin.Context) {
+	result := h.getProfile(c, customerID(c))
+	c.JSON(http.StatusOK, &entity.ProfileJSON{Profile: result, UID: utils.FromUUID(result.UID)})
+}
+
+func (h *User) getProfile(c *gin.Context, userID []byte) *entity.Profile {
+	result, err := h.db.User.GetProfile(c, userID)
+	if err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return result
+}
+
+// PutProfile renames the user of the customer token, replying its profile.
+func (h *User) PutProfile(c *gin.Context) {
+	result := h.putProfile(c)
+	c.JSON(http.StatusOK, &entity.ProfileJSON{Profile: result, UID: utils.FromUUID(result.UID)})
+}
+
+// putProfile decodes the body of PutProfile and PutProfileV2 and renames the user.
+func (h *User) putProfile(c *gin.Context) *entity.Profile {
+	userID := customerID(c)
+	req := entity.ProfilePutJSON{}
+	bindJSON(c, &req)
+
+	if err := h.db.User.UpdateName(c, userID, req.Name); err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return h.getProfile(c, userID)
+}
+
+// PutPassword changes the password of the user of the customer token, the current password of the body being right.
+// The imported users have no credential to change.
+func (h *User) PutPassword(c *gin.Context) {
+	h.putPassword(c)
+	c.String(http.StatusOK, "ok")
+}
+
+// putPassword decodes the body of PutPassword and PutPasswordV2 and changes the password.
+func (h *User) putPassword(c *gin.Context) *entity.Profile {
+	userID := customerID(c)
+	req := entity.PasswordPutJSON{}
+	bindJSON(c, &req)
+
+	profile := h.getProfile(c, userID)
+	if profile.Email == "" {
+		panic(errorhandler.NewErrDBExecute(storage.ErrCredentialNotFound))
+	}
+	credential, err := h.db.User.GetCredential(c, profile.Email)
+	if err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	if err := auth.CheckPassword(credential.PasswordHash, req.Password); err != nil {
+		panic(errorhandler.NewErrAuthenticate(err))
+	}
+	hash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		panic(errorhandler.NewErrServerExecute(err))
+	}
+	if err := h.db.User.UpdatePassword(c, userID, hash); err != nil {
+		panic(errorhandler.NewErrDBExecute(err))
+	}
+	return profile
+}
+
+// profileV2 is the /v2 reply of profile.
+func profileV2(profile *entity.Profile) entity.ProfileV2 {
+	return entity.ProfileV2{
+		UID:         utils.FromUUID(profile.UID),
+		Name:        profile.Name,
+		Email:       profile.Email,
+		CashBalance: profile.CashBalance,
+		CreatedTime: profile.CreatedTime,
+	}
+}
+
+// RegisterV2 is Register replying within an Envelope.
+func (h *User) RegisterV2(c *gin.Context) {
+	reply(c, profileV2(h.register(c)))
+}
+
+// LoginV2 is Login replying within an Envelope.
+func (h *User) LoginV2(c *gin.Context) {
+	reply(c, h.login(c))
+}
+
+// GetProfileV2 is GetProfile replying within an Envelope.
+func (h *User) GetProfileV2(c *gin.Context) {
+	reply(c, profileV2(h.getProfile(c, customerID(c))))
+}
+
+// PutProfileV2 is PutProfile replying within an Envelope.
+func (h *User) PutProfileV2(c *gin.Context) {
+	reply(c, profileV2(h.putProfile(c)))
+}
+
+// PutPasswordV2 is PutPassword replying the profile of the user within an Envelope.
+func (h *User) PutPasswordV2(c *gin.Context) {
+	reply(c, profileV2(h.putPassword(c)))
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/utils"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strings"
+	"testing"
+)
+
+// memUser keeps the registered users in memory, by user id.
+type memUser struct {
+	storage.IUser
+	profiles    map[string]*entity.Profile
+	credentials map[string]*entity.Credential
+}
+
+func newMemUser() memUser {
+	return memUser{profiles: map[string]*entity.Profile{}, credentials: map[string]*entity.Credential{}}
+}
+
+func (f memUser) Register(ctx context.Context, user entity.User, credential entity.Credential) error {
+	for _, item := range f.credentials {
+		if item.Email == credential.Email {
+			return storage.ErrEmailTaken
+		}
+	}
+	f.profiles[utils.FromUUID(user.UID)] = &entity.Profile{UID: user.UID, Name: user.Name, Email: credential.Email}
+	f.credentials[utils.FromUUID(user.UID)] = &credential
+	return nil
+}
+
+func (f memUser) GetCredential(ctx context.Context, email string) (*entity.Credential, error) {
+	for _, item := range f.credentials {
+		if item.Email == email {
+			return item, nil
+		}
+	}
+	return nil, storage.ErrCredentialNotFound
+}
+
+func (f memUser) GetProfile(ctx context.Context, userID []byte) (*entity.Profile, error) {
+	profile, ok := f.profiles[utils.FromUUID(userID)]
+	if !ok {
+		return nil, storage.ErrUserNotFound
+	}
+	result := *profile
+	return &result, nil
+}
+
+func (f memUser) UpdateName(ctx context.Context, userID []byte, name string) error {
+	profile, ok := f.profiles[utils.FromUUID(userID)]
+	if !ok {
+		return storage.ErrUserNotFound
+	}
+	profile.Name = name
+	return nil
+}
+
+func (f memUser) UpdatePassword(ctx context.Context, userID []byte, passwordHash string) error {
+	credential, ok := f.credentials[utils.FromUUID(userID)]
+	if !ok {
+		return storage.ErrCredentialNotFound
+	}
+	credential.PasswordHash = passwordHash
+	return nil
+}
+
+// fakeProductBuyer records the buyer of the purchases.
+type fakeProductBuyer struct {
+	fakeProduct
+	buyer *string
+}
+
+func (f fakeProductBuyer) Purchase(ctx context.Context, userID, pharmacyID, productID []byte, quantity int) error {
+	*f.buyer = utils.FromUUID(userID)
+	return nil
+}
+
+type UserSuite struct {
+	suite.Suite
+	route  *gin.Engine
+	issuer *auth.Issuer
+	users  memUser
+	buyer  string
+	cost   int
+}
+
+func (suite *UserSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.cost = auth.PasswordCost
+	auth.PasswordCost = bcrypt.MinCost
+	suite.issuer = newTestIssuer()
+	suite.users = newMemUser()
+	suite.buyer = ""
+	db := spannerDB.Set{
+		User:    suite.users,
+		Product: fakeProductBuyer{buyer: &suite.buyer},
+	}
+	reply := func(c *gin.Context) {}
+	suite.route = gin.New()
+	suite.route.Use(errorhandler.GinPanicErrorHandler("test", ""))
+	transaction, _ := NewTransaction(nil, db, suite.issuer)
+	user, _ := NewUser(nil, db, suite.issuer)
+	AddRoutes(suite.route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, Set{
+		Pharmacy:    &Pharmacy{},
+		Transaction: transaction,
+		GraphQL:     &GraphQL{},
+		Auth:        &Auth{},
+		User:        user,
+	})
+}
+
+func (suite *UserSuite) TearDownTest() {
+	auth.PasswordCost = suite.cost
+}
+
+// register registers the user of email and logs it in, returning its profile and the Authorization header.
+func (suite *UserSuite) register(email, password string) (entity.ProfileJSON, string) {
+	w := suite.do(http.MethodPost, "/user/v1/register", "",
+		`{"name":"Yvonne Guerrero","email":"`+email+`","password":"`+password+`"}`)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	profile := entity.ProfileJSON{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &profile))
+
+	w = suite.do(http.MethodPost, "/user/v1/login", "",
+		`{"email":"`+email+`","password":"`+password+`"}`)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	token := entity.Token{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &token))
+	return profile, auth.TokenType + " " + token.AccessToken
+}
+
+func (suite *UserSuite) do(method, url, authorization, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	suite.route.ServeHTTP(w, req)
+	return w
+}
+
+// request replies the status and the problem code of the request, empty on success.
+func (suite *UserSuite) request(method, url, authorization, body string) (int, string) {
+	w := suite.do(method, url, authorization, body)
+	if w.Code == http.StatusOK {
+		return w.Code, ""
+	}
+	problem := entity.Problem{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+	return w.Code, problem.Code
+}
+
+func (suite *UserSuite) TestRegisterLogin() {
+	profile, authorization := suite.register("Yvonne@Example.com", "correct horse")
+	suite.NotEmpty(profile.UID)
+	suite.Equal("yvonne@example.com", profile.Email)
+	suite.Equal(float64(0), profile.CashBalance)
+	suite.NotEqual("correct horse", suite.users.credentials[profile.UID].PasswordHash)
+
+	claims, err := suite.issuer.Parse(authorization[len(auth.TokenType)+1:])
+	suite.Require().NoError(err)
+	suite.Equal(auth.RoleCustomer, claims.Role)
+	suite.Equal(profile.UID, claims.Subject)
+
+	testCases := []struct {
+		Label  string
+		URL    string
+		Body   string
+		Status int
+		Code   string
+	}{
+		{Label: "Email taken", URL: "/user/v1/register", Body: `{"name":"Other","email":"yvonne@example.COM","password":"another horse"}`, Status: http.StatusConflict, Code: CodeEmailTaken},
+		{Label: "Short password", URL: "/user/v1/register", Body: `{"name":"Other","email":"other@example.com","password":"short"}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Invalid email", URL: "/user/v1/register", Body: `{"name":"Other","email":"other","password":"another horse"}`, Status: http.StatusBadRequest, Code: CodeInvalidArgument},
+		{Label: "Wrong password", URL: "/user/v1/login", Body: `{"email":"yvonne@example.com","password":"wrong horse"}`, Status: http.StatusUnauthorized, Code: CodeUnauthenticated},
+		{Label: "Unknown email", URL: "/user/v1/login", Body: `{"email":"other@example.com","password":"correct horse"}`, Status: http.StatusUnauthorized, Code: CodeUnauthenticated},
+	}
+	for _, testCase := range testCases {
+		suite.Run(testCase.Label, func() {
+			status, code := suite.request(http.MethodPost, testCase.URL, "", testCase.Body)
+			suite.Equal(testCase.Status, status)
+			suite.Equal(testCase.Code, code)
+		})
+	}
+
+	// an unknown email and a wrong password reply the same
+	wrongPassword := entity.Problem{}
+	w := suite.do(http.MethodPost, "/user/v1/login", "", `{"email":"yvonne@example.com","password":"wrong horse"}`)
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &wrongPassword))
+	unknownEmail := entity.Problem{}
+	w = suite.do(http.MethodPost, "/user/v1/login", "", `{"email":"other@example.com","password":"correct horse"}`)
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &unknownEmail))
+	suite.NotEmpty(wrongPassword.Detail)
+	suite.Equal(wrongPassword.Detail, unknownEmail.Detail)
+	suite.Equal(wrongPassword, unknownEmail)
+}
+
+func (suite *UserSuite) TestProfile() {
+	profile, authorization := suite.register("yvonne@example.com", "correct horse")
+
+	w := suite.do(http.MethodPut, "/user/v2/me", authorization, `{"name":"Yvonne G."}`)
+	suite.Equal(http.StatusOK, w.Code)
+	resp := entity.Envelope[entity.ProfileV2]{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Equal(profile.UID, resp.Data.UID)
+	suite.Equal("Yvonne G.", resp.Data.Name)
+
+	w = suite.do(http.MethodGet, "/user/v1/me", authorization, "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &profile))
+	suite.Equal("Yvonne G.", profile.Name)
+	suite.Equal("yvonne@example.com", profile.Email)
+
+	status, code := suite.request(http.MethodGet, "/user/v1/me", bearer(suite.issuer, auth.RoleAdmin, "ops", ""), "")
+	suite.Equal(http.StatusForbidden, status)
+	suite.Equal(CodePermissionDenied, code)
+	status, code = suite.request(http.MethodGet, "/user/v1/me", bearer(suite.issuer, auth.RoleCustomer, testUserID, ""), "")
+	suite.Equal(http.StatusNotFound, status)
+	suite.Equal(CodeUserNotFound, code)
+}
+
+func (suite *UserSuite) TestPutPassword() {
+	_, authorization := suite.register("yvonne@example.com", "correct horse")
+
+	status, code := suite.request(http.MethodPut, "/user/v1/me/password", authorization,
+		`{"password":"wrong horse","new_password":"battery staple"}`)
+	suite.Equal(http.StatusUnauthorized, status)
+	suite.Equal(CodeUnauthenticated, code)
+
+	status, _ = suite.request(http.MethodPut, "/user/v1/me/password", authorization,
+		`{"password":"correct horse","new_password":"battery staple"}`)
+	suite.Equal(http.StatusOK, status)
+	status, _ = suite.request(http.MethodPost, "/user/v1/login", "",
+		`{"email":"yvonne@example.com","password":"battery staple"}`)
+	suite.Equal(http.StatusOK, status)
+	status, _ = suite.request(http.MethodPost, "/user/v1/login", "",
+		`{"email":"yvonne@example.com","password":"correct horse"}`)
+	suite.Equal(http.StatusUnauthorized, status)
+}
+
+func (suite *UserSuite) TestPurchaseAsTheToken() {
+	profile, authorization := suite.register("yvonne@example.com", "correct horse")
+
+	w := suite.do(http.MethodPost, "/transaction/v2/purchase", authorization,
+		purchaseBody("", "00000000-0000-0000-0000-000000000004"))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(profile.UID, suite.buyer)
+	resp := entity.Envelope[entity.PurchaseV2]{}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Equal(profile.UID, resp.Data.UserID)
+
+	status, code := suite.request(http.MethodPost, "/transaction/v1/purchase", authorization,
+		purchaseBody(testUserID, "00000000-0000-0000-0000-000000000004"))
+	suite.Equal(http.StatusForbidden, status)
+	suite.Equal(CodePermissionDenied, code)
+}
+
+func TestUserSuite(t *testing.T) {
+	suite.Run(t, new(UserSuite))
+}
@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+// BearerScheme names the security scheme of the routes requiring a bearer token.
+const BearerScheme = "bearerAuth"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps the lower case http methods of a path to their operation.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Default    interface{}        `json:"default,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Route documents a route as it is registered on gin.
+type Route struct {
+	Method string
+	// Path is the gin path, its :name segments become path parameters
+	Path    string
+	Summary string
+	Tag     string
+	Query   []Parameter
+	// Body is a value of the JSON request body type, nil when the route takes none
+	Body interface{}
+	// Response is a value of the JSON response type, a string for a text response
+	Response interface{}
+	// Errors are the error responses of the route, a 400 and a 500 without body when empty
+	Errors []ErrorResponse
+	// Roles are the roles of the bearer token the route requires, the route is public when empty
+	Roles []string
+}
+
+// ErrorResponse documents the error responses of a status.
+type ErrorResponse struct {
+	Status      int
+	Description string
+	// ContentType is the media type of Body, application/json when empty
+	ContentType string
+	// Body is a value of the response body type, nil when the response has none
+	Body interface{}
+}
+
+// QueryParam documents a query parameter typed after value, a non zero value is its default.
+func QueryParam(name, description string, value interface{}) Parameter {
+	schema := primitiveSchema(reflect.TypeOf(value))
+	if !reflect.ValueOf(value).IsZero() {
+		schema.Default = value
+	}
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      schema,
+	}
+}
+
+var ginParamSyntax = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Path converts a gin path to an OpenAPI one.
+func Path(ginPath string) string {
+	return ginParamSyntax.ReplaceAllString(ginPath, "{$1}")
+}
+
+// New documents routes.
+func New(title, version string, routes ...Route) *Document {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+	gen := newGenerator(doc.Components.Schemas)
+	for _, route := range routes {
+		path := Path(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+		operation := &Operation{
+			OperationID: operationID(route.Method, path),
+			Summary:     route.Summary,
+			Responses: map[string]Response{
+				"200": gen.response(route.Response),
+			},
+		}
+		if len(route.Errors) == 0 {
+			operation.Responses["400"] = Response{Description: "invalid arguments"}
+			operation.Responses["500"] = Response{Description: "internal error"}
+		}
+		for _, item := range route.Errors {
+			operation.Responses[strconv.Itoa(item.Status)] = gen.errorResponse(item)
+		}
+		if len(route.Roles) > 0 {
+			operation.Description = "Requires a bearer token of role " + strings.Join(route.Roles, " or ")
+			operation.Security = []map[string][]string{{BearerScheme: {}}}
+			doc.Components.SecuritySchemes = map[string]*SecurityScheme{
+				BearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			}
+		}
+		if route.Tag != "" {
+			operation.Tags = []string{route.Tag}
+		}
+		for _, match := range ginParamSyntax.FindAllStringSubmatch(route.Path, -1) {
+			operation.Parameters = append(operation.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		operation.Parameters = append(operation.Parameters, route.Query...)
+		if route.Body != nil {
+			operation.RequestBody = &RequestBody{
+				Required: true,
+				Content: map[string]MediaType{
+					"application/json": {Schema: gen.schema(reflect.TypeOf(route.Body))},
+				},
+			}
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+	return doc
+}
+
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment == "" {
+			continue
+		}
+		id += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return id
+}
+
+func (gen *generator) response(value interface{}) Response {
+	if value == nil {
+		return Response{Description: http.StatusText(http.StatusOK)}
+	}
+	if _, ok := value.(string); ok {
+		return Response{
+			Description: http.StatusText(http.StatusOK),
+			Content: map[string]MediaType{
+				"text/plain": {Schema: &Schema{Type: "string"}},
+			},
+		}
+	}
+	return Response{
+		Description: http.StatusText(http.StatusOK),
+		Content: map[string]MediaType{
+			"application/json": {Schema: gen.schema(reflect.TypeOf(value))},
+		},
+	}
+}
+
+func (gen *generator) errorResponse(item ErrorResponse) Response {
+	response := Response{Description: item.Description}
+	if item.Body == nil {
+		return response
+	}
+	contentType := item.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	response.Content = map[string]MediaType{
+		contentType: {Schema: gen.schema(reflect.TypeOf(item.Body))},
+	}
+	return response
+}
@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	ID      []byte `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Private string `json:"-"`
+}
+
+type outer struct {
+	*inner
+	ID      string          `json:"id" validate:"required"`
+	Created time.Time       `json:"created,omitempty"`
+	Tags    []string        `json:"tags,omitempty"`
+	Child   *outer          `json:"child,omitempty"`
+	Raw     json.RawMessage `json:"raw,omitempty"`
+}
+
+type generic[T any] struct {
+	Data T `json:"data"`
+}
+
+type OpenAPISuite struct {
+	suite.Suite
+}
+
+func (suite *OpenAPISuite) TestPath() {
+	suite.Equal("/pharmacy/v1/{PharmacyID}/product", Path("/pharmacy/v1/:PharmacyID/product"))
+	suite.Equal("/static/{filepath}", Path("/static/*filepath"))
+}
+
+func (suite *OpenAPISuite) TestSchema() {
+	schemas := map[string]*Schema{}
+	gen := newGenerator(schemas)
+	suite.Equal(&Schema{Ref: "#/components/schemas/outer"}, gen.schema(reflect.TypeOf(&outer{})))
+	suite.Equal(&Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":      {Type: "string"},
+			"name":    {Type: "string"},
+			"created": {Type: "string", Format: "date-time"},
+			"tags":    {Type: "array", Items: &Schema{Type: "string"}},
+			"child":   {Ref: "#/components/schemas/outer"},
+			"raw":     {},
+		},
+		Required: []string{"id"},
+	}, schemas["outer"])
+}
+
+func (suite *OpenAPISuite) TestGenericName() {
+	gen := newGenerator(map[string]*Schema{})
+	suite.Equal("generic_inner", gen.name(reflect.TypeOf(generic[inner]{})))
+	suite.Equal("generic_Listouter", gen.name(reflect.TypeOf(generic[[]*outer]{})))
+	suite.Equal("generic_Time", gen.name(reflect.TypeOf(generic[time.Time]{})))
+}
+
+func (suite *OpenAPISuite) TestNew() {
+	doc := New("test", "1.0.0",
+		Route{
+			Method:   http.MethodGet,
+			Path:     "/item/:ID",
+			Query:    []Parameter{QueryParam("row", "", uint64(10))},
+			Response: outer{},
+		},
+		Route{Method: http.MethodPost, Path: "/item/:ID", Body: inner{}, Response: "", Roles: []string{"admin"}},
+	)
+	suite.Equal(Version, doc.OpenAPI)
+	suite.Len(doc.Paths, 1)
+	get := doc.Paths["/item/{ID}"]["get"]
+	suite.Equal("getItemID", get.OperationID)
+	suite.Equal([]Parameter{
+		{Name: "ID", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "row", In: "query", Schema: &Schema{Type: "integer", Format: "int64", Default: uint64(10)}},
+	}, get.Parameters)
+	suite.Equal(&Schema{Ref: "#/components/schemas/outer"}, get.Responses["200"].Content["application/json"].Schema)
+	post := doc.Paths["/item/{ID}"]["post"]
+	suite.Equal(&Schema{Ref: "#/components/schemas/inner"}, post.RequestBody.Content["application/json"].Schema)
+	suite.Contains(post.Responses["200"].Content, "text/plain")
+	suite.Nil(get.Security)
+	suite.Equal([]map[string][]string{{BearerScheme: {}}}, post.Security)
+	suite.Equal("bearer", doc.Components.SecuritySchemes[BearerScheme].Scheme)
+	suite.Contains(doc.Components.Schemas, "inner")
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPISuite))
+}
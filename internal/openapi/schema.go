@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	bytesType     = reflect.TypeOf([]byte{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+	// typeArgPackage matches the package a type argument is qualified by within the name of a generic type
+	typeArgPackage   = regexp.MustCompile(`[\w./-]*\.`)
+	typeArgSeparator = strings.NewReplacer("[]", "List", "[", "_", "]", "", "*", "", ",", "_", " ", "", "{", "", "}", "")
+)
+
+type generator struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func newGenerator(schemas map[string]*Schema) *generator {
+	return &generator{
+		schemas: schemas,
+		types:   map[string]reflect.Type{},
+	}
+}
+
+// primitiveSchema is the schema of the non struct types, nil for the others.
+func primitiveSchema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == bytesType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	}
+	return nil
+}
+
+// schema describes t as encoding/json writes it, named structs are added to the components and referenced.
+func (gen *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema := primitiveSchema(t); schema != nil {
+		return schema
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		// written by its own MarshalJSON, any value
+		return &Schema{}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: gen.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return gen.object(t)
+		}
+		name := gen.name(t)
+		if _, ok := gen.schemas[name]; !ok {
+			// registered before the fields so recursive types refer to themselves
+			gen.schemas[name] = &Schema{}
+			*gen.schemas[name] = *gen.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{}
+}
+
+// name is the component name of t, qualified by its package when another type already has its name.
+// Generic types are named after their type arguments, Envelope[[]*entity.Mask] being Envelope_ListMask.
+func (gen *generator) name(t reflect.Type) string {
+	name := typeArgSeparator.Replace(typeArgPackage.ReplaceAllString(t.Name(), ""))
+	if known, ok := gen.types[name]; ok && known != t {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	gen.types[name] = t
+	return name
+}
+
+type jsonField struct {
+	Name      string
+	Depth     int
+	Type      reflect.Type
+	Required  bool
+	Sequence  int
+	Ambiguous bool
+}
+
+// object lists the fields encoding/json writes for t, a field of an embedded struct is hidden by a shallower one of
+// the same name.
+func (gen *generator) object(t reflect.Type) *Schema {
+	fields := map[string]*jsonField{}
+	sequence := 0
+	var walk func(t reflect.Type, depth int)
+	walk = func(t reflect.Type, depth int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, options, _ := strings.Cut(tag, ",")
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+				walk(fieldType, depth+1)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			sequence++
+			current := &jsonField{
+				Name:     name,
+				Depth:    depth,
+				Type:     field.Type,
+				Required: strings.Contains(field.Tag.Get("validate"), "required") || !strings.Contains(options, "omitempty"),
+				Sequence: sequence,
+			}
+			known, ok := fields[name]
+			switch {
+			case !ok || depth < known.Depth:
+				fields[name] = current
+			case depth == known.Depth:
+				known.Ambiguous = true
+			}
+		}
+	}
+	walk(t, 0)
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	ordered := make([]*jsonField, 0, len(fields))
+	for _, field := range fields {
+		if !field.Ambiguous {
+			ordered = append(ordered, field)
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Sequence < ordered[j].Sequence
+	})
+	for _, field := range ordered {
+		schema.Properties[field.Name] = gen.schema(field.Type)
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return schema
+}
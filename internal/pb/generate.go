@@ -0,0 +1,4 @@
+// Package pb is the gRPC API generated from phantom_mask.proto, served by the rpc package.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative phantom_mask.proto
@@ -0,0 +1,666 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/justdomepaul/toolbox/entity"
+	"github.com/justdomepaul/toolbox/interceptor/authenticate"
+	"github.com/justdomepaul/toolbox/jwt"
+	"github.com/justdomepaul/toolbox/restful"
+	"github.com/justdomepaul/toolbox/utils"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/auth"
+	internalEntity "phantom_mask/internal/entity"
+	"phantom_mask/internal/handler"
+	"phantom_mask/internal/openapi"
+	"phantom_mask/internal/pb"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testPharmacyID = "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+	testProductID  = "6fa459ea-ee8a-3ca4-894e-db77e160355e"
+	testUserID     = "9a7b330a-a736-41e5-b1a5-6b51ac0a2a19"
+)
+
+var testCreatedTime = time.Date(2022, 4, 15, 8, 30, 0, 0, time.UTC)
+
+// recorder keeps the arguments the storage was called with, so both APIs are checked to run the same query.
+type recorder struct {
+	calls [][]interface{}
+}
+
+func (r *recorder) record(args ...interface{}) {
+	r.calls = append(r.calls, args)
+}
+
+type fakePharmacy struct {
+	storage.IPharmacy
+	*recorder
+}
+
+func (f fakePharmacy) ListPharmacyMixProduct(ctx context.Context, row, page uint64, name string, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*internalEntity.PharmacyProductList, error) {
+	f.record("ListPharmacyMixProduct", row, page, name, orderEnum, condition, cursor)
+	return &internalEntity.PharmacyProductList{
+		CommonListResponse: entity.CommonListResponse{Count: 12, Row: int64(row), Page: int64(page)},
+		PharmacyProducts: []*internalEntity.PharmacyProduct{{
+			UID:          utils.ParseUUID(testPharmacyID),
+			ProductID:    utils.ParseUUID(testProductID),
+			PharmacyName: "Better You",
+			CashBalance:  328.41,
+			ProductName:  "Second Smile (black) (6 per pack)",
+			Price:        15.7,
+			Brand:        "Second Smile",
+			Color:        "black",
+			PackSize:     6,
+			Score:        0.75,
+		}},
+		NextPageToken: "next",
+	}, nil
+}
+
+func (f fakePharmacy) ListSpecifyTime(ctx context.Context, row, page uint64, specifyTimestamp int64, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*internalEntity.PharmacySpecifyTimestampList, error) {
+	f.record("ListSpecifyTime", row, page, specifyTimestamp, orderEnum, condition, cursor)
+	return &internalEntity.PharmacySpecifyTimestampList{
+		CommonListResponse: entity.CommonListResponse{Count: 3, Row: int64(row), Page: int64(page)},
+		Pharmacies: []*internalEntity.PharmacySpecifyTimestamp{{
+			UID:         utils.ParseUUID(testPharmacyID),
+			Name:        "Better You",
+			CashBalance: 328.41,
+			CreatedTime: testCreatedTime,
+			Day:         3,
+			OpenHour:    8.5,
+			CloseHour:   20,
+		}},
+		NextPageToken: "next",
+	}, nil
+}
+
+func (f fakePharmacy) ListByProductPriceRange(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*internalEntity.PharmacyProductCountList, error) {
+	f.record("ListByProductPriceRange", row, page, orderEnum, condition, cursor)
+	return &internalEntity.PharmacyProductCountList{
+		CommonListResponse: entity.CommonListResponse{Count: 1, Row: int64(row), Page: int64(page)},
+		Pharmacies: []*internalEntity.PharmacyProductCount{{
+			UID:          utils.ParseUUID(testPharmacyID),
+			Name:         "Better You",
+			CashBalance:  328.41,
+			CreatedTime:  testCreatedTime,
+			ProductCount: 4,
+		}},
+	}, nil
+}
+
+func (f fakePharmacy) ListProductPriceComparison(ctx context.Context, row, page uint64, name string, specifyTimestamp int64, condition storage.PharmacyListCondition) (*internalEntity.ProductPriceComparisonList, error) {
+	f.record("ListProductPriceComparison", row, page, name, specifyTimestamp, condition)
+	return &internalEntity.ProductPriceComparisonList{
+		CommonListResponse: entity.CommonListResponse{Count: 1, Row: int64(row), Page: int64(page)},
+		Products: []*internalEntity.ProductPriceComparison{{
+			Name: "cotton kiss (green) (3 per pack)",
+			Offers: []*internalEntity.PharmacyPriceOffer{{
+				UID:          utils.ParseUUID(testPharmacyID),
+				PharmacyName: "Better You",
+				ProductID:    utils.ParseUUID(testProductID),
+				ProductName:  "Cotton Kiss (green) (3 per pack)",
+				Price:        9.3,
+				PackSize:     3,
+				IsOpen:       true,
+			}},
+		}},
+	}, nil
+}
+
+type fakeProduct struct {
+	storage.IProduct
+	*recorder
+}
+
+func (f fakeProduct) Purchase(ctx context.Context, userID, pharmacyID, productID []byte, quantity int) error {
+	f.record("Purchase", userID, pharmacyID, productID, quantity)
+	return nil
+}
+
+func (f fakeProduct) List(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum, condition storage.ProductListCondition, cursor storage.Cursor) (*internalEntity.ProductList, error) {
+	f.record("List", row, page, orderEnum, condition, cursor)
+	return &internalEntity.ProductList{
+		CommonListResponse: entity.CommonListResponse{Count: 2, Row: int64(row), Page: int64(page)},
+		Products: []*internalEntity.Product{{
+			UID:         utils.ParseUUID(testPharmacyID),
+			ProductID:   utils.ParseUUID(testProductID),
+			Name:        "Cotton Kiss (green) (3 per pack)",
+			Price:       9.3,
+			Brand:       "Cotton Kiss",
+			Color:       "green",
+			PackSize:    3,
+			MaskID:      utils.ParseUUID(testProductID),
+			CreatedTime: testCreatedTime,
+		}},
+		NextPageToken: "next",
+	}, nil
+}
+
+type fakeUser struct {
+	storage.IUser
+	*recorder
+}
+
+func (f fakeUser) ListTopTransactionAmount(ctx context.Context, topNumber, startTime, endTime int64) (*internalEntity.TopTransactionAmountList, error) {
+	f.record("ListTopTransactionAmount", topNumber, startTime, endTime)
+	return &internalEntity.TopTransactionAmountList{
+		TopTransactionAmountUsers: []*internalEntity.TopTransactionAmountUser{{
+			UID:               utils.ParseUUID(testUserID),
+			Name:              "Yvonne Guerrero",
+			TransactionAmount: 191.83,
+		}},
+	}, nil
+}
+
+func (f fakeUser) GetTransactionTotal(ctx context.Context, startTime, endTime int64) (*internalEntity.TransactionTotal, error) {
+	f.record("GetTransactionTotal", startTime, endTime)
+	return &internalEntity.TransactionTotal{Total: 27, TransactionAmount: 341.25}, nil
+}
+
+type fakeMask struct {
+	storage.IMask
+	*recorder
+}
+
+func (f fakeMask) List(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum) (*internalEntity.MaskList, error) {
+	f.record("List", row, page, orderEnum)
+	return &internalEntity.MaskList{
+		CommonListResponse: entity.CommonListResponse{Count: 8, Row: int64(row), Page: int64(page)},
+		Masks: []*internalEntity.Mask{{
+			MaskID:      utils.ParseUUID(testProductID),
+			Name:        "cotton kiss (green) (3 per pack)",
+			Brand:       "Cotton Kiss",
+			Color:       "green",
+			PackSize:    3,
+			CreatedTime: testCreatedTime,
+		}},
+	}, nil
+}
+
+func (f fakeMask) ListTransactionAmount(ctx context.Context, startTime, endTime int64) (*internalEntity.MaskTransactionList, error) {
+	f.record("ListTransactionAmount", startTime, endTime)
+	return &internalEntity.MaskTransactionList{
+		MaskTransactions: []*internalEntity.MaskTransaction{{
+			MaskID:            utils.ParseUUID(testProductID),
+			Name:              "cotton kiss (green) (3 per pack)",
+			Total:             5,
+			TransactionAmount: 46.5,
+		}},
+	}, nil
+}
+
+// normalize decodes a REST body or the protojson of a gRPC response so both compare equal when they carry the same
+// data: keys are lower cased, the int64 protojson quotes are numbers again and the zero values either side omits are dropped.
+func normalize(data []byte) interface{} {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		panic(err)
+	}
+	return normalizeValue(value)
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, item := range v {
+			if item = normalizeValue(item); item != nil {
+				result[strings.ToLower(key)] = item
+			}
+		}
+		if len(result) == 0 {
+			return nil
+		}
+		return result
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, normalizeValue(item))
+		}
+		return result
+	case string:
+		if number, err := strconv.ParseFloat(v, 64); err == nil {
+			return normalizeValue(number)
+		}
+		if v == "" {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	}
+	return value
+}
+
+type ParitySuite struct {
+	suite.Suite
+	issuer   *auth.Issuer
+	recorder *recorder
+	route    *gin.Engine
+	docs     []openapi.Route
+	server   *grpc.Server
+	conn     *grpc.ClientConn
+}
+
+func (suite *ParitySuite) SetupTest() {
+	suite.recorder = &recorder{}
+	db := spannerDB.Set{
+		Pharmacy: fakePharmacy{recorder: suite.recorder},
+		Product:  fakeProduct{recorder: suite.recorder},
+		User:     fakeUser{recorder: suite.recorder},
+		Mask:     fakeMask{recorder: suite.recorder},
+	}
+
+	tool, err := jwt.NewEHS384JWT("test secret")
+	suite.Require().NoError(err)
+	suite.issuer = auth.NewIssuer(tool)
+
+	gin.SetMode(gin.TestMode)
+	suite.route = gin.New()
+	handlerPharmacy, _ := handler.NewPharmacy(nil, db, suite.issuer)
+	handlerTransaction, _ := handler.NewTransaction(nil, db, suite.issuer)
+	handlerGraphQL, _ := handler.NewGraphQL(nil, db, suite.issuer)
+	handlerAuth, _ := handler.NewAuth(nil, suite.issuer)
+	handlerUser, _ := handler.NewUser(nil, db, suite.issuer)
+	reply := func(c *gin.Context) {}
+	handlers := handler.Set{
+		Pharmacy:    handlerPharmacy,
+		Transaction: handlerTransaction,
+		GraphQL:     handlerGraphQL,
+		Auth:        handlerAuth,
+		User:        handlerUser,
+	}
+	handler.AddRoutes(suite.route, restful.CommonHandler{Error404: reply, QuickReply: reply, PromHTTP: reply}, handlers)
+	suite.docs = nil
+	for _, docs := range [][]openapi.Route{handlerPharmacy.Docs(), handlerTransaction.Docs(), handlerGraphQL.Docs(), handlerAuth.Docs(), handlerUser.Docs()} {
+		suite.docs = append(suite.docs, docs...)
+	}
+
+	services := Set{}
+	services.Pharmacy, _ = NewPharmacy(nil, db)
+	services.Search, _ = NewSearch(nil, db)
+	services.Transaction, _ = NewTransaction(nil, db, suite.issuer)
+	services.Report, _ = NewReport(nil, db)
+	listener := bufconn.Listen(1 << 20)
+	suite.server = grpc.NewServer(grpc.UnaryInterceptor(authenticate.UnaryServerInterceptor(NewGuard(suite.issuer))))
+	Register(suite.server, services)
+	go suite.server.Serve(listener)
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.conn = conn
+}
+
+// bearer is the Authorization of a customer token of subject.
+func (suite *ParitySuite) bearer(subject string) string {
+	token, err := suite.issuer.Issue(auth.NewClaims(auth.RoleCustomer, subject, ""))
+	suite.Require().NoError(err)
+	return auth.TokenType + " " + token.AccessToken
+}
+
+func (suite *ParitySuite) TearDownTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+}
+
+// parityCases are every REST route of a gRPC counterpart, called with the same arguments.
+func (suite *ParitySuite) parityCases() []parityCase {
+	pharmacyClient := pb.NewPharmacyServiceClient(suite.conn)
+	searchClient := pb.NewSearchServiceClient(suite.conn)
+	transactionClient := pb.NewTransactionServiceClient(suite.conn)
+	reportClient := pb.NewReportServiceClient(suite.conn)
+	timeRange := &pb.TimeRange{
+		Utc0MillisecondStartTimestamp: 1640000000000,
+		Utc0MillisecondEndTimestamp:   wrapperspb.Int64(1650000000000),
+	}
+
+	return []parityCase{
+		{
+			Label:      "List pharmacies",
+			FullMethod: "/phantom_mask.v1.PharmacyService/ListPharmacies",
+			Method:     http.MethodGet,
+			URL:        "/pharmacy/v1/?page=2&row=5&page_token=abc&with_count=false&sort=-cash_balance&filter=name~you&specify_utc0_millisecond_timestamp=1650000000000",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return pharmacyClient.ListPharmacies(ctx, &pb.ListPharmaciesRequest{
+					List:                            &pb.ListParams{Page: 2, Row: 5, PageToken: "abc", WithoutCount: true, Sort: "-cash_balance", Filter: "name~you"},
+					SpecifyUtc0MillisecondTimestamp: 1650000000000,
+				})
+			},
+		},
+		{
+			Label:      "Search",
+			FullMethod: "/phantom_mask.v1.SearchService/Search",
+			Method:     http.MethodGet,
+			URL:        "/pharmacy/v1/mix?name=smile&sorted=name&brand=second&color=black&pack_size=6&filter=price<20",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return searchClient.Search(ctx, &pb.SearchRequest{
+					List:     &pb.ListParams{Filter: "price<20"},
+					Name:     "smile",
+					Sorted:   "name",
+					Brand:    "second",
+					Color:    "black",
+					PackSize: 6,
+				})
+			},
+		},
+		{
+			Label:      "List masks",
+			FullMethod: "/phantom_mask.v1.PharmacyService/ListMasks",
+			Method:     http.MethodGet,
+			URL:        "/pharmacy/v1/mask?page=3&row=4",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return pharmacyClient.ListMasks(ctx, &pb.ListMasksRequest{Page: 3, Row: 4})
+			},
+		},
+		{
+			Label:      "List products",
+			FullMethod: "/phantom_mask.v1.PharmacyService/ListProducts",
+			Method:     http.MethodGet,
+			URL:        "/pharmacy/v1/" + testPharmacyID + "/product?sorted=price&brand=cotton&sort=-price",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return pharmacyClient.ListProducts(ctx, &pb.ListProductsRequest{
+					List:       &pb.ListParams{Sort: "-price"},
+					PharmacyId: testPharmacyID,
+					Sorted:     "price",
+					Brand:      "cotton",
+				})
+			},
+		},
+		{
+			Label:      "List pharmacies by product price",
+			FullMethod: "/phantom_mask.v1.PharmacyService/ListPharmaciesByProductPrice",
+			Method:     http.MethodGet,
+			URL:        "/pharmacy/v1/product/price?min=5&max=30&count=2&count_operator=lt",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return pharmacyClient.ListPharmaciesByProductPrice(ctx, &pb.ListPharmaciesByProductPriceRequest{
+					Min:           5,
+					Max:           wrapperspb.Int64(30),
+					Count:         wrapperspb.Int64(2),
+					CountOperator: "lt",
+				})
+			},
+		},
+		{
+			Label:      "Compare product price",
+			FullMethod: "/phantom_mask.v1.PharmacyService/CompareProductPrice",
+			Method:     http.MethodGet,
+			URL:        "/pharmacy/v1/product/compare?name=kiss&specify_utc0_millisecond_timestamp=1650000000000&open_only=true",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return pharmacyClient.CompareProductPrice(ctx, &pb.CompareProductPriceRequest{
+					Name:                            "kiss",
+					SpecifyUtc0MillisecondTimestamp: wrapperspb.Int64(1650000000000),
+					OpenOnly:                        true,
+				})
+			},
+		},
+		{
+			Label:      "Purchase",
+			FullMethod: "/phantom_mask.v1.TransactionService/Purchase",
+			Method:     http.MethodPost,
+			URL:        "/transaction/v1/purchase",
+			Body:       `{"pharmacy_id":"` + testPharmacyID + `","product_id":"` + testProductID + `","quantity":2}`,
+			Text:       true,
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return transactionClient.Purchase(ctx, &pb.PurchaseRequest{
+					PharmacyId: testPharmacyID,
+					ProductId:  testProductID,
+					Quantity:   2,
+				})
+			},
+		},
+		{
+			Label:      "List top users",
+			FullMethod: "/phantom_mask.v1.ReportService/ListTopUsers",
+			Method:     http.MethodGet,
+			URL:        "/transaction/v1/transaction/top?top_number=3&utc0_millisecond_start_timestamp=1640000000000&utc0_millisecond_end_timestamp=1650000000000",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return reportClient.ListTopUsers(ctx, &pb.ListTopUsersRequest{TopNumber: 3, Range: timeRange})
+			},
+		},
+		{
+			Label:      "Get transaction total",
+			FullMethod: "/phantom_mask.v1.ReportService/GetTransactionTotal",
+			Method:     http.MethodGet,
+			URL:        "/transaction/v1/transaction/product?utc0_millisecond_start_timestamp=1640000000000&utc0_millisecond_end_timestamp=1650000000000",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return reportClient.GetTransactionTotal(ctx, &pb.GetTransactionTotalRequest{Range: timeRange})
+			},
+		},
+		{
+			Label:      "List mask transactions",
+			FullMethod: "/phantom_mask.v1.ReportService/ListMaskTransactions",
+			Method:     http.MethodGet,
+			URL:        "/transaction/v1/transaction/mask?utc0_millisecond_start_timestamp=1640000000000&utc0_millisecond_end_timestamp=1650000000000",
+			Call: func(ctx context.Context) (proto.Message, error) {
+				return reportClient.ListMaskTransactions(ctx, &pb.ListMaskTransactionsRequest{Range: timeRange})
+			},
+		},
+	}
+}
+
+// parityCase is a REST route and its gRPC counterpart, FullMethod.
+type parityCase struct {
+	Label      string
+	Method     string
+	URL        string
+	Body       string
+	FullMethod string
+	// Text REST responses are not compared with the gRPC one
+	Text bool
+	Call func(ctx context.Context) (proto.Message, error)
+}
+
+// TestParity calls every REST route and its gRPC counterpart with the same arguments, both must query the storage
+// the same way and return the same data.
+func (suite *ParitySuite) TestParity() {
+	testCases := suite.parityCases()
+	authorization := suite.bearer(testUserID)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", authorization)
+	for _, tc := range testCases {
+		suite.recorder.calls = nil
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.Method, tc.URL, bytes.NewBufferString(tc.Body))
+		req.Header.Set("Authorization", authorization)
+		suite.route.ServeHTTP(w, req)
+		suite.Equal(http.StatusOK, w.Code, tc.Label)
+		restCalls := suite.recorder.calls
+
+		suite.recorder.calls = nil
+		resp, err := tc.Call(ctx)
+		suite.NoError(err, tc.Label)
+		suite.Equal(restCalls, suite.recorder.calls, tc.Label)
+		suite.Len(restCalls, 1, tc.Label)
+		if tc.Text {
+			continue
+		}
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp)
+		suite.NoError(err, tc.Label)
+		suite.Equal(normalize(w.Body.Bytes()), normalize(data), tc.Label)
+	}
+}
+
+func (suite *ParitySuite) TestInvalidArguments() {
+	_, err := pb.NewPharmacyServiceClient(suite.conn).ListProducts(context.Background(), &pb.ListProductsRequest{PharmacyId: "not-an-id"})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = pb.NewSearchServiceClient(suite.conn).Search(context.Background(), &pb.SearchRequest{List: &pb.ListParams{Sort: "brand"}})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", suite.bearer(testUserID))
+	_, err = pb.NewTransactionServiceClient(suite.conn).Purchase(ctx, &pb.PurchaseRequest{UserId: testUserID})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ParitySuite) TestSearchOrder() {
+	testCases := []struct {
+		Sorted string
+		Order  storage.OrderListEnum
+	}{
+		{Sorted: "", Order: storage.PharmacyProduct},
+		{Sorted: "name", Order: storage.PharmacyProduct},
+		{Sorted: "relevance", Order: storage.Relevance},
+	}
+	for _, tc := range testCases {
+		suite.recorder.calls = nil
+		w := httptest.NewRecorder()
+		url := "/pharmacy/v1/mix?name=smile"
+		if tc.Sorted != "" {
+			url += "&sorted=" + tc.Sorted
+		}
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		suite.route.ServeHTTP(w, req)
+		suite.Equal(http.StatusOK, w.Code, tc.Sorted)
+		_, err := pb.NewSearchServiceClient(suite.conn).Search(context.Background(), &pb.SearchRequest{Name: "smile", Sorted: tc.Sorted})
+		suite.NoError(err, tc.Sorted)
+		suite.Require().Len(suite.recorder.calls, 2, tc.Sorted)
+		suite.Equal(tc.Order, suite.recorder.calls[0][4], tc.Sorted)
+		suite.Equal(tc.Order, suite.recorder.calls[1][4], tc.Sorted)
+	}
+}
+
+func (suite *ParitySuite) TestCompareDefaultsToNow() {
+	defer func(rest, rpc func() int64) {
+		handler.GetNowTimestamp, GetNowTimestamp = rest, rpc
+	}(handler.GetNowTimestamp, GetNowTimestamp)
+	now := func() int64 { return 1650000000000 }
+	handler.GetNowTimestamp, GetNowTimestamp = now, now
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/pharmacy/v1/product/compare?name=kiss", nil)
+	suite.route.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+	_, err := pb.NewPharmacyServiceClient(suite.conn).CompareProductPrice(context.Background(), &pb.CompareProductPriceRequest{Name: "kiss"})
+	suite.NoError(err)
+	suite.Require().Len(suite.recorder.calls, 2)
+	suite.Equal(int64(1650000000000), suite.recorder.calls[0][4])
+	suite.Equal(suite.recorder.calls[0], suite.recorder.calls[1])
+}
+
+func (suite *ParitySuite) TestPurchaseAuthorization() {
+	client := pb.NewTransactionServiceClient(suite.conn)
+	req := &pb.PurchaseRequest{UserId: testUserID, PharmacyId: testPharmacyID, ProductId: testProductID, Quantity: 2}
+	_, err := client.Purchase(context.Background(), req)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = client.Purchase(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer forged"), req)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = client.Purchase(metadata.AppendToOutgoingContext(context.Background(), "authorization", suite.bearer(testPharmacyID)), req)
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+	admin, err := suite.issuer.Issue(auth.NewClaims(auth.RoleAdmin, "ops", ""))
+	suite.Require().NoError(err)
+	_, err = client.Purchase(metadata.AppendToOutgoingContext(context.Background(), "authorization", auth.TokenType+" "+admin.AccessToken),
+		&pb.PurchaseRequest{PharmacyId: testPharmacyID, ProductId: testProductID, Quantity: 2})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	suite.Empty(suite.recorder.calls)
+}
+
+// restRoles returns the roles of the documented REST route of method matching the path of url.
+func (suite *ParitySuite) restRoles(method, url string) []string {
+	path := strings.Split(strings.SplitN(url, "?", 2)[0], "/")
+	for _, route := range suite.docs {
+		segments := strings.Split(route.Path, "/")
+		if route.Method != method || len(segments) != len(path) {
+			continue
+		}
+		match := true
+		for i, segment := range segments {
+			if !strings.HasPrefix(segment, ":") && segment != path[i] {
+				match = false
+			}
+		}
+		if match {
+			return route.Roles
+		}
+	}
+	suite.Failf("undocumented route", "%s %s", method, url)
+	return nil
+}
+
+// TestAccess keeps the gRPC methods guarded as their REST routes: every method served must be listed in Access with
+// the roles of its REST counterpart, and the guarded ones must refuse a call without a token on every transport.
+func (suite *ParitySuite) TestAccess() {
+	served := map[string]bool{}
+	for _, service := range []grpc.ServiceDesc{pb.PharmacyService_ServiceDesc, pb.SearchService_ServiceDesc, pb.TransactionService_ServiceDesc, pb.ReportService_ServiceDesc} {
+		for _, method := range service.Methods {
+			served["/"+service.ServiceName+"/"+method.MethodName] = true
+		}
+	}
+	listed := map[string]bool{}
+	for fullMethod := range Access {
+		listed[fullMethod] = true
+	}
+	suite.Equal(served, listed)
+
+	for _, tc := range suite.parityCases() {
+		suite.True(served[tc.FullMethod], tc.Label)
+		served[tc.FullMethod] = false
+		roles := make([]string, 0, len(Access[tc.FullMethod]))
+		for _, role := range Access[tc.FullMethod] {
+			roles = append(roles, string(role))
+		}
+		suite.ElementsMatch(suite.restRoles(tc.Method, tc.URL), roles, tc.Label)
+		if len(roles) == 0 {
+			continue
+		}
+
+		suite.recorder.calls = nil
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.Method, tc.URL, bytes.NewBufferString(tc.Body))
+		suite.route.ServeHTTP(w, req)
+		suite.Equal(http.StatusUnauthorized, w.Code, tc.Label)
+		_, err := tc.Call(context.Background())
+		suite.Equal(codes.Unauthenticated, status.Code(err), tc.Label)
+		suite.Empty(suite.recorder.calls, tc.Label)
+	}
+	for fullMethod, untested := range served {
+		suite.False(untested, "%s has no REST counterpart in the parity cases", fullMethod)
+	}
+
+	// GraphQL serves the users and purchase histories, a token is required there too
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, handler.GraphQLPath, strings.NewReader(`{"query":"{ masks { count } }"}`))
+	suite.route.ServeHTTP(w, req)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (suite *ParitySuite) TestGuardRefusesUnlistedMethods() {
+	_, err := NewGuard(suite.issuer).Authenticate(context.Background(), func() (string, error) {
+		return "", nil
+	}, "/phantom_mask.v1.ReportService/ListUsers")
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+	suite.Contains(err.Error(), ErrMethodNotListed.Error())
+}
+
+func TestParitySuite(t *testing.T) {
+	suite.Run(t, new(ParitySuite))
+}
@@ -0,0 +1,235 @@
+package rpc
+
+import (
+	"context"
+	"github.com/justdomepaul/toolbox/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+	"phantom_mask/internal/pb"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	internalUtils "phantom_mask/internal/utils"
+)
+
+func NewPharmacy(
+	logger *zap.Logger,
+	db spannerDB.Set,
+) (*Pharmacy, error) {
+	return &Pharmacy{
+		logger: logger,
+		db:     db,
+	}, nil
+}
+
+// Pharmacy serves the PharmacyService, the gRPC counterpart of the /pharmacy/v1 routes.
+type Pharmacy struct {
+	pb.UnimplementedPharmacyServiceServer
+	logger *zap.Logger
+	db     spannerDB.Set
+}
+
+func (s *Pharmacy) ListPharmacies(ctx context.Context, req *pb.ListPharmaciesRequest) (*pb.ListPharmaciesResponse, error) {
+	row, page, cursor := listArgs(req.GetList())
+	condition, err := pharmacyQuery(req.GetList(), storage.PharmacyListCondition{}, storage.PharmacySortFields, storage.PharmacyFilterFields)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result, err := s.db.Pharmacy.ListSpecifyTime(ctx, row, page, req.GetSpecifyUtc0MillisecondTimestamp(), storage.PharmacyNameASC, condition, cursor)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListPharmaciesResponse{
+		Count:         result.Count,
+		Row:           result.Row,
+		Page:          result.Page,
+		NextPageToken: result.NextPageToken,
+	}
+	for _, item := range result.Pharmacies {
+		resp.Pharmacies = append(resp.Pharmacies, &pb.Pharmacy{
+			Uid:         utils.FromUUID(item.UID),
+			Name:        item.Name,
+			CashBalance: item.CashBalance,
+			CreatedTime: toTimestamp(item.CreatedTime),
+			Day:         item.Day,
+			OpenHour:    item.OpenHour,
+			CloseHour:   item.CloseHour,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Pharmacy) ListMasks(ctx context.Context, req *pb.ListMasksRequest) (*pb.ListMasksResponse, error) {
+	row, page := pageArgs(req.GetRow(), req.GetPage())
+	result, err := s.db.Mask.List(ctx, row, page, storage.ProductNameASC)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListMasksResponse{
+		Count: result.Count,
+		Row:   result.Row,
+		Page:  result.Page,
+	}
+	for _, item := range result.Masks {
+		resp.Masks = append(resp.Masks, &pb.Mask{
+			MaskId:      utils.FromUUID(item.MaskID),
+			Name:        item.Name,
+			Brand:       item.Brand,
+			Color:       item.Color,
+			PackSize:    item.PackSize,
+			CreatedTime: toTimestamp(item.CreatedTime),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Pharmacy) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	pharmacyID, err := parseUUID("pharmacy_id", req.GetPharmacyId())
+	if err != nil {
+		return nil, err
+	}
+	row, page, cursor := listArgs(req.GetList())
+	var order storage.OrderListEnum
+	switch req.GetSorted() {
+	case "", "name":
+		order = storage.ProductNameASC
+	case "price":
+		order = storage.ProductPriceASC
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported sorted: %s", req.GetSorted())
+	}
+
+	condition := storage.ProductListCondition{}
+	condition = storage.WithProductSpecifyPharmacy(condition, pharmacyID)
+	if req.GetBrand() != "" {
+		condition = storage.WithProductBrand(condition, req.GetBrand())
+	}
+	if req.GetColor() != "" {
+		condition = storage.WithProductColor(condition, req.GetColor())
+	}
+	if req.GetPackSize() != 0 {
+		condition = storage.WithProductPackSize(condition, req.GetPackSize())
+	}
+	condition, err = productQuery(req.GetList(), condition, storage.ProductSortFields, storage.ProductFilterFields)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result, err := s.db.Product.List(ctx, row, page, order, condition, cursor)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListProductsResponse{
+		Count:         result.Count,
+		Row:           result.Row,
+		Page:          result.Page,
+		NextPageToken: result.NextPageToken,
+	}
+	for _, item := range result.Products {
+		product := &pb.Product{
+			Uid:          utils.FromUUID(item.UID),
+			ProductId:    utils.FromUUID(item.ProductID),
+			Name:         item.Name,
+			Price:        item.Price,
+			Brand:        item.Brand,
+			Color:        item.Color,
+			PackSize:     item.PackSize,
+			CreatedTime:  toTimestamp(item.CreatedTime),
+			PricePerMask: internalUtils.PricePerMask(item.Price, item.PackSize),
+		}
+		if len(item.MaskID) != 0 {
+			product.MaskId = utils.FromUUID(item.MaskID)
+		}
+		resp.Products = append(resp.Products, product)
+	}
+	return resp, nil
+}
+
+func (s *Pharmacy) ListPharmaciesByProductPrice(ctx context.Context, req *pb.ListPharmaciesByProductPriceRequest) (*pb.ListPharmaciesByProductPriceResponse, error) {
+	row, page, cursor := listArgs(req.GetList())
+	max := int64(math.MaxInt64)
+	if req.GetMax() != nil {
+		max = req.GetMax().GetValue()
+	}
+
+	condition := storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductPriceRange(condition, req.GetMin(), max)
+	if req.GetCount() != nil {
+		count := req.GetCount().GetValue()
+		switch req.GetCountOperator() {
+		case "", "gt":
+			condition = storage.WithPharmacyProductCount(condition, storage.CountGreaterThan, count)
+		case "lt":
+			condition = storage.WithPharmacyProductCount(condition, storage.CountLessThan, count)
+		case "eq":
+			condition = storage.WithPharmacyProductCount(condition, storage.CountEqual, count)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported count_operator: %s", req.GetCountOperator())
+		}
+	}
+	condition, err := pharmacyQuery(req.GetList(), condition, storage.ProductCountSortFields, storage.ProductCountFilterFields)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result, err := s.db.Pharmacy.ListByProductPriceRange(ctx, row, page, storage.PharmacyNameASC, condition, cursor)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListPharmaciesByProductPriceResponse{
+		Count:         result.Count,
+		Row:           result.Row,
+		Page:          result.Page,
+		NextPageToken: result.NextPageToken,
+	}
+	for _, item := range result.Pharmacies {
+		resp.Pharmacies = append(resp.Pharmacies, &pb.PharmacyProductCount{
+			Uid:          utils.FromUUID(item.UID),
+			Name:         item.Name,
+			CashBalance:  item.CashBalance,
+			CreatedTime:  toTimestamp(item.CreatedTime),
+			ProductCount: item.ProductCount,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Pharmacy) CompareProductPrice(ctx context.Context, req *pb.CompareProductPriceRequest) (*pb.CompareProductPriceResponse, error) {
+	row, page := pageArgs(req.GetRow(), req.GetPage())
+	specifyTimestamp := GetNowTimestamp()
+	if req.GetSpecifyUtc0MillisecondTimestamp() != nil {
+		specifyTimestamp = req.GetSpecifyUtc0MillisecondTimestamp().GetValue()
+	}
+
+	condition := storage.PharmacyListCondition{}
+	if req.GetOpenOnly() {
+		condition = storage.WithPharmacyOpenAt(condition, specifyTimestamp)
+	}
+	result, err := s.db.Pharmacy.ListProductPriceComparison(ctx, row, page, req.GetName(), specifyTimestamp, condition)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.CompareProductPriceResponse{
+		Count: result.Count,
+		Row:   result.Row,
+		Page:  result.Page,
+	}
+	for _, item := range result.Products {
+		product := &pb.ProductPriceComparison{
+			Name: item.Name,
+		}
+		for _, offer := range item.Offers {
+			product.Offers = append(product.Offers, &pb.PharmacyPriceOffer{
+				Uid:          utils.FromUUID(offer.UID),
+				PharmacyName: offer.PharmacyName,
+				ProductId:    utils.FromUUID(offer.ProductID),
+				ProductName:  offer.ProductName,
+				Price:        offer.Price,
+				PackSize:     offer.PackSize,
+				PricePerMask: internalUtils.PricePerMask(offer.Price, offer.PackSize),
+				IsOpen:       offer.IsOpen,
+			})
+		}
+		resp.Products = append(resp.Products, product)
+	}
+	return resp, nil
+}
@@ -0,0 +1,214 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/timestamp"
+	"github.com/justdomepaul/toolbox/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/http"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/pb"
+	"phantom_mask/internal/storage"
+	"strings"
+	"time"
+)
+
+var (
+	GetNowTimestamp = timestamp.GetNowTimestamp
+)
+
+type Set struct {
+	Pharmacy    *Pharmacy
+	Search      *Search
+	Transaction *Transaction
+	Report      *Report
+}
+
+// Register serves every service of services on server.
+func Register(server *grpc.Server, services Set) {
+	pb.RegisterPharmacyServiceServer(server, services.Pharmacy)
+	pb.RegisterSearchServiceServer(server, services.Search)
+	pb.RegisterTransactionServiceServer(server, services.Transaction)
+	pb.RegisterReportServiceServer(server, services.Report)
+}
+
+// Access is the roles of the bearer token each method requires, the roles its REST counterpart is guarded with, a
+// method of no roles is public. A method missing from Access is refused, so a new method stays closed until listed.
+var Access = map[string][]auth.Role{
+	"/phantom_mask.v1.PharmacyService/ListPharmacies":               nil,
+	"/phantom_mask.v1.PharmacyService/ListMasks":                    nil,
+	"/phantom_mask.v1.PharmacyService/ListProducts":                 nil,
+	"/phantom_mask.v1.PharmacyService/ListPharmaciesByProductPrice": nil,
+	"/phantom_mask.v1.PharmacyService/CompareProductPrice":          nil,
+	"/phantom_mask.v1.SearchService/Search":                         nil,
+	"/phantom_mask.v1.TransactionService/Purchase":                  {auth.RoleCustomer, auth.RoleAdmin},
+	"/phantom_mask.v1.ReportService/ListTopUsers":                   nil,
+	"/phantom_mask.v1.ReportService/GetTransactionTotal":            nil,
+	"/phantom_mask.v1.ReportService/ListMaskTransactions":           nil,
+}
+
+var ErrMethodNotListed = fmt.Errorf("%w: method not listed in the access of the services", errorhandler.ErrNoPermission)
+
+func NewGuard(issuer *auth.Issuer) *Guard {
+	return &Guard{issuer: issuer}
+}
+
+// Guard authenticates every call by Access before it reaches the services, as the REST guard does for the routes.
+type Guard struct {
+	issuer *auth.Issuer
+}
+
+func (g *Guard) Authenticate(ctx context.Context, tokenFn func() (string, error), fullMethod string) ([]byte, error) {
+	roles, ok := Access[fullMethod]
+	if !ok {
+		return nil, statusError(fmt.Errorf("%w: %s", ErrMethodNotListed, fullMethod))
+	}
+	if len(roles) == 0 {
+		return nil, errorhandler.ErrInWhitelist
+	}
+	claims, err := allow(g.issuer, tokenFn, roles...)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(claims.Subject), nil
+}
+
+// statusError converts err to the status matching the problem the REST handlers answer.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, errorhandler.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errorhandler.ErrNoPermission):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errorhandler.ErrInvalidArguments):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errorhandler.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrInsufficientBalance):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// authorize returns the claims of the bearer token of ctx when its role is one of roles, as the REST guard does.
+func authorize(ctx context.Context, issuer *auth.Issuer, roles ...auth.Role) (*auth.Claims, error) {
+	return allow(issuer, func() (string, error) {
+		return utils.GetAccessToken(ctx)
+	}, roles...)
+}
+
+// allow returns the claims of the token of tokenFn when its role is one of roles.
+func allow(issuer *auth.Issuer, tokenFn func() (string, error), roles ...auth.Role) (*auth.Claims, error) {
+	token, err := tokenFn()
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	claims, err := issuer.Parse(token)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := claims.Allow(roles...); err != nil {
+		return nil, statusError(err)
+	}
+	return claims, nil
+}
+
+// listArgs reads the row, page and cursor of params with the defaults of the REST query.
+func listArgs(params *pb.ListParams) (uint64, uint64, storage.Cursor) {
+	row, page := pageArgs(params.GetRow(), params.GetPage())
+	return row, page, storage.Cursor{
+		Token:        params.GetPageToken(),
+		WithoutCount: params.GetWithoutCount(),
+	}
+}
+
+func pageArgs(row, page uint64) (uint64, uint64) {
+	if row == 0 {
+		row = 10
+	}
+	if page == 0 {
+		page = 1
+	}
+	return row, page
+}
+
+// timeRange reads the start and end of r, the end being now when unset.
+func timeRange(r *pb.TimeRange) (int64, int64) {
+	if r.GetUtc0MillisecondEndTimestamp() == nil {
+		return r.GetUtc0MillisecondStartTimestamp(), GetNowTimestamp()
+	}
+	return r.GetUtc0MillisecondStartTimestamp(), r.GetUtc0MillisecondEndTimestamp().GetValue()
+}
+
+// pharmacyQuery adds the sort and filter of params, restricted to the whitelisted sortFields and filterFields, to condition.
+func pharmacyQuery(params *pb.ListParams, condition storage.PharmacyListCondition, sortFields, filterFields map[string]storage.ListField) (storage.PharmacyListCondition, error) {
+	if query := params.GetSort(); query != "" {
+		sorts, err := storage.ParseSort(query, sortFields)
+		if err != nil {
+			return condition, err
+		}
+		condition = storage.WithPharmacySort(condition, sorts...)
+	}
+	if query := params.GetFilter(); query != "" {
+		filters, err := storage.ParseFilter(query, filterFields)
+		if err != nil {
+			return condition, err
+		}
+		condition = storage.WithPharmacyFilter(condition, filters...)
+	}
+	return condition, nil
+}
+
+// productQuery adds the sort and filter of params, restricted to the whitelisted sortFields and filterFields, to condition.
+func productQuery(params *pb.ListParams, condition storage.ProductListCondition, sortFields, filterFields map[string]storage.ListField) (storage.ProductListCondition, error) {
+	if query := params.GetSort(); query != "" {
+		sorts, err := storage.ParseSort(query, sortFields)
+		if err != nil {
+			return condition, err
+		}
+		condition = storage.WithProductSort(condition, sorts...)
+	}
+	if query := params.GetFilter(); query != "" {
+		filters, err := storage.ParseFilter(query, filterFields)
+		if err != nil {
+			return condition, err
+		}
+		condition = storage.WithProductFilter(condition, filters...)
+	}
+	return condition, nil
+}
+
+// toTimestamp converts t, leaving the zero time unset.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// Multiplex hands the HTTP/2 gRPC calls to server and every other request to fallback, so gRPC shares the port of REST.
+func Multiplex(server *grpc.Server, fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			server.ServeHTTP(w, r)
+			return
+		}
+		fallback.ServeHTTP(w, r)
+	})
+}
+
+// parseUUID parses the id field called name, unlike utils.ParseUUID it reports a malformed id instead of panicking.
+func parseUUID(name, id string) ([]byte, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", name, err)
+	}
+	return uid[:], nil
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/pb"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	"strings"
+	"testing"
+)
+
+type MultiplexSuite struct {
+	suite.Suite
+}
+
+func (suite *MultiplexSuite) TestMultiplex() {
+	server := grpc.NewServer()
+	defer server.Stop()
+	pharmacy, _ := NewPharmacy(nil, spannerDB.Set{Mask: fakeMask{recorder: &recorder{}}})
+	pb.RegisterPharmacyServiceServer(server, pharmacy)
+	fallback := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("rest"))
+	})
+	httpServer := httptest.NewServer(h2c.NewHandler(Multiplex(server, fallback), &http2.Server{}))
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + "/ping")
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	conn, err := grpc.Dial(strings.TrimPrefix(httpServer.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	suite.Require().NoError(err)
+	defer conn.Close()
+	result, err := pb.NewPharmacyServiceClient(conn).ListMasks(context.Background(), &pb.ListMasksRequest{})
+	suite.NoError(err)
+	suite.Len(result.GetMasks(), 1)
+	suite.Equal(int64(10), result.GetRow())
+}
+
+func (suite *MultiplexSuite) TestStatusError() {
+	suite.Equal(codes.NotFound, status.Code(statusError(storage.ErrProductNotFound)))
+	suite.Equal(codes.FailedPrecondition, status.Code(statusError(storage.ErrInsufficientBalance)))
+	suite.Equal(codes.InvalidArgument, status.Code(statusError(errorhandler.ErrInvalidArguments)))
+	suite.Equal(codes.Unauthenticated, status.Code(statusError(auth.ErrInvalidToken)))
+	suite.Equal(codes.PermissionDenied, status.Code(statusError(auth.ErrNotOwner)))
+	suite.Equal(codes.Internal, status.Code(statusError(errors.New("spanner unavailable"))))
+}
+
+func TestMultiplexSuite(t *testing.T) {
+	suite.Run(t, new(MultiplexSuite))
+}
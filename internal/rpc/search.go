@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"context"
+	"github.com/justdomepaul/toolbox/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"phantom_mask/internal/pb"
+	"phantom_mask/internal/storage"
+	spannerDB "phantom_mask/internal/storage/spanner"
+	internalUtils "phantom_mask/internal/utils"
+)
+
+func NewSearch(
+	logger *zap.Logger,
+	db spannerDB.Set,
+) (*Search, error) {
+	return &Search{
+		logger: logger,
+		db:     db,
+	}, nil
+}
+
+// Search serves the SearchService, the gRPC counterpart of /pharmacy/v1/mix.
+type Search struct {
+	pb.UnimplementedSearchServiceServer
+	logger *zap.Logger
+	db     spannerDB.Set
+}
+
+func (s *Search) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	row, page, cursor := listArgs(req.GetList())
+	var order storage.OrderListEnum
+	switch req.GetSorted() {
+	case "", "name":
+		order = storage.PharmacyProduct
+	case "relevance":
+		order = storage.Relevance
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported sorted: %s", req.GetSorted())
+	}
+
+	condition := storage.PharmacyListCondition{}
+	if req.GetBrand() != "" {
+		condition = storage.WithPharmacyProductBrand(condition, req.GetBrand())
+	}
+	if req.GetColor() != "" {
+		condition = storage.WithPharmacyProductColor(condition, req.GetColor())
+	}
+	if req.GetPackSize() != 0 {
+		condition = storage.WithPharmacyProductPackSize(condition, req.GetPackSize())
+	}
+	condition, err := pharmacyQuery(req.GetList(), condition, storage.MixSortFields, storage.MixFilterFields)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	result, err := s.db.Pharmacy.ListPharmacyMixProduct(ctx, row, page, req.GetName(), order, condition, cursor)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.SearchResponse{
+		Count:         result.Count,
+		Row:           result.Row,
+		Page:          result.Page,
+		NextPageToken: result.NextPageToken,
+	}
+	for _, item := range result.PharmacyProducts {
+		resp.PharmacyProducts = append(resp.PharmacyProducts, &pb.PharmacyProduct{
+			Uid:          utils.FromUUID(item.UID),
+			ProductId:    utils.FromUUID(item.ProductID),
+			PharmacyName: item.PharmacyName,
+			CashBalance:  item.CashBalance,
+			ProductName:  item.ProductName,
+			Price:        item.Price,
+			Brand:        item.Brand,
+			Color:        item.Color,
+			PackSize:     item.PackSize,
+			Score:        item.Score,
+			PricePerMask: internalUtils.PricePerMask(item.Price, item.PackSize),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/justdomepaul/toolbox/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"phantom_mask/internal/auth"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/pb"
+	spannerDB "phantom_mask/internal/storage/spanner"
+)
+
+func NewTransaction(
+	logger *zap.Logger,
+	db spannerDB.Set,
+	issuer *auth.Issuer,
+) (*Transaction, error) {
+	return &Transaction{
+		logger: logger,
+		db:     db,
+		issuer: issuer,
+	}, nil
+}
+
+// Transaction serves the TransactionService, the gRPC counterpart of /transaction/v1/purchase.
+type Transaction struct {
+	pb.UnimplementedTransactionServiceServer
+	logger *zap.Logger
+	db     spannerDB.Set
+	issuer *auth.Issuer
+}
+
+// Purchase requires the bearer token of the customer purchasing or of an admin, as the REST route does, the buyer being
+// the customer of the token or the user_id an admin names.
+func (s *Transaction) Purchase(ctx context.Context, req *pb.PurchaseRequest) (*pb.PurchaseResponse, error) {
+	claims, err := authorize(ctx, s.issuer, auth.RoleCustomer, auth.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	input := entity.PurchaseJSON{
+		UserID:     req.GetUserId(),
+		PharmacyID: req.GetPharmacyId(),
+		ProductID:  req.GetProductId(),
+		Quantity:   int(req.GetQuantity()),
+	}
+	if err := validator.New().Struct(&input); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if input.UserID, err = claims.Buyer(input.UserID); err != nil {
+		return nil, statusError(err)
+	}
+
+	userID, err := parseUUID("user_id", input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	pharmacyID, err := parseUUID("pharmacy_id", input.PharmacyID)
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseUUID("product_id", input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Product.Purchase(ctx, userID, pharmacyID, productID, input.Quantity); err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.PurchaseResponse{}, nil
+}
+
+func NewReport(
+	logger *zap.Logger,
+	db spannerDB.Set,
+) (*Report, error) {
+	return &Report{
+		logger: logger,
+		db:     db,
+	}, nil
+}
+
+// Report serves the ReportService, the gRPC counterpart of the /transaction/v1/transaction routes.
+type Report struct {
+	pb.UnimplementedReportServiceServer
+	logger *zap.Logger
+	db     spannerDB.Set
+}
+
+func (s *Report) ListTopUsers(ctx context.Context, req *pb.ListTopUsersRequest) (*pb.ListTopUsersResponse, error) {
+	topNumber := req.GetTopNumber()
+	if topNumber == 0 {
+		topNumber = 10
+	}
+	startTime, endTime := timeRange(req.GetRange())
+	result, err := s.db.User.ListTopTransactionAmount(ctx, topNumber, startTime, endTime)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListTopUsersResponse{}
+	for _, item := range result.TopTransactionAmountUsers {
+		resp.TopTransactionAmountUsers = append(resp.TopTransactionAmountUsers, &pb.TopTransactionAmountUser{
+			Uid:               utils.FromUUID(item.UID),
+			Name:              item.Name,
+			TransactionAmount: item.TransactionAmount,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Report) GetTransactionTotal(ctx context.Context, req *pb.GetTransactionTotalRequest) (*pb.GetTransactionTotalResponse, error) {
+	startTime, endTime := timeRange(req.GetRange())
+	result, err := s.db.User.GetTransactionTotal(ctx, startTime, endTime)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.GetTransactionTotalResponse{
+		Total:             result.Total,
+		TransactionAmount: result.TransactionAmount,
+	}, nil
+}
+
+func (s *Report) ListMaskTransactions(ctx context.Context, req *pb.ListMaskTransactionsRequest) (*pb.ListMaskTransactionsResponse, error) {
+	startTime, endTime := timeRange(req.GetRange())
+	result, err := s.db.Mask.ListTransactionAmount(ctx, startTime, endTime)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &pb.ListMaskTransactionsResponse{}
+	for _, item := range result.MaskTransactions {
+		resp.MaskTransactions = append(resp.MaskTransactions, &pb.MaskTransaction{
+			MaskId:            utils.FromUUID(item.MaskID),
+			Name:              item.Name,
+			Total:             item.Total,
+			TransactionAmount: item.TransactionAmount,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,392 @@
+package search
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"math"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"phantom_mask/internal/utils"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RefreshPageRow is the page size used when Refresh pages through the pharmacy products of the source.
+var RefreshPageRow uint64 = 1000
+
+// RefreshInterval is how often Watch rebuilds the index.
+var RefreshInterval = 5 * time.Minute
+
+type keySet map[string]struct{}
+
+func (s keySet) add(key string) { s[key] = struct{}{} }
+
+// NewIndex method
+func NewIndex(logger *zap.Logger) *Index {
+	index := &Index{
+		logger: logger,
+	}
+	index.reset()
+	return index
+}
+
+// Index is an in-process inverted index over pharmacy and product names.
+// Name tokens point to the pharmacies or products using them, and every token is further
+// indexed by its trigrams and length so substring and typo tolerant lookups only touch the vocabulary.
+type Index struct {
+	logger *zap.Logger
+
+	mu               sync.RWMutex
+	pharmacies       map[string]*entity.Pharmacy
+	products         map[string]*entity.Product
+	pharmacyProducts map[string]keySet
+	pharmacyTerms    map[string]keySet
+	productTerms     map[string]keySet
+	trigrams         map[string]keySet
+	lengths          map[int]keySet
+}
+
+func (idx *Index) reset() {
+	idx.pharmacies = map[string]*entity.Pharmacy{}
+	idx.products = map[string]*entity.Product{}
+	idx.pharmacyProducts = map[string]keySet{}
+	idx.pharmacyTerms = map[string]keySet{}
+	idx.productTerms = map[string]keySet{}
+	idx.trigrams = map[string]keySet{}
+	idx.lengths = map[int]keySet{}
+}
+
+func productKey(pharmacyID, productID []byte) string {
+	return string(pharmacyID) + string(productID)
+}
+
+// Refresh rebuilds the index from every pharmacy product the source returns.
+func (idx *Index) Refresh(ctx context.Context, source storage.IPharmacy) error {
+	var items []*entity.PharmacyProduct
+	for page := uint64(1); ; page++ {
+		result, err := source.ListPharmacyMixProduct(ctx, RefreshPageRow, page, "", storage.PharmacyProduct, storage.PharmacyListCondition{}, storage.Cursor{})
+		if err != nil {
+			return err
+		}
+		items = append(items, result.PharmacyProducts...)
+		if len(result.PharmacyProducts) == 0 || uint64(len(items)) >= uint64(result.Count) {
+			break
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.reset()
+	for _, item := range items {
+		idx.putPharmacy(&entity.Pharmacy{
+			UID:         item.UID,
+			Name:        item.PharmacyName,
+			CashBalance: item.CashBalance,
+		})
+		idx.putProduct(&entity.Product{
+			UID:       item.UID,
+			ProductID: item.ProductID,
+			Name:      item.ProductName,
+			Price:     item.Price,
+			Brand:     item.Brand,
+			Color:     item.Color,
+			PackSize:  item.PackSize,
+		})
+	}
+	idx.logger.Info("search index refreshed",
+		zap.Int("pharmacies", len(idx.pharmacies)),
+		zap.Int("products", len(idx.products)),
+	)
+	return nil
+}
+
+// Watch calls Refresh every RefreshInterval until ctx is done, so the rows written outside this process,
+// such as by the importer, become searchable without a restart. A failed refresh keeps the current index.
+func (idx *Index) Watch(ctx context.Context, source storage.IPharmacy) {
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := idx.Refresh(ctx, source); err != nil {
+				idx.logger.Warn("search index refresh failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// PutPharmacy adds or replaces a pharmacy.
+func (idx *Index) PutPharmacy(input entity.Pharmacy) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.putPharmacy(&input)
+}
+
+// PutProduct adds or replaces a product, it is only searchable once its pharmacy is known.
+func (idx *Index) PutProduct(input entity.Product) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.putProduct(&input)
+}
+
+// ApplyPurchase credits the pharmacy with the indexed product price times quantity, mirroring IProduct.Purchase.
+func (idx *Index) ApplyPurchase(pharmacyID, productID []byte, quantity int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	pharmacy, ok := idx.pharmacies[string(pharmacyID)]
+	if !ok {
+		return
+	}
+	product, ok := idx.products[productKey(pharmacyID, productID)]
+	if !ok {
+		return
+	}
+	pharmacy.CashBalance += product.Price * float64(quantity)
+}
+
+func (idx *Index) putPharmacy(input *entity.Pharmacy) {
+	key := string(input.UID)
+	if old, ok := idx.pharmacies[key]; ok {
+		idx.removeTerms(idx.pharmacyTerms, old.Name, key)
+	}
+	idx.pharmacies[key] = input
+	idx.addTerms(idx.pharmacyTerms, input.Name, key)
+}
+
+func (idx *Index) putProduct(input *entity.Product) {
+	*input = storage.WithMaskAttribute(*input)
+	key := productKey(input.UID, input.ProductID)
+	if old, ok := idx.products[key]; ok {
+		idx.removeTerms(idx.productTerms, old.Name, key)
+	}
+	idx.products[key] = input
+	idx.addTerms(idx.productTerms, input.Name, key)
+	if _, ok := idx.pharmacyProducts[string(input.UID)]; !ok {
+		idx.pharmacyProducts[string(input.UID)] = keySet{}
+	}
+	idx.pharmacyProducts[string(input.UID)].add(key)
+}
+
+func (idx *Index) addTerms(terms map[string]keySet, name, key string) {
+	for _, token := range utils.SplitWords(name) {
+		if _, ok := terms[token]; !ok {
+			terms[token] = keySet{}
+		}
+		terms[token].add(key)
+		idx.addVocabulary(token)
+	}
+}
+
+func (idx *Index) removeTerms(terms map[string]keySet, name, key string) {
+	for _, token := range utils.SplitWords(name) {
+		delete(terms[token], key)
+		if len(terms[token]) == 0 {
+			delete(terms, token)
+		}
+		if len(idx.pharmacyTerms[token]) == 0 && len(idx.productTerms[token]) == 0 {
+			idx.removeVocabulary(token)
+		}
+	}
+}
+
+func (idx *Index) addVocabulary(token string) {
+	for _, gram := range trigrams(token) {
+		if _, ok := idx.trigrams[gram]; !ok {
+			idx.trigrams[gram] = keySet{}
+		}
+		idx.trigrams[gram].add(token)
+	}
+	n := len([]rune(token))
+	if _, ok := idx.lengths[n]; !ok {
+		idx.lengths[n] = keySet{}
+	}
+	idx.lengths[n].add(token)
+}
+
+func (idx *Index) removeVocabulary(token string) {
+	for _, gram := range trigrams(token) {
+		delete(idx.trigrams[gram], token)
+		if len(idx.trigrams[gram]) == 0 {
+			delete(idx.trigrams, gram)
+		}
+	}
+	n := len([]rune(token))
+	delete(idx.lengths[n], token)
+	if len(idx.lengths[n]) == 0 {
+		delete(idx.lengths, n)
+	}
+}
+
+// trigrams returns the distinct trigrams of the word padded with two "$" on both sides.
+func trigrams(word string) []string {
+	runes := []rune("$$" + word + "$$")
+	seen := keySet{}
+	var result []string
+	for i := 0; i+3 <= len(runes); i++ {
+		gram := string(runes[i : i+3])
+		if _, ok := seen[gram]; ok {
+			continue
+		}
+		seen.add(gram)
+		result = append(result, gram)
+	}
+	return result
+}
+
+// matchTokens returns the indexed tokens containing word or within utils.MaxEditDistance of it.
+func (idx *Index) matchTokens(word string) keySet {
+	result := keySet{}
+	limit := utils.MaxEditDistance(word)
+	grams := trigrams(word)
+	n := len([]rune(word))
+
+	// Each edit removes at most four distinct trigrams, so a token within the limit shares at least
+	// len(grams)-4*limit of them, and a token containing the word shares its inner len(word)-2.
+	// Short words or loose limits fall back to scanning the vocabulary by length.
+	counts := map[string]int{}
+	for _, gram := range grams {
+		for token := range idx.trigrams[gram] {
+			counts[token]++
+		}
+	}
+	var candidates []string
+	if threshold := len(grams) - 4*limit; n >= 3 && threshold > 0 {
+		for token, count := range counts {
+			if count >= threshold {
+				candidates = append(candidates, token)
+			}
+		}
+	} else {
+		for length, tokens := range idx.lengths {
+			if length >= n || n-length <= limit {
+				for token := range tokens {
+					candidates = append(candidates, token)
+				}
+			}
+		}
+	}
+	for _, token := range candidates {
+		if strings.Contains(token, word) || utils.EditDistance(word, token) <= limit {
+			result.add(token)
+		}
+	}
+	return result
+}
+
+// filterValue reads field from the pharmacy product, nil for the fields the index does not keep.
+func filterValue(field storage.ListField, pharmacy *entity.Pharmacy, product *entity.Product) interface{} {
+	switch field {
+	case storage.FieldPharmacyName:
+		return pharmacy.Name
+	case storage.FieldProductName:
+		return product.Name
+	case storage.FieldCashBalance:
+		return pharmacy.CashBalance
+	case storage.FieldPrice:
+		return product.Price
+	case storage.FieldBrand:
+		return product.Brand
+	case storage.FieldColor:
+		return product.Color
+	case storage.FieldPackSize:
+		return product.PackSize
+	}
+	return nil
+}
+
+// matchCondition applies the product attribute filters and the filters of condition, the price range is ignored.
+func matchCondition(pharmacy *entity.Pharmacy, product *entity.Product, condition storage.PharmacyListCondition) bool {
+	for _, op := range condition.Fields {
+		switch op {
+		case storage.PharmacyProductBrand:
+			if !strings.EqualFold(product.Brand, condition.Brand) {
+				return false
+			}
+		case storage.PharmacyProductColor:
+			if !strings.EqualFold(product.Color, condition.Color) {
+				return false
+			}
+		case storage.PharmacyProductPackSize:
+			if product.PackSize != condition.PackSize {
+				return false
+			}
+		case storage.PharmacyFilter:
+			for _, filter := range condition.Filters {
+				if !storage.MatchFilter(filter, filterValue(filter.Field, pharmacy, product)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Search returns the pharmacy products matching condition whose pharmacy or product name matches query,
+// scored with utils.Relevance and sorted by descending score, then pharmacy name and product name.
+// An empty query returns every pharmacy product matching condition with a zero score.
+func (idx *Index) Search(query string, condition storage.PharmacyListCondition) []*entity.PharmacyProduct {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	candidates := keySet{}
+	words := utils.SplitWords(query)
+	if len(words) == 0 {
+		for key := range idx.products {
+			candidates.add(key)
+		}
+	}
+	for _, word := range words {
+		for token := range idx.matchTokens(word) {
+			for pharmacyKey := range idx.pharmacyTerms[token] {
+				for key := range idx.pharmacyProducts[pharmacyKey] {
+					candidates.add(key)
+				}
+			}
+			for key := range idx.productTerms[token] {
+				candidates.add(key)
+			}
+		}
+	}
+
+	var result []*entity.PharmacyProduct
+	for key := range candidates {
+		product := idx.products[key]
+		pharmacy, ok := idx.pharmacies[string(product.UID)]
+		if !ok || !matchCondition(pharmacy, product, condition) {
+			continue
+		}
+		score := math.Max(utils.Relevance(query, pharmacy.Name), utils.Relevance(query, product.Name))
+		if score == 0 && len(words) > 0 {
+			continue
+		}
+		result = append(result, &entity.PharmacyProduct{
+			UID:          product.UID,
+			ProductID:    product.ProductID,
+			PharmacyName: pharmacy.Name,
+			CashBalance:  pharmacy.CashBalance,
+			ProductName:  product.Name,
+			Price:        product.Price,
+			Brand:        product.Brand,
+			Color:        product.Color,
+			PackSize:     product.PackSize,
+			Score:        score,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].PharmacyName != result[j].PharmacyName {
+			return result[i].PharmacyName < result[j].PharmacyName
+		}
+		if result[i].ProductName != result[j].ProductName {
+			return result[i].ProductName < result[j].ProductName
+		}
+		return productKey(result[i].UID, result[i].ProductID) < productKey(result[j].UID, result[j].ProductID)
+	})
+	return result
+}
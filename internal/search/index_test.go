@@ -0,0 +1,256 @@
+package search
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"testing"
+	"time"
+)
+
+type fakePharmacy struct {
+	storage.IPharmacy
+	items []*entity.PharmacyProduct
+	calls int
+}
+
+func (f *fakePharmacy) ListPharmacyMixProduct(_ context.Context, row, page uint64, _ string, _ storage.OrderListEnum, _ storage.PharmacyListCondition, _ storage.Cursor) (*entity.PharmacyProductList, error) {
+	f.calls++
+	resp := &entity.PharmacyProductList{}
+	resp.Count = int64(len(f.items))
+	start := (page - 1) * row
+	if start > uint64(len(f.items)) {
+		start = uint64(len(f.items))
+	}
+	end := start + row
+	if end > uint64(len(f.items)) {
+		end = uint64(len(f.items))
+	}
+	resp.PharmacyProducts = f.items[start:end]
+	return resp, nil
+}
+
+type IndexSuite struct {
+	suite.Suite
+	ctx        context.Context
+	index      *Index
+	source     *fakePharmacy
+	medlife    []byte
+	keystone   []byte
+	secondID   []byte
+	barrierID  []byte
+	cottonID   []byte
+	medlifeBox []byte
+}
+
+func newID() []byte {
+	uid := uuid.New()
+	return uid[:]
+}
+
+func (suite *IndexSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.medlife = newID()
+	suite.keystone = newID()
+	suite.secondID = newID()
+	suite.barrierID = newID()
+	suite.cottonID = newID()
+	suite.medlifeBox = newID()
+	suite.source = &fakePharmacy{
+		items: []*entity.PharmacyProduct{
+			{UID: suite.keystone, ProductID: suite.secondID, PharmacyName: "Keystone Pharmacy", CashBalance: 10, ProductName: "Second Smile (black) (10 per pack)", Price: 31.98},
+			{UID: suite.keystone, ProductID: suite.barrierID, PharmacyName: "Keystone Pharmacy", CashBalance: 10, ProductName: "True Barrier (green) (3 per pack)", Price: 13.7},
+			{UID: suite.medlife, ProductID: suite.cottonID, PharmacyName: "Medlife", CashBalance: 20, ProductName: "Cotton Kiss (blue) (6 per pack)", Price: 5},
+		},
+	}
+	suite.index = NewIndex(zap.NewNop())
+	RefreshPageRow = 2
+	suite.NoError(suite.index.Refresh(suite.ctx, suite.source))
+}
+
+func (suite *IndexSuite) TestRefreshPagesThroughSource() {
+	suite.Equal(2, suite.source.calls)
+	suite.Len(suite.index.Search("", storage.PharmacyListCondition{}), 3)
+}
+
+func (suite *IndexSuite) TestWatchPicksUpNewRows() {
+	interval := RefreshInterval
+	defer func() { RefreshInterval = interval }()
+	RefreshInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+
+	imported := newID()
+	suite.source.items = append(suite.source.items, &entity.PharmacyProduct{
+		UID: imported, ProductID: newID(), PharmacyName: "Imported Pharmacy", ProductName: "Masquerade (blue) (6 per pack)", Price: 8,
+	})
+	suite.Empty(suite.index.Search("imported", storage.PharmacyListCondition{}))
+	go suite.index.Watch(ctx, suite.source)
+	suite.Eventually(func() bool {
+		return len(suite.index.Search("imported", storage.PharmacyListCondition{})) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *IndexSuite) TestSearch() {
+	testCases := []struct {
+		Label string
+		Query string
+		Want  [][]byte
+	}{
+		{
+			Label: "Typo in mask name",
+			Query: "seconds smile",
+			Want:  [][]byte{suite.secondID},
+		},
+		{
+			Label: "Transposed letters",
+			Query: "barreir",
+			Want:  [][]byte{suite.barrierID},
+		},
+		{
+			Label: "Pharmacy name returns all its products",
+			Query: "keystone",
+			Want:  [][]byte{suite.secondID, suite.barrierID},
+		},
+		{
+			Label: "Color token",
+			Query: "blue",
+			Want:  [][]byte{suite.cottonID},
+		},
+		{
+			Label: "Substring inside a word",
+			Query: "otto",
+			Want:  [][]byte{suite.cottonID},
+		},
+		{
+			Label: "Short word is not fuzzy",
+			Query: "10",
+			Want:  [][]byte{suite.secondID},
+		},
+		{
+			Label: "No match",
+			Query: "zebra",
+			Want:  nil,
+		},
+	}
+	for _, tc := range testCases {
+		var got [][]byte
+		for _, item := range suite.index.Search(tc.Query, storage.PharmacyListCondition{}) {
+			suite.Greater(item.Score, float64(0), tc.Label)
+			got = append(got, item.ProductID)
+		}
+		suite.Equal(tc.Want, got, tc.Label)
+	}
+}
+
+func (suite *IndexSuite) TestSearchRanksExactBeforeFuzzy() {
+	suite.index.PutProduct(entity.Product{
+		UID:       suite.medlife,
+		ProductID: suite.medlifeBox,
+		Name:      "Smile",
+		Price:     1,
+	})
+	result := suite.index.Search("smile", storage.PharmacyListCondition{})
+	suite.Len(result, 2)
+	suite.Equal(suite.medlifeBox, result[0].ProductID)
+	suite.Equal(suite.secondID, result[1].ProductID)
+	suite.Greater(result[0].Score, result[1].Score)
+}
+
+func (suite *IndexSuite) TestSearchWithCondition() {
+	condition := storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductColor(condition, "Green")
+	result := suite.index.Search("keystone", condition)
+	suite.Len(result, 1)
+	suite.Equal(suite.barrierID, result[0].ProductID)
+	suite.Equal("True Barrier", result[0].Brand)
+	suite.Equal(int64(3), result[0].PackSize)
+
+	condition = storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductBrand(condition, "second smile")
+	condition = storage.WithPharmacyProductPackSize(condition, 10)
+	result = suite.index.Search("", condition)
+	suite.Len(result, 1)
+	suite.Equal(suite.secondID, result[0].ProductID)
+}
+
+func (suite *IndexSuite) TestSearchWithFilter() {
+	condition := storage.WithPharmacyFilter(storage.PharmacyListCondition{},
+		storage.Filter{Field: storage.FieldPrice, Operator: storage.FilterLessThan, Value: float64(20)},
+		storage.Filter{Field: storage.FieldPharmacyName, Operator: storage.FilterContains, Value: "KEY"},
+	)
+	result := suite.index.Search("", condition)
+	suite.Len(result, 1)
+	suite.Equal(suite.barrierID, result[0].ProductID)
+
+	condition = storage.WithPharmacyFilter(storage.PharmacyListCondition{},
+		storage.Filter{Field: storage.FieldCashBalance, Operator: storage.FilterGreaterEqual, Value: float64(20)},
+	)
+	result = suite.index.Search("", condition)
+	suite.Len(result, 1)
+	suite.Equal(suite.cottonID, result[0].ProductID)
+}
+
+func (suite *IndexSuite) TestPutPharmacyRenames() {
+	suite.index.PutPharmacy(entity.Pharmacy{
+		UID:         suite.medlife,
+		Name:        "Welltrack",
+		CashBalance: 20,
+	})
+	suite.Empty(suite.index.Search("medlife", storage.PharmacyListCondition{}))
+	result := suite.index.Search("welltrack", storage.PharmacyListCondition{})
+	suite.Len(result, 1)
+	suite.Equal("Welltrack", result[0].PharmacyName)
+}
+
+func (suite *IndexSuite) TestApplyPurchase() {
+	suite.index.ApplyPurchase(suite.medlife, suite.cottonID, 2)
+	result := suite.index.Search("cotton", storage.PharmacyListCondition{})
+	suite.Len(result, 1)
+	suite.Equal(float64(30), result[0].CashBalance)
+}
+
+func (suite *IndexSuite) TestPharmacyDecorator() {
+	decorator := NewPharmacy(suite.source, suite.index)
+	result, err := decorator.ListPharmacyMixProduct(suite.ctx, 1, 2, "keystone", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{})
+	suite.NoError(err)
+	suite.Equal(int64(2), result.Count)
+	suite.Len(result.PharmacyProducts, 1)
+	suite.Equal(suite.barrierID, result.PharmacyProducts[0].ProductID)
+
+	_, err = decorator.ListPharmacyMixProduct(suite.ctx, 0, 1, "keystone", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func (suite *IndexSuite) TestPharmacyDecoratorPageToken() {
+	decorator := NewPharmacy(suite.source, suite.index)
+	first, err := decorator.ListPharmacyMixProduct(suite.ctx, 1, 1, "keystone", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{})
+	suite.NoError(err)
+	suite.Len(first.PharmacyProducts, 1)
+	suite.NotEmpty(first.NextPageToken)
+
+	second, err := decorator.ListPharmacyMixProduct(suite.ctx, 1, 1, "keystone", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{
+		Token:        first.NextPageToken,
+		WithoutCount: true,
+	})
+	suite.NoError(err)
+	suite.Zero(second.Count)
+	suite.Len(second.PharmacyProducts, 1)
+	suite.Equal(suite.barrierID, second.PharmacyProducts[0].ProductID)
+	suite.Empty(second.NextPageToken)
+
+	_, err = decorator.ListPharmacyMixProduct(suite.ctx, 1, 1, "keystone", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{Token: "%%%"})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+	_, err = decorator.ListPharmacyMixProduct(suite.ctx, 1, 1, "keystone", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{
+		Token: storage.PageToken{Sort: storage.SortKey([]storage.Sort{{Field: storage.FieldPharmacyName}})}.Encode(),
+	})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func TestIndexSuite(t *testing.T) {
+	suite.Run(t, new(IndexSuite))
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"context"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+)
+
+// NewPharmacy method
+func NewPharmacy(origin storage.IPharmacy, index *Index) *Pharmacy {
+	return &Pharmacy{
+		IPharmacy: origin,
+		index:     index,
+	}
+}
+
+// Pharmacy serves relevance ranked ListPharmacyMixProduct without Sorts from the index and keeps it in sync on Create and Upsert,
+// every other call goes to the wrapped storage.
+type Pharmacy struct {
+	storage.IPharmacy
+	index *Index
+}
+
+func (st Pharmacy) Create(ctx context.Context, input entity.Pharmacy) error {
+	if err := st.IPharmacy.Create(ctx, input); err != nil {
+		return err
+	}
+	st.index.PutPharmacy(input)
+	return nil
+}
+
+func (st Pharmacy) Upsert(ctx context.Context, input entity.Pharmacy) error {
+	if err := st.IPharmacy.Upsert(ctx, input); err != nil {
+		return err
+	}
+	st.index.PutPharmacy(input)
+	return nil
+}
+
+func (st Pharmacy) ListPharmacyMixProduct(ctx context.Context, row, page uint64, name string, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*entity.PharmacyProductList, error) {
+	if orderEnum != storage.Relevance || len(condition.Sorts) != 0 {
+		return st.IPharmacy.ListPharmacyMixProduct(ctx, row, page, name, orderEnum, condition, cursor)
+	}
+	return storage.PageRelevance(st.index.Search(name, condition), row, page, cursor)
+}
+
+// NewProduct method
+func NewProduct(origin storage.IProduct, index *Index) *Product {
+	return &Product{
+		IProduct: origin,
+		index:    index,
+	}
+}
+
+// Product keeps the index in sync on Create, Upsert and Purchase, every other call goes to the wrapped storage.
+type Product struct {
+	storage.IProduct
+	index *Index
+}
+
+func (st Product) Create(ctx context.Context, input entity.Product) error {
+	if err := st.IProduct.Create(ctx, input); err != nil {
+		return err
+	}
+	st.index.PutProduct(input)
+	return nil
+}
+
+func (st Product) Upsert(ctx context.Context, input entity.Product) error {
+	if err := st.IProduct.Upsert(ctx, input); err != nil {
+		return err
+	}
+	st.index.PutProduct(input)
+	return nil
+}
+
+func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []byte, quantity int) error {
+	if err := st.IProduct.Purchase(ctx, userID, pharmacyID, productID, quantity); err != nil {
+		return err
+	}
+	st.index.ApplyPurchase(pharmacyID, productID, quantity)
+	return nil
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"phantom_mask/internal/entity"
+)
+
+// BatchResult counts how the rows of a batch write compared against the stored rows.
+type BatchResult struct {
+	Inserted  int
+	Updated   int
+	Unchanged int
+	Deleted   int
+}
+
+// Add sums other into r.
+func (r *BatchResult) Add(other BatchResult) {
+	r.Inserted += other.Inserted
+	r.Updated += other.Updated
+	r.Unchanged += other.Unchanged
+	r.Deleted += other.Deleted
+}
+
+// IBatch writes many rows at once for bulk loads such as the importer. Every method upserts, so writing the
+// same inputs again converges to the same rows, and implementations split the inputs into as few commits as
+// the backend allows (Spanner mutation batches, COPY or multi-row inserts elsewhere). Rows equal to the stored
+// ones are not written again and only count as unchanged.
+type IBatch interface {
+	UpsertPharmacies(ctx context.Context, inputs []entity.Pharmacy) (BatchResult, error)
+	// ReplacePharmacyInfos replaces the opening hours rows of every pharmacy in pharmacyIDs with its inputs,
+	// pharmacies whose rows already equal the inputs are left untouched
+	ReplacePharmacyInfos(ctx context.Context, pharmacyIDs [][]byte, inputs []entity.PharmacyInfo) (BatchResult, error)
+	UpsertMasks(ctx context.Context, inputs []entity.Mask) (BatchResult, error)
+	// UpsertProducts fills the mask attributes and canonical MaskID of every product like IProduct.Create
+	UpsertProducts(ctx context.Context, inputs []entity.Product) (BatchResult, error)
+	UpsertUsers(ctx context.Context, inputs []entity.User) (BatchResult, error)
+	UpsertPurchaseHistories(ctx context.Context, inputs []entity.PurchaseHistory) (BatchResult, error)
+	// ProductsExist reports for every input whether a product with its UID and ProductID is stored
+	ProductsExist(ctx context.Context, inputs []entity.Product) ([]bool, error)
+}
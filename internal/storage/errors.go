@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/justdomepaul/toolbox/errorhandler"
+)
+
+// The errors the storage reports for the domain, the not found ones wrap errorhandler.ErrNoRows.
+var (
+	ErrUserNotFound        = fmt.Errorf("%w: user not found", errorhandler.ErrNoRows)
+	ErrPharmacyNotFound    = fmt.Errorf("%w: pharmacy not found", errorhandler.ErrNoRows)
+	ErrProductNotFound     = fmt.Errorf("%w: product not found", errorhandler.ErrNoRows)
+	ErrCredentialNotFound  = fmt.Errorf("%w: credential not found", errorhandler.ErrNoRows)
+	ErrInsufficientBalance = errors.New("user CashBalance not enough")
+	ErrEmailTaken          = fmt.Errorf("%w: email already registered", errorhandler.ErrAlreadyExists)
+)
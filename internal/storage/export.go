@@ -0,0 +1,12 @@
+package storage
+
+import (
+	"context"
+	"phantom_mask/internal/entity"
+)
+
+// IExport reads whole tables for bulk dumps such as the exporter.
+type IExport interface {
+	// Snapshot reads every table at the same timestamp, rows in primary key order
+	Snapshot(ctx context.Context) (*entity.Snapshot, error)
+}
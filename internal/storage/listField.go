@@ -0,0 +1,47 @@
+package storage
+
+// The sort and filter whitelists of the list endpoints, mapping the field names of their sort and filter query to fields.
+var (
+	PharmacySortFields = map[string]ListField{
+		"name":         FieldPharmacyName,
+		"cash_balance": FieldCashBalance,
+		"created_time": FieldCreatedTime,
+	}
+	PharmacyFilterFields = PharmacySortFields
+
+	MixSortFields = map[string]ListField{
+		"pharmacy_name": FieldPharmacyName,
+		"product_name":  FieldProductName,
+		"cash_balance":  FieldCashBalance,
+		"price":         FieldPrice,
+	}
+	MixFilterFields = map[string]ListField{
+		"pharmacy_name": FieldPharmacyName,
+		"product_name":  FieldProductName,
+		"cash_balance":  FieldCashBalance,
+		"price":         FieldPrice,
+		"brand":         FieldBrand,
+		"color":         FieldColor,
+		"pack_size":     FieldPackSize,
+	}
+	ProductSortFields = map[string]ListField{
+		"name":         FieldProductName,
+		"price":        FieldPrice,
+		"created_time": FieldCreatedTime,
+	}
+	ProductFilterFields = map[string]ListField{
+		"name":         FieldProductName,
+		"price":        FieldPrice,
+		"brand":        FieldBrand,
+		"color":        FieldColor,
+		"pack_size":    FieldPackSize,
+		"created_time": FieldCreatedTime,
+	}
+	ProductCountSortFields = map[string]ListField{
+		"name":          FieldPharmacyName,
+		"cash_balance":  FieldCashBalance,
+		"created_time":  FieldCreatedTime,
+		"product_count": FieldProductCount,
+	}
+	ProductCountFilterFields = ProductCountSortFields
+)
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/utils"
+)
+
+// NewCanonicalMask builds the catalogue entry for a product name, its MaskID is derived from the normalized name
+// so every pharmacy listing of the same mask resolves to it.
+func NewCanonicalMask(name string) entity.Mask {
+	attr := utils.ParseMaskName(name)
+	return entity.Mask{
+		MaskID:   utils.CanonicalMaskID(name),
+		Name:     utils.NormalizeProductName(name),
+		Brand:    attr.Brand,
+		Color:    attr.Color,
+		PackSize: attr.PackSize,
+	}
+}
+
+type IMask interface {
+	// Create method
+	// creating a mask already in the catalogue is not an error
+	Create(ctx context.Context, input entity.Mask) error
+	Get(ctx context.Context, maskID []byte) (*entity.Mask, error)
+	// List method
+	// row required, and min is 1
+	// page required, and min is 1
+	List(ctx context.Context, row, page uint64, orderEnum OrderListEnum) (*entity.MaskList, error)
+	// ListTransactionAmount method
+	// sums the purchase histories within the range by the canonical mask of the purchased product
+	ListTransactionAmount(ctx context.Context, startTime, endTime int64) (*entity.MaskTransactionList, error)
+	// ListByIDs method
+	// reads the catalogue masks of maskIDs in a single read, the missing ones are left out
+	ListByIDs(ctx context.Context, maskIDs [][]byte) ([]*entity.Mask, error)
+}
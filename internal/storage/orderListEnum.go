@@ -5,12 +5,11 @@ type OrderListEnum int
 const (
 	CreatedTimeASC OrderListEnum = iota
 	CreatedTimeDESC
-	UpdatedTimeASC
-	UpdatedTimeDESC
 	PharmacyNameASC
 	ProductNameASC
 	ProductPriceASC
 	PharmacyProduct
+	Relevance
 )
 
 type OrderPrimaryKeyListEnum bool
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/justdomepaul/toolbox/entity"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/spannertool"
+	internalEntity "phantom_mask/internal/entity"
+	"sort"
+	"time"
+)
+
+// Cursor picks the page of a list by keyset instead of by page number and tells whether to count every matching row.
+type Cursor struct {
+	// Token is the NextPageToken of the previous page, when set the list continues right after that page and page is ignored
+	Token string
+	// WithoutCount leaves Count zero instead of counting every matching row
+	WithoutCount bool
+}
+
+// PageToken is the position of the last row of a page in the list order, NextPageToken is its opaque encoding.
+type PageToken struct {
+	Sort         string    `json:"o"`
+	PharmacyName string    `json:"n,omitempty"`
+	ProductName  string    `json:"pn,omitempty"`
+	CashBalance  float64   `json:"c,omitempty"`
+	Price        float64   `json:"p,omitempty"`
+	ProductCount int64     `json:"pc,omitempty"`
+	Score        float64   `json:"s,omitempty"`
+	CreatedTime  time.Time `json:"t"`
+	UID          []byte    `json:"u,omitempty"`
+	ProductID    []byte    `json:"i,omitempty"`
+}
+
+// RelevanceSortKey is the SortKey of lists ranked by relevance.
+const RelevanceSortKey = "relevance"
+
+// Value returns the value of field in the row the token points at.
+func (t PageToken) Value(field ListField) interface{} {
+	switch field {
+	case FieldPharmacyName:
+		return t.PharmacyName
+	case FieldProductName:
+		return t.ProductName
+	case FieldCashBalance:
+		return t.CashBalance
+	case FieldPrice:
+		return t.Price
+	case FieldProductCount:
+		return t.ProductCount
+	case FieldCreatedTime:
+		return t.CreatedTime
+	}
+	return nil
+}
+
+// Encode returns the token as an url safe string.
+func (t PageToken) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken parses token, which must come from a list sorted as sortKey.
+func DecodePageToken(token string, sortKey string) (PageToken, error) {
+	result := PageToken{}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return result, fmt.Errorf("%w: malformed page token", errorhandler.ErrInvalidArguments)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("%w: malformed page token", errorhandler.ErrInvalidArguments)
+	}
+	if result.Sort != sortKey {
+		return result, fmt.Errorf("%w: page token belongs to another sort order", errorhandler.ErrInvalidArguments)
+	}
+	return result, nil
+}
+
+// RelevanceLess orders pharmacy products by descending Score, then by pharmacy name, product name and key,
+// so every pharmacy product has exactly one position.
+func RelevanceLess(a, b *internalEntity.PharmacyProduct) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.PharmacyName != b.PharmacyName {
+		return a.PharmacyName < b.PharmacyName
+	}
+	if a.ProductName != b.ProductName {
+		return a.ProductName < b.ProductName
+	}
+	if c := bytes.Compare(a.UID, b.UID); c != 0 {
+		return c < 0
+	}
+	return bytes.Compare(a.ProductID, b.ProductID) < 0
+}
+
+// PageRelevance sorts matches with RelevanceLess and returns the page selected by row and page, or by cursor when it has a token.
+func PageRelevance(matches []*internalEntity.PharmacyProduct, row, page uint64, cursor Cursor) (*internalEntity.PharmacyProductList, error) {
+	if err := spannertool.ValidListArgument(row, page); err != nil {
+		return nil, err
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return RelevanceLess(matches[i], matches[j])
+	})
+
+	resp := &internalEntity.PharmacyProductList{
+		CommonListResponse: entity.CommonListResponse{
+			Row:  int64(row),
+			Page: int64(page),
+		},
+		PharmacyProducts: []*internalEntity.PharmacyProduct{},
+	}
+	if !cursor.WithoutCount {
+		resp.Count = int64(len(matches))
+	}
+	offset := (page - 1) * row
+	if cursor.Token != "" {
+		token, err := DecodePageToken(cursor.Token, RelevanceSortKey)
+		if err != nil {
+			return nil, err
+		}
+		last := &internalEntity.PharmacyProduct{
+			UID:          token.UID,
+			ProductID:    token.ProductID,
+			PharmacyName: token.PharmacyName,
+			ProductName:  token.ProductName,
+			Score:        token.Score,
+		}
+		offset = uint64(sort.Search(len(matches), func(i int) bool {
+			return RelevanceLess(last, matches[i])
+		}))
+		resp.Page = 0
+	}
+	if offset >= uint64(len(matches)) {
+		return resp, nil
+	}
+	end := offset + row
+	if end >= uint64(len(matches)) {
+		end = uint64(len(matches))
+	} else {
+		last := matches[end-1]
+		resp.NextPageToken = PageToken{
+			Sort:         RelevanceSortKey,
+			PharmacyName: last.PharmacyName,
+			ProductName:  last.ProductName,
+			Score:        last.Score,
+			UID:          last.UID,
+			ProductID:    last.ProductID,
+		}.Encode()
+	}
+	resp.PharmacyProducts = matches[offset:end]
+	return resp, nil
+}
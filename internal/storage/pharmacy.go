@@ -9,12 +9,34 @@ type PharmacyEnumType int
 
 const (
 	PharmacyProductPriceRange PharmacyEnumType = iota
+	PharmacyProductBrand
+	PharmacyProductColor
+	PharmacyProductPackSize
+	PharmacyOpenAt
+	PharmacyProductCount
+	PharmacyFilter
+)
+
+type CountOperator int
+
+const (
+	CountGreaterThan CountOperator = iota
+	CountLessThan
+	CountEqual
 )
 
 type PharmacyListCondition struct {
-	Fields []PharmacyEnumType
-	Min    int64
-	Max    int64
+	Fields   []PharmacyEnumType
+	Min      int64
+	Max      int64
+	Brand    string
+	Color    string
+	PackSize int64
+	OpenAt   int64
+	Operator CountOperator
+	Count    int64
+	Filters  []Filter
+	Sorts    []Sort
 }
 
 func WithPharmacyProductPriceRange(condition PharmacyListCondition, min, max int64) PharmacyListCondition {
@@ -24,18 +46,83 @@ func WithPharmacyProductPriceRange(condition PharmacyListCondition, min, max int
 	return condition
 }
 
+func WithPharmacyProductBrand(condition PharmacyListCondition, brand string) PharmacyListCondition {
+	condition.Fields = append(condition.Fields, PharmacyProductBrand)
+	condition.Brand = brand
+	return condition
+}
+
+func WithPharmacyProductColor(condition PharmacyListCondition, color string) PharmacyListCondition {
+	condition.Fields = append(condition.Fields, PharmacyProductColor)
+	condition.Color = color
+	return condition
+}
+
+func WithPharmacyProductPackSize(condition PharmacyListCondition, packSize int64) PharmacyListCondition {
+	condition.Fields = append(condition.Fields, PharmacyProductPackSize)
+	condition.PackSize = packSize
+	return condition
+}
+
+// WithPharmacyOpenAt keeps only pharmacies open at the specify utc0 millisecond timestamp.
+func WithPharmacyOpenAt(condition PharmacyListCondition, specifyTimestamp int64) PharmacyListCondition {
+	condition.Fields = append(condition.Fields, PharmacyOpenAt)
+	condition.OpenAt = specifyTimestamp
+	return condition
+}
+
+// WithPharmacyProductCount keeps only pharmacies whose number of matching products compares to count with operator.
+func WithPharmacyProductCount(condition PharmacyListCondition, operator CountOperator, count int64) PharmacyListCondition {
+	condition.Fields = append(condition.Fields, PharmacyProductCount)
+	condition.Operator = operator
+	condition.Count = count
+	return condition
+}
+
+// WithPharmacyFilter keeps only the rows matching every filter, see ParseFilter.
+func WithPharmacyFilter(condition PharmacyListCondition, filters ...Filter) PharmacyListCondition {
+	condition.Fields = append(condition.Fields, PharmacyFilter)
+	condition.Filters = append(condition.Filters, filters...)
+	return condition
+}
+
+// WithPharmacySort sorts the list by sorts instead of its orderEnum, see ParseSort.
+func WithPharmacySort(condition PharmacyListCondition, sorts ...Sort) PharmacyListCondition {
+	condition.Sorts = append(condition.Sorts, sorts...)
+	return condition
+}
+
 type IPharmacy interface {
 	Create(ctx context.Context, input entity.Pharmacy) error
+	// Upsert method
+	// inserts the pharmacy or updates the existing one with the same UID, keeping its CreatedTime
+	Upsert(ctx context.Context, input entity.Pharmacy) error
 	// ListPharmacyMixProduct method
 	// row required, and min is 1
 	// page required, and min is 1
-	ListPharmacyMixProduct(ctx context.Context, row, page uint64, name string, orderEnum OrderListEnum) (*entity.PharmacyProductList, error)
+	// orderEnum Relevance ranks by how well name matches the pharmacy or product name and fills Score
+	// cursor with a token continues after the page that returned it, NextPageToken is empty on the last page
+	ListPharmacyMixProduct(ctx context.Context, row, page uint64, name string, orderEnum OrderListEnum, condition PharmacyListCondition, cursor Cursor) (*entity.PharmacyProductList, error)
 	// ListSpecifyTime method
 	// row required, and min is 1
 	// page required, and min is 1
-	ListSpecifyTime(ctx context.Context, row, page uint64, specifyTimestamp int64, orderEnum OrderListEnum) (*entity.PharmacySpecifyTimestampList, error)
+	// cursor with a token continues after the page that returned it, NextPageToken is empty on the last page
+	ListSpecifyTime(ctx context.Context, row, page uint64, specifyTimestamp int64, orderEnum OrderListEnum, condition PharmacyListCondition, cursor Cursor) (*entity.PharmacySpecifyTimestampList, error)
 	// ListByProductPriceRange method
 	// row required, and min is 1
 	// page required, and min is 1
-	ListByProductPriceRange(ctx context.Context, row, page uint64, orderEnum OrderListEnum, condition PharmacyListCondition) (*entity.PharmacyList, error)
+	// ProductCount is the number of products of the pharmacy matching condition, WithPharmacyProductCount filters on it,
+	// the pharmacies without any are listed, as zero, for a CountLessThan or CountEqual count only
+	// cursor with a token continues after the page that returned it, NextPageToken is empty on the last page
+	ListByProductPriceRange(ctx context.Context, row, page uint64, orderEnum OrderListEnum, condition PharmacyListCondition, cursor Cursor) (*entity.PharmacyProductCountList, error)
+	// ListProductPriceComparison method
+	// row required, and min is 1
+	// page required, and min is 1
+	// groups the products whose name contains name by their catalogue mask, named after it and paged in name order,
+	// every group lists its pharmacies by ascending price
+	// and IsOpen tells whether the pharmacy is open at specifyTimestamp
+	ListProductPriceComparison(ctx context.Context, row, page uint64, name string, specifyTimestamp int64, condition PharmacyListCondition) (*entity.ProductPriceComparisonList, error)
+	// ListByIDs method
+	// reads the pharmacies of pharmacyIDs in a single read, the missing ones are left out
+	ListByIDs(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.Pharmacy, error)
 }
@@ -7,4 +7,10 @@ import (
 
 type IPharmacyInfo interface {
 	Create(ctx context.Context, input entity.PharmacyInfo) error
+	// Replace method
+	// replaces every opening hours row of the pharmacy with inputs in a single transaction
+	Replace(ctx context.Context, pharmacyID []byte, inputs []entity.PharmacyInfo) error
+	// ListByPharmacies method
+	// reads the opening hours rows of every pharmacy of pharmacyIDs in a single read
+	ListByPharmacies(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.PharmacyInfo, error)
 }
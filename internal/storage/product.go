@@ -3,17 +3,27 @@ package storage
 import (
 	"context"
 	"phantom_mask/internal/entity"
+	"phantom_mask/internal/utils"
 )
 
 type ProductEnumType int
 
 const (
 	ProductSpecifyPharmacy ProductEnumType = iota
+	ProductBrand
+	ProductColor
+	ProductPackSize
+	ProductFilter
 )
 
 type ProductListCondition struct {
 	Fields     []ProductEnumType
 	PharmacyID []byte
+	Brand      string
+	Color      string
+	PackSize   int64
+	Filters    []Filter
+	Sorts      []Sort
 }
 
 func WithProductSpecifyPharmacy(condition ProductListCondition, pharmacyID []byte) ProductListCondition {
@@ -22,11 +32,81 @@ func WithProductSpecifyPharmacy(condition ProductListCondition, pharmacyID []byt
 	return condition
 }
 
+func WithProductBrand(condition ProductListCondition, brand string) ProductListCondition {
+	condition.Fields = append(condition.Fields, ProductBrand)
+	condition.Brand = brand
+	return condition
+}
+
+func WithProductColor(condition ProductListCondition, color string) ProductListCondition {
+	condition.Fields = append(condition.Fields, ProductColor)
+	condition.Color = color
+	return condition
+}
+
+func WithProductPackSize(condition ProductListCondition, packSize int64) ProductListCondition {
+	condition.Fields = append(condition.Fields, ProductPackSize)
+	condition.PackSize = packSize
+	return condition
+}
+
+// WithProductFilter keeps only the products matching every filter, see ParseFilter.
+func WithProductFilter(condition ProductListCondition, filters ...Filter) ProductListCondition {
+	condition.Fields = append(condition.Fields, ProductFilter)
+	condition.Filters = append(condition.Filters, filters...)
+	return condition
+}
+
+// WithProductSort sorts the list by sorts instead of its orderEnum, see ParseSort.
+func WithProductSort(condition ProductListCondition, sorts ...Sort) ProductListCondition {
+	condition.Sorts = append(condition.Sorts, sorts...)
+	return condition
+}
+
+// WithMaskAttribute fills Brand, Color and PackSize from the product name when none of them is set.
+func WithMaskAttribute(input entity.Product) entity.Product {
+	if input.Brand != "" || input.Color != "" || input.PackSize != 0 {
+		return input
+	}
+	attr := utils.ParseMaskName(input.Name)
+	input.Brand = attr.Brand
+	input.Color = attr.Color
+	input.PackSize = attr.PackSize
+	return input
+}
+
+// WithCanonicalMask links the product to the catalogue mask of its name when MaskID is not set.
+func WithCanonicalMask(input entity.Product) entity.Product {
+	if len(input.MaskID) != 0 {
+		return input
+	}
+	input.MaskID = utils.CanonicalMaskID(input.Name)
+	return input
+}
+
+// ProductKey is the primary key of a product, the UID of its pharmacy and its ProductID.
+type ProductKey struct {
+	UID       []byte
+	ProductID []byte
+}
+
 type IProduct interface {
+	// Create method
+	// the product is linked to its catalogue mask, which is created along with it when missing
 	Create(ctx context.Context, input entity.Product) error
+	// Upsert method
+	// inserts the product or updates the existing one with the same UID and ProductID, keeping its CreatedTime
+	Upsert(ctx context.Context, input entity.Product) error
 	Purchase(ctx context.Context, userID, pharmacyID, productID []byte, quantity int) error
 	// List method
 	// row required, and min is 1
 	// page required, and min is 1
-	List(ctx context.Context, row, page uint64, orderEnum OrderListEnum, condition ProductListCondition) (*entity.ProductList, error)
+	// cursor with a token continues after the page that returned it, NextPageToken is empty on the last page
+	List(ctx context.Context, row, page uint64, orderEnum OrderListEnum, condition ProductListCondition, cursor Cursor) (*entity.ProductList, error)
+	// ListByPharmacies method
+	// reads the products of every pharmacy of pharmacyIDs in a single read
+	ListByPharmacies(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.Product, error)
+	// ListByKeys method
+	// reads the products of keys in a single read, the missing ones are left out
+	ListByKeys(ctx context.Context, keys []ProductKey) ([]*entity.Product, error)
 }
@@ -17,4 +17,10 @@ type PurchaseHistoryListCondition struct {
 
 type IPurchaseHistory interface {
 	Create(ctx context.Context, input entity.PurchaseHistory) error
+	// Upsert method
+	// inserts the purchase history or updates the existing one with the same UID and TransactionDate
+	Upsert(ctx context.Context, input entity.PurchaseHistory) error
+	// ListByUsers method
+	// reads the purchase histories of every user of userIDs in a single read
+	ListByUsers(ctx context.Context, userIDs [][]byte) ([]*entity.PurchaseHistory, error)
 }
@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"fmt"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListField is a column a list can be sorted or filtered by.
+type ListField int
+
+const (
+	FieldPharmacyName ListField = iota
+	FieldProductName
+	FieldCashBalance
+	FieldPrice
+	FieldBrand
+	FieldColor
+	FieldPackSize
+	FieldProductCount
+	FieldCreatedTime
+)
+
+type fieldType int
+
+const (
+	fieldString fieldType = iota
+	fieldFloat
+	fieldInteger
+	fieldTime
+)
+
+var listFieldType = map[ListField]fieldType{
+	FieldPharmacyName: fieldString,
+	FieldProductName:  fieldString,
+	FieldCashBalance:  fieldFloat,
+	FieldPrice:        fieldFloat,
+	FieldBrand:        fieldString,
+	FieldColor:        fieldString,
+	FieldPackSize:     fieldInteger,
+	FieldProductCount: fieldInteger,
+	FieldCreatedTime:  fieldTime,
+}
+
+// Sort orders a list by Field, ascending unless Desc.
+type Sort struct {
+	Field ListField
+	Desc  bool
+}
+
+// SortKey identifies sorts, a page token only continues a list sorted the same way.
+func SortKey(sorts []Sort) string {
+	keys := make([]string, 0, len(sorts))
+	for _, item := range sorts {
+		key := strconv.Itoa(int(item.Field))
+		if item.Desc {
+			key = "-" + key
+		}
+		keys = append(keys, key)
+	}
+	return strings.Join(keys, ",")
+}
+
+type FilterOperator int
+
+const (
+	FilterEqual FilterOperator = iota
+	FilterNotEqual
+	FilterGreaterThan
+	FilterGreaterEqual
+	FilterLessThan
+	FilterLessEqual
+	FilterContains
+)
+
+// filterOperatorSyntax lists the operators of the filter query, the two character ones first so they match before = < >.
+var filterOperatorSyntax = []struct {
+	Syntax   string
+	Operator FilterOperator
+}{
+	{Syntax: "!=", Operator: FilterNotEqual},
+	{Syntax: ">=", Operator: FilterGreaterEqual},
+	{Syntax: "<=", Operator: FilterLessEqual},
+	{Syntax: "=", Operator: FilterEqual},
+	{Syntax: ">", Operator: FilterGreaterThan},
+	{Syntax: "<", Operator: FilterLessThan},
+	{Syntax: "~", Operator: FilterContains},
+}
+
+// Filter keeps the rows whose Field compares to Value with Operator, Value is a string, float64, int64 or time.Time
+// following the type of Field.
+type Filter struct {
+	Field    ListField
+	Operator FilterOperator
+	Value    interface{}
+}
+
+var queryFieldSyntax = regexp.MustCompile(`^[a-z_]+`)
+
+// ParseSort parses a comma separated list of field names, each sorted descending when prefixed by -,
+// allowed maps the field names a list accepts to their fields.
+func ParseSort(query string, allowed map[string]ListField) ([]Sort, error) {
+	var result []Sort
+	seen := map[ListField]bool{}
+	for _, term := range strings.Split(query, ",") {
+		term = strings.TrimSpace(term)
+		item := Sort{}
+		if strings.HasPrefix(term, "-") {
+			item.Desc = true
+			term = term[1:]
+		}
+		field, ok := allowed[term]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported sort field %q", errorhandler.ErrInvalidArguments, term)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: duplicate sort field %q", errorhandler.ErrInvalidArguments, term)
+		}
+		seen[field] = true
+		item.Field = field
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// ParseFilter parses a comma separated list of "field operator value" terms, operator being one of = != > >= < <= and ~,
+// the case insensitive contains of string fields. String fields only support = != and ~, time fields take a utc0
+// millisecond timestamp. allowed maps the field names a list accepts to their fields.
+func ParseFilter(query string, allowed map[string]ListField) ([]Filter, error) {
+	var result []Filter
+	for _, term := range strings.Split(query, ",") {
+		term = strings.TrimSpace(term)
+		name := queryFieldSyntax.FindString(term)
+		field, ok := allowed[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported filter field %q", errorhandler.ErrInvalidArguments, name)
+		}
+		rest := strings.TrimSpace(term[len(name):])
+		filter := Filter{Field: field, Operator: -1}
+		for _, item := range filterOperatorSyntax {
+			if strings.HasPrefix(rest, item.Syntax) {
+				filter.Operator = item.Operator
+				rest = strings.TrimSpace(rest[len(item.Syntax):])
+				break
+			}
+		}
+		if filter.Operator < 0 {
+			return nil, fmt.Errorf("%w: missing operator in filter %q", errorhandler.ErrInvalidArguments, term)
+		}
+		value, err := parseFilterValue(field, filter.Operator, rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: filter %q: %s", errorhandler.ErrInvalidArguments, term, err.Error())
+		}
+		filter.Value = value
+		result = append(result, filter)
+	}
+	return result, nil
+}
+
+func parseFilterValue(field ListField, operator FilterOperator, value string) (interface{}, error) {
+	kind := listFieldType[field]
+	if kind == fieldString {
+		if operator != FilterEqual && operator != FilterNotEqual && operator != FilterContains {
+			return nil, fmt.Errorf("string fields only support = != and ~")
+		}
+		return value, nil
+	}
+	if operator == FilterContains {
+		return nil, fmt.Errorf("~ only applies to string fields")
+	}
+	switch kind {
+	case fieldFloat:
+		return strconv.ParseFloat(value, 64)
+	case fieldInteger:
+		return strconv.ParseInt(value, 10, 64)
+	default:
+		millisecond, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return time.UnixMilli(millisecond), nil
+	}
+}
+
+// MatchFilter tells whether value, typed after the field of filter, passes filter, for lists filtered in memory.
+func MatchFilter(filter Filter, value interface{}) bool {
+	compare := 0
+	switch v := value.(type) {
+	case string:
+		want, _ := filter.Value.(string)
+		switch filter.Operator {
+		case FilterEqual:
+			return strings.EqualFold(v, want)
+		case FilterNotEqual:
+			return !strings.EqualFold(v, want)
+		case FilterContains:
+			return strings.Contains(strings.ToLower(v), strings.ToLower(want))
+		}
+		return false
+	case float64:
+		want, _ := filter.Value.(float64)
+		compare = compareOrdered(v, want)
+	case int64:
+		want, _ := filter.Value.(int64)
+		compare = compareOrdered(v, want)
+	case time.Time:
+		want, _ := filter.Value.(time.Time)
+		compare = compareOrdered(v.UnixNano(), want.UnixNano())
+	default:
+		return false
+	}
+	switch filter.Operator {
+	case FilterEqual:
+		return compare == 0
+	case FilterNotEqual:
+		return compare != 0
+	case FilterGreaterThan:
+		return compare > 0
+	case FilterGreaterEqual:
+		return compare >= 0
+	case FilterLessThan:
+		return compare < 0
+	case FilterLessEqual:
+		return compare <= 0
+	}
+	return false
+}
+
+func compareOrdered[T float64 | int64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
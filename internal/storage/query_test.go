@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type QuerySuite struct {
+	suite.Suite
+	fields map[string]ListField
+}
+
+func (suite *QuerySuite) SetupTest() {
+	suite.fields = map[string]ListField{
+		"name":         FieldProductName,
+		"price":        FieldPrice,
+		"pack_size":    FieldPackSize,
+		"created_time": FieldCreatedTime,
+	}
+}
+
+func (suite *QuerySuite) TestParseSort() {
+	result, err := ParseSort("price, -name", suite.fields)
+	suite.NoError(err)
+	suite.Equal([]Sort{{Field: FieldPrice}, {Field: FieldProductName, Desc: true}}, result)
+	suite.Equal("3,-1", SortKey(result))
+
+	testCases := []struct {
+		Label string
+		Query string
+	}{
+		{Label: "Unknown field", Query: "brand"},
+		{Label: "Empty field", Query: "price,"},
+		{Label: "Duplicate field", Query: "price,-price"},
+	}
+	for _, tc := range testCases {
+		_, err := ParseSort(tc.Query, suite.fields)
+		suite.ErrorIs(err, errorhandler.ErrInvalidArguments, tc.Label)
+	}
+}
+
+func (suite *QuerySuite) TestParseFilter() {
+	result, err := ParseFilter("name~smile, price>=10,pack_size!=3,created_time<1650000000000", suite.fields)
+	suite.NoError(err)
+	suite.Equal([]Filter{
+		{Field: FieldProductName, Operator: FilterContains, Value: "smile"},
+		{Field: FieldPrice, Operator: FilterGreaterEqual, Value: float64(10)},
+		{Field: FieldPackSize, Operator: FilterNotEqual, Value: int64(3)},
+		{Field: FieldCreatedTime, Operator: FilterLessThan, Value: time.UnixMilli(1650000000000)},
+	}, result)
+
+	testCases := []struct {
+		Label string
+		Query string
+	}{
+		{Label: "Unknown field", Query: "brand=3M"},
+		{Label: "Missing operator", Query: "price"},
+		{Label: "Not a number", Query: "price>cheap"},
+		{Label: "Not an integer", Query: "pack_size=1.5"},
+		{Label: "Ordering a string", Query: "name>a"},
+		{Label: "Contains on a number", Query: "price~1"},
+	}
+	for _, tc := range testCases {
+		_, err := ParseFilter(tc.Query, suite.fields)
+		suite.ErrorIs(err, errorhandler.ErrInvalidArguments, tc.Label)
+	}
+}
+
+func (suite *QuerySuite) TestMatchFilter() {
+	testCases := []struct {
+		Label  string
+		Filter Filter
+		Value  interface{}
+		Want   bool
+	}{
+		{
+			Label:  "String equal ignores case",
+			Filter: Filter{Field: FieldProductName, Operator: FilterEqual, Value: "cotton kiss"},
+			Value:  "Cotton Kiss",
+			Want:   true,
+		},
+		{
+			Label:  "String contains",
+			Filter: Filter{Field: FieldProductName, Operator: FilterContains, Value: "KISS"},
+			Value:  "Cotton Kiss",
+			Want:   true,
+		},
+		{
+			Label:  "Float greater than",
+			Filter: Filter{Field: FieldPrice, Operator: FilterGreaterThan, Value: float64(10)},
+			Value:  float64(10),
+			Want:   false,
+		},
+		{
+			Label:  "Integer less or equal",
+			Filter: Filter{Field: FieldPackSize, Operator: FilterLessEqual, Value: int64(6)},
+			Value:  int64(6),
+			Want:   true,
+		},
+		{
+			Label:  "Unknown value",
+			Filter: Filter{Field: FieldCreatedTime, Operator: FilterEqual, Value: time.UnixMilli(0)},
+			Value:  nil,
+			Want:   false,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Equal(tc.Want, MatchFilter(tc.Filter, tc.Value), tc.Label)
+	}
+}
+
+func TestQuerySuite(t *testing.T) {
+	suite.Run(t, new(QuerySuite))
+}
@@ -0,0 +1,342 @@
+package spanner
+
+import (
+	"bytes"
+	spannerSyntax "cloud.google.com/go/spanner"
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/spannertool"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type BatchOption struct {
+	// Workers is how many commits run at the same time, at least 1
+	Workers int
+	// MaxMutations caps the mutated cells of a commit, spannertool.MaxMutations when zero
+	MaxMutations int
+}
+
+// NewBatch method
+func NewBatch(logger *zap.Logger, session spanner.ISession, option BatchOption) *Batch {
+	if option.Workers < 1 {
+		option.Workers = 1
+	}
+	if option.MaxMutations < 1 {
+		option.MaxMutations = spannertool.MaxMutations
+	}
+	return &Batch{
+		logger:  logger,
+		session: session,
+		option:  option,
+	}
+}
+
+// Batch splits the inputs into commits of at most MaxMutations cells and runs them on Workers goroutines,
+// logging the progress of every table as commits complete.
+type Batch struct {
+	logger  *zap.Logger
+	session spanner.ISession
+	option  BatchOption
+}
+
+func (st Batch) UpsertPharmacies(ctx context.Context, inputs []entity.Pharmacy) (storage.BatchResult, error) {
+	return st.upsert(ctx, pharmacyTable, []string{"UID"}, toRows(inputs))
+}
+
+func (st Batch) ReplacePharmacyInfos(ctx context.Context, pharmacyIDs [][]byte, inputs []entity.PharmacyInfo) (storage.BatchResult, error) {
+	result := storage.BatchResult{}
+	if len(pharmacyIDs) == 0 {
+		return result, nil
+	}
+	infoKey := func(info entity.PharmacyInfo) string {
+		return spannerSyntax.Key{info.UID, info.Day}.String()
+	}
+	keySets := make([]spannerSyntax.KeySet, 0, len(pharmacyIDs))
+	for _, pharmacyID := range pharmacyIDs {
+		keySets = append(keySets, spannerSyntax.Key{pharmacyID}.AsPrefix())
+	}
+	stored := map[string]entity.PharmacyInfo{}
+	iter := st.session.Single().Read(ctx, pharmacyInfoTable, spannerSyntax.KeySets(keySets...), []string{"UID", "Day", "OpenHour", "CloseHour"})
+	if err := iter.Do(func(r *spannerSyntax.Row) error {
+		info := entity.PharmacyInfo{}
+		if err := r.ToStruct(&info); err != nil {
+			return err
+		}
+		stored[infoKey(info)] = info
+		return nil
+	}); err != nil {
+		return result, err
+	}
+
+	var mut []*spannerSyntax.Mutation
+	for _, input := range inputs {
+		key := infoKey(input)
+		old, ok := stored[key]
+		delete(stored, key)
+		if ok && old.OpenHour == input.OpenHour && old.CloseHour == input.CloseHour {
+			result.Unchanged++
+			continue
+		}
+		write := spannerSyntax.InsertStruct
+		if ok {
+			write = spannerSyntax.UpdateStruct
+		}
+		prepareMut, err := write(pharmacyInfoTable, input)
+		if err != nil {
+			return result, err
+		}
+		mut = append(mut, prepareMut)
+		if ok {
+			result.Updated++
+		} else {
+			result.Inserted++
+		}
+	}
+	for _, old := range stored {
+		mut = append(mut, spannerSyntax.Delete(pharmacyInfoTable, spannerSyntax.Key{old.UID, old.Day}))
+		result.Deleted++
+	}
+
+	return result, st.run(ctx, pharmacyInfoTable, len(mut), columnCount(toRows(inputs)), func(ctx context.Context, start, end int) error {
+		_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+			return txn.BufferWrite(mut[start:end])
+		})
+		return err
+	})
+}
+
+func (st Batch) UpsertMasks(ctx context.Context, inputs []entity.Mask) (storage.BatchResult, error) {
+	return st.upsert(ctx, maskTable, []string{"MaskID"}, toRows(inputs))
+}
+
+func (st Batch) UpsertProducts(ctx context.Context, inputs []entity.Product) (storage.BatchResult, error) {
+	products := make([]entity.Product, 0, len(inputs))
+	for _, input := range inputs {
+		products = append(products, storage.WithCanonicalMask(storage.WithMaskAttribute(input)))
+	}
+	return st.upsert(ctx, productTable, []string{"UID", "ProductID"}, toRows(products))
+}
+
+func (st Batch) UpsertUsers(ctx context.Context, inputs []entity.User) (storage.BatchResult, error) {
+	return st.upsert(ctx, userTable, []string{"UID"}, toRows(inputs))
+}
+
+func (st Batch) UpsertPurchaseHistories(ctx context.Context, inputs []entity.PurchaseHistory) (storage.BatchResult, error) {
+	return st.upsert(ctx, purchaseHistoryTable, []string{"UID", "TransactionDate"}, toRows(inputs))
+}
+
+func (st Batch) ProductsExist(ctx context.Context, inputs []entity.Product) ([]bool, error) {
+	result := make([]bool, len(inputs))
+	if len(inputs) == 0 {
+		return result, nil
+	}
+	keys := make([]spannerSyntax.Key, 0, len(inputs))
+	for _, input := range inputs {
+		keys = append(keys, spannerSyntax.Key{input.UID, input.ProductID})
+	}
+	existing := map[string]bool{}
+	iter := st.session.Single().Read(ctx, productTable, spannerSyntax.KeySetFromKeys(keys...), []string{"UID", "ProductID"})
+	if err := iter.Do(func(r *spannerSyntax.Row) error {
+		var uid, productID []byte
+		if err := r.Columns(&uid, &productID); err != nil {
+			return err
+		}
+		existing[spannerSyntax.Key{uid, productID}.String()] = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for index, key := range keys {
+		result[index] = existing[key.String()]
+	}
+	return result, nil
+}
+
+func toRows[T any](inputs []T) []interface{} {
+	rows := make([]interface{}, 0, len(inputs))
+	for _, input := range inputs {
+		rows = append(rows, input)
+	}
+	return rows
+}
+
+func columnCount(rows []interface{}) int {
+	if len(rows) == 0 {
+		return 1
+	}
+	return reflect.TypeOf(rows[0]).NumField()
+}
+
+// sameValue compares two column values, timestamps by instant and byte slices by content.
+func sameValue(a, b interface{}) bool {
+	switch value := a.(type) {
+	case time.Time:
+		other, ok := b.(time.Time)
+		return ok && value.Equal(other)
+	case []byte:
+		other, ok := b.([]byte)
+		return ok && bytes.Equal(value, other)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// upsert validates rows, then per commit reads the stored rows with the same keys and inserts the new rows or
+// updates the changed ones, leaving CreatedTime of existing rows untouched like the Upsert methods. Rows equal
+// to the stored ones are not written.
+func (st Batch) upsert(ctx context.Context, table string, keyColumns []string, rows []interface{}) (storage.BatchResult, error) {
+	total := storage.BatchResult{}
+	validate := validator.New()
+	for index, row := range rows {
+		if err := validate.Struct(row); err != nil {
+			return total, fmt.Errorf("%w: %s[%d]: %s", errorhandler.ErrInvalidArguments, table, index, err.Error())
+		}
+	}
+	var mu sync.Mutex
+	err := st.run(ctx, table, len(rows), columnCount(rows), func(ctx context.Context, start, end int) error {
+		var result storage.BatchResult
+		_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+			result = storage.BatchResult{}
+			keys := make([]spannerSyntax.Key, 0, end-start)
+			params := make([]spannertool.Parameters, 0, end-start)
+			var columns spannertool.Columns
+			for _, row := range rows[start:end] {
+				rowColumns, _, rowParams := spannertool.FetchSpannerTagValue(row, false, DBCreatedTime)
+				columns = rowColumns
+				keys = append(keys, rowKey(keyColumns, rowParams))
+				params = append(params, rowParams)
+			}
+			var compared []string
+			for _, column := range columns {
+				if column != DBCreatedTime {
+					compared = append(compared, column)
+				}
+			}
+
+			stored := map[string]spannertool.Parameters{}
+			rowType := reflect.TypeOf(rows[start])
+			iter := txn.Read(ctx, table, spannerSyntax.KeySetFromKeys(keys...), compared)
+			if err := iter.Do(func(r *spannerSyntax.Row) error {
+				value := reflect.New(rowType)
+				if err := r.ToStruct(value.Interface()); err != nil {
+					return err
+				}
+				_, _, storedParams := spannertool.FetchSpannerTagValue(value.Elem().Interface(), false, DBCreatedTime)
+				stored[rowKey(keyColumns, storedParams).String()] = storedParams
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			mut := make([]*spannerSyntax.Mutation, 0, len(keys))
+			for index, key := range keys {
+				values := map[string]interface{}{}
+				for _, column := range compared {
+					values[column] = params[index][column]
+				}
+				storedParams, ok := stored[key.String()]
+				if ok {
+					changed := false
+					for _, column := range compared {
+						if !sameValue(storedParams[column], values[column]) {
+							changed = true
+							break
+						}
+					}
+					if !changed {
+						result.Unchanged++
+						continue
+					}
+					mut = append(mut, spannerSyntax.UpdateMap(table, values))
+					result.Updated++
+					continue
+				}
+				if len(compared) != len(columns) {
+					values[DBCreatedTime] = spannerSyntax.CommitTimestamp
+				}
+				mut = append(mut, spannerSyntax.InsertMap(table, values))
+				result.Inserted++
+			}
+			if len(mut) == 0 {
+				return nil
+			}
+			return txn.BufferWrite(mut)
+		})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		total.Add(result)
+		mu.Unlock()
+		return nil
+	})
+	return total, err
+}
+
+func rowKey(keyColumns []string, params spannertool.Parameters) spannerSyntax.Key {
+	key := spannerSyntax.Key{}
+	for _, column := range keyColumns {
+		key = append(key, params[column])
+	}
+	return key
+}
+
+// run calls write for consecutive [start, end) ranges of total rows sized to fit MaxMutations,
+// on at most Workers goroutines, and returns the first error.
+func (st Batch) run(ctx context.Context, table string, total, columns int, write func(ctx context.Context, start, end int) error) error {
+	if total == 0 {
+		return nil
+	}
+	size := st.option.MaxMutations / columns
+	if size < 1 {
+		size = 1
+	}
+	ranges := make(chan [2]int)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		done     int64
+	)
+	for worker := 0; worker < st.option.Workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range ranges {
+				if err := write(ctx, r[0], r[1]); err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("%s rows %d-%d: %w", table, r[0], r[1], err)
+						cancel()
+					})
+					continue
+				}
+				st.logger.Info("batch committed",
+					zap.String("table", table),
+					zap.Int64("done", atomic.AddInt64(&done, int64(r[1]-r[0]))),
+					zap.Int("total", total),
+				)
+			}
+		}()
+	}
+	for start := 0; start < total && ctx.Err() == nil; start += size {
+		end := start + size
+		if end > total {
+			end = total
+		}
+		ranges <- [2]int{start, end}
+	}
+	close(ranges)
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,116 @@
+package spanner
+
+import (
+	spannerSyntax "cloud.google.com/go/spanner"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"testing"
+	"time"
+)
+
+type BatchSuite struct {
+	suite.Suite
+	ctx    context.Context
+	logger *zap.Logger
+	client *Batch
+}
+
+func (suite *BatchSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	logger, err := zap.NewDevelopment()
+	suite.NoError(err)
+	suite.logger = logger
+	suite.client = NewBatch(suite.logger, session, BatchOption{Workers: 3, MaxMutations: 8})
+}
+
+func (suite *BatchSuite) TestUpsertConverges() {
+	var pharmacies []entity.Pharmacy
+	var pharmacyIDs [][]byte
+	var infos []entity.PharmacyInfo
+	var products []entity.Product
+	for index := 0; index < 5; index++ {
+		uid, err := uuid.NewUUID()
+		suite.NoError(err)
+		productID, err := uuid.NewUUID()
+		suite.NoError(err)
+		pharmacies = append(pharmacies, entity.Pharmacy{
+			UID:         uid[:],
+			Name:        "TesterBatchPharmacy",
+			CashBalance: float64(index + 1),
+		})
+		pharmacyIDs = append(pharmacyIDs, uid[:])
+		infos = append(infos, entity.PharmacyInfo{UID: uid[:], Day: 1, OpenHour: 8, CloseHour: 12})
+		products = append(products, entity.Product{
+			UID:       uid[:],
+			ProductID: productID[:],
+			Name:      "TesterBatch (green) (3 per pack)",
+			Price:     10,
+		})
+	}
+
+	readCreatedTime := func(uid []byte) time.Time {
+		row, err := session.Single().ReadRow(suite.ctx, pharmacyTable, spannerSyntax.Key{uid}, []string{"CreatedTime"})
+		suite.NoError(err)
+		var createdTime time.Time
+		suite.NoError(row.Column(0, &createdTime))
+		return createdTime
+	}
+
+	result, err := suite.client.UpsertPharmacies(suite.ctx, pharmacies)
+	suite.NoError(err)
+	suite.Equal(storage.BatchResult{Inserted: 5}, result)
+	createdTime := readCreatedTime(pharmacyIDs[0])
+	pharmacies[0].CashBalance = 100
+	result, err = suite.client.UpsertPharmacies(suite.ctx, pharmacies)
+	suite.NoError(err)
+	suite.Equal(storage.BatchResult{Updated: 1, Unchanged: 4}, result)
+	suite.Equal(createdTime, readCreatedTime(pharmacyIDs[0]))
+	row, err := session.Single().ReadRow(suite.ctx, pharmacyTable, spannerSyntax.Key{pharmacyIDs[0]}, []string{"CashBalance"})
+	suite.NoError(err)
+	var cashBalance float64
+	suite.NoError(row.Column(0, &cashBalance))
+	suite.Equal(float64(100), cashBalance)
+
+	result, err = suite.client.ReplacePharmacyInfos(suite.ctx, pharmacyIDs, infos)
+	suite.NoError(err)
+	suite.Equal(storage.BatchResult{Inserted: 5}, result)
+	infos[0].CloseHour = 18
+	infos[1].Day = 2
+	result, err = suite.client.ReplacePharmacyInfos(suite.ctx, pharmacyIDs, infos)
+	suite.NoError(err)
+	suite.Equal(storage.BatchResult{Inserted: 1, Updated: 1, Unchanged: 3, Deleted: 1}, result)
+
+	result, err = suite.client.UpsertProducts(suite.ctx, products)
+	suite.NoError(err)
+	suite.Equal(storage.BatchResult{Inserted: 5}, result)
+	result, err = suite.client.UpsertProducts(suite.ctx, products)
+	suite.NoError(err)
+	suite.Equal(storage.BatchResult{Unchanged: 5}, result)
+
+	missingID, err := uuid.NewUUID()
+	suite.NoError(err)
+	exists, err := suite.client.ProductsExist(suite.ctx, []entity.Product{products[0], {UID: pharmacyIDs[0], ProductID: missingID[:]}})
+	suite.NoError(err)
+	suite.Equal([]bool{true, false}, exists)
+
+	var count int
+	iter := session.Single().Read(suite.ctx, productTable, spannerSyntax.Key{pharmacyIDs[0]}.AsPrefix(), []string{"MaskID", "PackSize"})
+	suite.NoError(iter.Do(func(r *spannerSyntax.Row) error {
+		var maskID []byte
+		var packSize int64
+		suite.NoError(r.Columns(&maskID, &packSize))
+		suite.NotEmpty(maskID)
+		suite.Equal(int64(3), packSize)
+		count++
+		return nil
+	}))
+	suite.Equal(1, count)
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
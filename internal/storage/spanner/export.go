@@ -0,0 +1,56 @@
+package spanner
+
+import (
+	spannerSyntax "cloud.google.com/go/spanner"
+	"context"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+)
+
+// NewExport method
+func NewExport(logger *zap.Logger, session spanner.ISession) *Export {
+	return &Export{
+		logger:  logger,
+		session: session,
+	}
+}
+
+type Export struct {
+	logger  *zap.Logger
+	session spanner.ISession
+}
+
+func (st Export) Snapshot(ctx context.Context) (*entity.Snapshot, error) {
+	txn := st.session.ReadOnlyTransaction()
+	defer txn.Close()
+	resp := &entity.Snapshot{}
+	if err := readAll(ctx, txn, pharmacyTable, &resp.Pharmacies); err != nil {
+		return nil, err
+	}
+	if err := readAll(ctx, txn, pharmacyInfoTable, &resp.PharmacyInfos); err != nil {
+		return nil, err
+	}
+	if err := readAll(ctx, txn, productTable, &resp.Products); err != nil {
+		return nil, err
+	}
+	if err := readAll(ctx, txn, userTable, &resp.Users); err != nil {
+		return nil, err
+	}
+	if err := readAll(ctx, txn, purchaseHistoryTable, &resp.PurchaseHistories); err != nil {
+		return nil, err
+	}
+	st.logger.Info("snapshot read",
+		zap.Int("pharmacies", len(resp.Pharmacies)),
+		zap.Int("pharmacy_infos", len(resp.PharmacyInfos)),
+		zap.Int("products", len(resp.Products)),
+		zap.Int("users", len(resp.Users)),
+		zap.Int("purchase_histories", len(resp.PurchaseHistories)),
+	)
+	return resp, nil
+}
+
+// readAll appends every row of table to dst, reading the columns tagged on T.
+func readAll[T any](ctx context.Context, txn *spannerSyntax.ReadOnlyTransaction, table string, dst *[]*T) error {
+	return readKeys(ctx, txn, table, spannerSyntax.AllKeys(), dst)
+}
@@ -0,0 +1,71 @@
+package spanner
+
+import (
+	"bytes"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+	"testing"
+)
+
+type ExportSuite struct {
+	suite.Suite
+	ctx            context.Context
+	logger         *zap.Logger
+	client         *Export
+	pharmacyClient *Pharmacy
+	productClient  *Product
+}
+
+func (suite *ExportSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	logger, err := zap.NewDevelopment()
+	suite.NoError(err)
+	suite.logger = logger
+	suite.client = NewExport(suite.logger, session)
+	suite.pharmacyClient = NewPharmacy(suite.logger, session)
+	suite.productClient = NewProduct(suite.logger, session)
+}
+
+func (suite *ExportSuite) TestSnapshotMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	productID, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.pharmacyClient.Create(suite.ctx, entity.Pharmacy{
+		UID:         uid[:],
+		Name:        "TesterExportPharmacy",
+		CashBalance: 10,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       uid[:],
+		ProductID: productID[:],
+		Name:      "TesterExport (green) (3 per pack)",
+		Price:     10,
+	}))
+
+	result, err := suite.client.Snapshot(suite.ctx)
+	suite.NoError(err)
+	var pharmacy *entity.Pharmacy
+	for _, item := range result.Pharmacies {
+		if bytes.Equal(item.UID, uid[:]) {
+			pharmacy = item
+		}
+	}
+	suite.NotNil(pharmacy)
+	suite.Equal("TesterExportPharmacy", pharmacy.Name)
+	var product *entity.Product
+	for _, item := range result.Products {
+		if bytes.Equal(item.ProductID, productID[:]) {
+			product = item
+		}
+	}
+	suite.NotNil(product)
+	suite.Equal(int64(3), product.PackSize)
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
@@ -1,7 +1,13 @@
 package spanner
 
 import (
+	spannerSyntax "cloud.google.com/go/spanner"
+	"context"
+	"fmt"
+	"github.com/justdomepaul/toolbox/spannertool"
+	"google.golang.org/grpc/codes"
 	"phantom_mask/internal/storage"
+	"strings"
 )
 
 var (
@@ -12,11 +18,53 @@ func withTimeOrder(orderEnum storage.OrderListEnum) string {
 	return map[storage.OrderListEnum]string{
 		storage.CreatedTimeASC:  " ORDER BY CreatedTime ASC",
 		storage.CreatedTimeDESC: " ORDER BY CreatedTime DESC",
-		storage.UpdatedTimeASC:  " ORDER BY UpdatedTime ASC",
-		storage.UpdatedTimeDESC: " ORDER BY UpdatedTime DESC",
 		storage.PharmacyNameASC: " ORDER BY Name ASC",
 		storage.ProductNameASC:  " ORDER BY Name ASC",
 		storage.ProductPriceASC: " ORDER BY Price ASC",
 		storage.PharmacyProduct: " ORDER BY PharmacyName ASC, ProductName ASC",
 	}[orderEnum]
 }
+
+// upsert inserts input into table, or when its keyColumns already exist updates every other column but CreatedTime,
+// so writing the same input again leaves the row unchanged.
+func upsert(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction, table string, keyColumns []string, input interface{}) error {
+	columns, placeholder, params := spannertool.FetchSpannerTagValue(input, false, DBCreatedTime)
+	isKey := map[string]bool{}
+	key := spannerSyntax.Key{}
+	for _, column := range keyColumns {
+		isKey[column] = true
+		key = append(key, params[column])
+	}
+	_, err := txn.ReadRow(ctx, table, key, keyColumns)
+	if spannerSyntax.ErrCode(err) == codes.NotFound {
+		stmt := spannerSyntax.Statement{
+			SQL:    fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, columns, placeholder),
+			Params: params,
+		}
+		_, err := txn.Update(ctx, stmt)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	var assignments, conditions []string
+	for _, column := range columns {
+		switch {
+		case isKey[column]:
+			conditions = append(conditions, fmt.Sprintf("%s = @%s", column, column))
+		case column != DBCreatedTime:
+			assignments = append(assignments, fmt.Sprintf("%s = @%s", column, column))
+		}
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(`UPDATE %s SET %s WHERE %s`,
+			table, strings.Join(assignments, ", "), strings.Join(conditions, " AND ")),
+		Params: params,
+	}
+	_, err = txn.Update(ctx, stmt)
+	return err
+}
@@ -0,0 +1,128 @@
+package spanner
+
+import (
+	"fmt"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/stringtool"
+	"phantom_mask/internal/storage"
+	"strings"
+)
+
+// keysetOrder lists the sorts of each orderEnum, a list sorts by the Sorts of its condition instead when given.
+var keysetOrder = map[storage.OrderListEnum][]storage.Sort{
+	storage.CreatedTimeASC:  {{Field: storage.FieldCreatedTime}},
+	storage.CreatedTimeDESC: {{Field: storage.FieldCreatedTime, Desc: true}},
+	storage.PharmacyNameASC: {{Field: storage.FieldPharmacyName}},
+	storage.ProductNameASC:  {{Field: storage.FieldProductName}},
+	storage.ProductPriceASC: {{Field: storage.FieldPrice}},
+	storage.PharmacyProduct: {{Field: storage.FieldPharmacyName}, {Field: storage.FieldProductName}},
+}
+
+// keyValue reads the value of a key column from a page token.
+var keyValue = map[string]func(token storage.PageToken) interface{}{
+	"UID":       func(token storage.PageToken) interface{} { return token.UID },
+	"ProductID": func(token storage.PageToken) interface{} { return token.ProductID },
+}
+
+// listSorts returns sorts, or the ones of orderEnum when there are none.
+func listSorts(orderEnum storage.OrderListEnum, sorts []storage.Sort) ([]storage.Sort, error) {
+	if len(sorts) != 0 {
+		return sorts, nil
+	}
+	sorts, ok := keysetOrder[orderEnum]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported list order %d", errorhandler.ErrInvalidArguments, orderEnum)
+	}
+	return sorts, nil
+}
+
+// withKeyset returns the ORDER BY clause of sorts followed by keyColumns, columns maps the sortable fields of the list
+// to their column. When cursor has a token it also appends the condition keeping only the rows after it to condition
+// and its values to args.
+func withKeyset(sorts []storage.Sort, columns map[storage.ListField]string, keyColumns []string, cursor storage.Cursor, condition *string, args map[string]interface{}) (string, error) {
+	type sortColumn struct {
+		Column string
+		Desc   bool
+		Value  func(token storage.PageToken) interface{}
+	}
+	var sortColumns []sortColumn
+	for _, item := range sorts {
+		column, ok := columns[item.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: unsupported sort field %d", errorhandler.ErrInvalidArguments, item.Field)
+		}
+		field := item.Field
+		sortColumns = append(sortColumns, sortColumn{
+			Column: column,
+			Desc:   item.Desc,
+			Value:  func(token storage.PageToken) interface{} { return token.Value(field) },
+		})
+	}
+	for _, column := range keyColumns {
+		sortColumns = append(sortColumns, sortColumn{Column: column, Value: keyValue[column]})
+	}
+
+	orders := make([]string, 0, len(sortColumns))
+	for _, column := range sortColumns {
+		direction := "ASC"
+		if column.Desc {
+			direction = "DESC"
+		}
+		orders = append(orders, fmt.Sprintf("%s %s", column.Column, direction))
+	}
+	orderSyntax := fmt.Sprintf(" ORDER BY %s", strings.Join(orders, ", "))
+
+	if cursor.Token == "" {
+		return orderSyntax, nil
+	}
+	token, err := storage.DecodePageToken(cursor.Token, storage.SortKey(sorts))
+	if err != nil {
+		return "", err
+	}
+	// (a, b, c) after (@A, @B, @C) is a > @A OR (a = @A AND (b > @B OR (b = @B AND c > @C)))
+	after := ""
+	for i := len(sortColumns) - 1; i >= 0; i-- {
+		param := fmt.Sprintf("After%d", i)
+		args[param] = sortColumns[i].Value(token)
+		operator := ">"
+		if sortColumns[i].Desc {
+			operator = "<"
+		}
+		if after == "" {
+			after = fmt.Sprintf("%s %s @%s", sortColumns[i].Column, operator, param)
+			continue
+		}
+		after = fmt.Sprintf("%[1]s %[2]s @%[3]s OR (%[1]s = @%[3]s AND (%[4]s))", sortColumns[i].Column, operator, param, after)
+	}
+	*condition = stringtool.StringJoin(*condition, fmt.Sprintf(" AND (%s)", after))
+	return orderSyntax, nil
+}
+
+// withCount counts the rows of from unless cursor skips the count, which then stays zero.
+func withCount(cursor storage.Cursor, from string) string {
+	if cursor.WithoutCount {
+		return "0"
+	}
+	return fmt.Sprintf("(SELECT COUNT(*) FROM %s)", from)
+}
+
+// withPageArgs sets the LIMIT and OFFSET of a keyset list, fetching one row more than row to tell whether a next page exists.
+// With a token the page number no longer applies, so Page is reported as zero.
+func withPageArgs(row, page uint64, cursor storage.Cursor, args map[string]interface{}) {
+	args["Row"] = int64(row)
+	args["Limit"] = int64(row + 1)
+	args["Page"] = int64(page)
+	args["Offset"] = int64((page - 1) * row)
+	if cursor.Token != "" {
+		args["Page"] = int64(0)
+		args["Offset"] = int64(0)
+	}
+}
+
+// trimPage cuts the row + 1 fetched items down to row and tells whether a next page exists.
+func trimPage[T any](items []T, row uint64) ([]T, bool) {
+	if uint64(len(items)) <= row {
+		return items, false
+	}
+	return items[:row], true
+}
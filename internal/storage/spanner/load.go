@@ -0,0 +1,49 @@
+package spanner
+
+import (
+	spannerSyntax "cloud.google.com/go/spanner"
+	"context"
+	"github.com/justdomepaul/toolbox/spannertool"
+	"phantom_mask/internal/storage"
+)
+
+// keysOf is the key set of the rows whose single column key is one of ids.
+func keysOf(ids [][]byte) spannerSyntax.KeySet {
+	keys := make([]spannerSyntax.KeySet, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, spannerSyntax.Key{id})
+	}
+	return spannerSyntax.KeySets(keys...)
+}
+
+// childKeysOf is the key set of the rows interleaved in the parent rows of ids.
+func childKeysOf(ids [][]byte) spannerSyntax.KeySet {
+	keys := make([]spannerSyntax.KeySet, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, spannerSyntax.Key{id}.AsPrefix())
+	}
+	return spannerSyntax.KeySets(keys...)
+}
+
+// productKeysOf is the key set of the products of keys.
+func productKeysOf(keys []storage.ProductKey) spannerSyntax.KeySet {
+	sets := make([]spannerSyntax.KeySet, 0, len(keys))
+	for _, key := range keys {
+		sets = append(sets, spannerSyntax.Key{key.UID, key.ProductID})
+	}
+	return spannerSyntax.KeySets(sets...)
+}
+
+// readKeys appends the rows of table within keys to dst, reading the columns tagged on T.
+func readKeys[T any](ctx context.Context, txn *spannerSyntax.ReadOnlyTransaction, table string, keys spannerSyntax.KeySet, dst *[]*T) error {
+	var zero T
+	columns, _, _ := spannertool.FetchSpannerTagValue(zero, false, DBCreatedTime)
+	return txn.Read(ctx, table, keys, columns).Do(func(r *spannerSyntax.Row) error {
+		item := new(T)
+		if err := r.ToStruct(item); err != nil {
+			return err
+		}
+		*dst = append(*dst, item)
+		return nil
+	})
+}
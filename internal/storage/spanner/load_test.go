@@ -0,0 +1,139 @@
+package spanner
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"phantom_mask/internal/utils"
+	"testing"
+	"time"
+)
+
+type LoadSuite struct {
+	suite.Suite
+	ctx                   context.Context
+	logger                *zap.Logger
+	pharmacyClient        *Pharmacy
+	pharmacyInfoClient    *PharmacyInfo
+	productClient         *Product
+	userClient            *User
+	purchaseHistoryClient *PurchaseHistory
+	maskClient            *Mask
+}
+
+func (suite *LoadSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	logger, err := zap.NewDevelopment()
+	suite.NoError(err)
+	suite.logger = logger
+	suite.pharmacyClient = NewPharmacy(suite.logger, session)
+	suite.pharmacyInfoClient = NewPharmacyInfo(suite.logger, session)
+	suite.productClient = NewProduct(suite.logger, session)
+	suite.userClient = NewUser(suite.logger, session)
+	suite.purchaseHistoryClient = NewPurchaseHistory(suite.logger, session)
+	suite.maskClient = NewMask(suite.logger, session)
+}
+
+func (suite *LoadSuite) newUID() []byte {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	return uid[:]
+}
+
+func (suite *LoadSuite) TestPharmacyMethods() {
+	first, second, missing := suite.newUID(), suite.newUID(), suite.newUID()
+	for i, uid := range [][]byte{first, second} {
+		suite.NoError(suite.pharmacyClient.Create(suite.ctx, entity.Pharmacy{
+			UID:         uid,
+			Name:        "TesterLoadPharmacy",
+			CashBalance: float64(i + 1),
+		}))
+		suite.NoError(suite.pharmacyInfoClient.Replace(suite.ctx, uid, []entity.PharmacyInfo{
+			{UID: uid, Day: 1, OpenHour: 8, CloseHour: 18},
+			{UID: uid, Day: 2, OpenHour: 8, CloseHour: 18},
+		}))
+		suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+			UID:       uid,
+			ProductID: suite.newUID(),
+			Name:      "TesterLoad (green) (3 per pack)",
+			Price:     10,
+		}))
+	}
+
+	pharmacies, err := suite.pharmacyClient.ListByIDs(suite.ctx, [][]byte{first, second, missing})
+	suite.NoError(err)
+	suite.Len(pharmacies, 2)
+
+	infos, err := suite.pharmacyInfoClient.ListByPharmacies(suite.ctx, [][]byte{first, second, missing})
+	suite.NoError(err)
+	suite.Len(infos, 4)
+
+	products, err := suite.productClient.ListByPharmacies(suite.ctx, [][]byte{first, second, missing})
+	suite.NoError(err)
+	suite.Len(products, 2)
+	suite.Equal(int64(3), products[0].PackSize)
+
+	keys := []storage.ProductKey{
+		{UID: products[0].UID, ProductID: products[0].ProductID},
+		{UID: first, ProductID: missing},
+	}
+	products, err = suite.productClient.ListByKeys(suite.ctx, keys)
+	suite.NoError(err)
+	suite.Len(products, 1)
+
+	masks, err := suite.maskClient.ListByIDs(suite.ctx, [][]byte{products[0].MaskID, missing})
+	suite.NoError(err)
+	suite.Len(masks, 1)
+	suite.Equal(utils.CanonicalMaskID("TesterLoad (green) (3 per pack)"), masks[0].MaskID)
+
+	pharmacies, err = suite.pharmacyClient.ListByIDs(suite.ctx, nil)
+	suite.NoError(err)
+	suite.Empty(pharmacies)
+}
+
+func (suite *LoadSuite) TestUserMethods() {
+	pharmacyUID, productID := suite.newUID(), suite.newUID()
+	suite.NoError(suite.pharmacyClient.Create(suite.ctx, entity.Pharmacy{
+		UID:         pharmacyUID,
+		Name:        "TesterLoadUserPharmacy",
+		CashBalance: 10,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       pharmacyUID,
+		ProductID: productID,
+		Name:      "TesterLoadUser (black) (10 per pack)",
+		Price:     10,
+	}))
+
+	first, second := suite.newUID(), suite.newUID()
+	for _, uid := range [][]byte{first, second} {
+		suite.NoError(suite.userClient.Create(suite.ctx, entity.User{
+			UID:         uid,
+			Name:        "TesterLoadUser",
+			CashBalance: 100,
+		}))
+		suite.NoError(suite.purchaseHistoryClient.Create(suite.ctx, entity.PurchaseHistory{
+			UID:               uid,
+			PharmacyUID:       pharmacyUID,
+			ProductID:         productID,
+			TransactionAmount: 10,
+			TransactionDate:   time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC),
+		}))
+	}
+
+	users, err := suite.userClient.ListByIDs(suite.ctx, [][]byte{first, second})
+	suite.NoError(err)
+	suite.Len(users, 2)
+
+	histories, err := suite.purchaseHistoryClient.ListByUsers(suite.ctx, [][]byte{first, second})
+	suite.NoError(err)
+	suite.Len(histories, 2)
+	suite.Equal(productID, histories[0].ProductID)
+}
+
+func TestLoadSuite(t *testing.T) {
+	suite.Run(t, new(LoadSuite))
+}
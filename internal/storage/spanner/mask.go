@@ -0,0 +1,180 @@
+package spanner
+
+import (
+	spannerSyntax "cloud.google.com/go/spanner"
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/justdomepaul/toolbox/database/spanner"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/spannertool"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"time"
+)
+
+var (
+	maskTable = "Mask"
+)
+
+// createMaskIfMissing inserts the catalogue mask unless its MaskID is already taken.
+func createMaskIfMissing(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction, input entity.Mask) error {
+	_, err := txn.ReadRow(ctx, maskTable, spannerSyntax.Key{input.MaskID}, []string{"MaskID"})
+	if err == nil {
+		return nil
+	}
+	if spannerSyntax.ErrCode(err) != codes.NotFound {
+		return err
+	}
+	columns, placeholder, params := spannertool.FetchSpannerTagValue(input, false, DBCreatedTime)
+	stmt := spannerSyntax.Statement{
+		SQL:    fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, maskTable, columns, placeholder),
+		Params: params,
+	}
+	_, err = txn.Update(ctx, stmt)
+	return err
+}
+
+// NewMask method
+func NewMask(logger *zap.Logger, session spanner.ISession) *Mask {
+	return &Mask{
+		logger:  logger,
+		session: session,
+	}
+}
+
+type Mask struct {
+	logger  *zap.Logger
+	session spanner.ISession
+}
+
+func (st Mask) Create(ctx context.Context, input entity.Mask) error {
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return createMaskIfMissing(ctx, txn, input)
+	})
+	return err
+}
+
+func (st Mask) Get(ctx context.Context, maskID []byte) (*entity.Mask, error) {
+	if err := validator.New().Var(maskID, `required`); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	row, err := st.session.Single().ReadRow(ctx, maskTable, spannerSyntax.Key{maskID},
+		[]string{"MaskID", "Name", "Brand", "Color", "PackSize", "CreatedTime"})
+	if spannerSyntax.ErrCode(err) == codes.NotFound {
+		return nil, fmt.Errorf("%w: %s", errorhandler.ErrNoRows, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		brand, color spannerSyntax.NullString
+		packSize     spannerSyntax.NullInt64
+	)
+	resp := &entity.Mask{}
+	if err := row.Columns(&resp.MaskID, &resp.Name, &brand, &color, &packSize, &resp.CreatedTime); err != nil {
+		return nil, err
+	}
+	resp.Brand = brand.StringVal
+	resp.Color = color.StringVal
+	resp.PackSize = packSize.Int64
+	return resp, nil
+}
+
+func (st Mask) List(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum) (*entity.MaskList, error) {
+	if err := spannertool.ValidListArgument(row, page); err != nil {
+		return nil, err
+	}
+
+	args := map[string]interface{}{}
+	args["Row"] = int64(row)
+	args["Offset"] = int64((page - 1) * row)
+	args["Page"] = int64(page)
+
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(
+			`
+SELECT 
+	(SELECT COUNT(*) FROM %s) AS Count, 
+	@Row AS Row, 
+	@Page AS Page, 
+	(SELECT ARRAY(
+		SELECT STRUCT(MaskID, Name, IFNULL(Brand, '') AS Brand, IFNULL(Color, '') AS Color, IFNULL(PackSize, 0) AS PackSize, CreatedTime) 
+		FROM %s%s LIMIT @Row OFFSET @Offset
+	)) AS Masks
+`, maskTable, maskTable, withTimeOrder(orderEnum),
+		),
+		Params: args,
+	}
+	iter := st.session.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	resp := &entity.MaskList{}
+	if err := spannertool.GetIteratorFirstRow(iter, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (st Mask) ListTransactionAmount(ctx context.Context, startTime, endTime int64) (*entity.MaskTransactionList, error) {
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(
+			`
+WITH Data AS (
+    SELECT M.MaskID AS MaskID, M.Name AS Name, COUNT(*) AS Total, SUM(PH.TransactionAmount) AS TransactionAmount
+    FROM %s AS PH JOIN %s AS P on PH.PharmacyUID = P.UID AND PH.ProductID = P.ProductID 
+    JOIN %s AS M on P.MaskID = M.MaskID
+    WHERE @StartTime <= TransactionDate AND TransactionDate <= @EndTime
+    GROUP BY M.MaskID, M.Name
+)
+SELECT
+    (SELECT ARRAY(
+        SELECT STRUCT(MaskID, Name, Total, TransactionAmount) FROM Data ORDER BY TransactionAmount DESC, Name ASC
+    )) AS MaskTransactions
+`, purchaseHistoryTable, productTable, maskTable),
+		Params: map[string]interface{}{
+			"StartTime": time.UnixMilli(startTime),
+			"EndTime":   time.UnixMilli(endTime),
+		},
+	}
+	iter := st.session.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	resp := &entity.MaskTransactionList{}
+	if err := spannertool.GetIteratorFirstRow(iter, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (st Mask) ListByIDs(ctx context.Context, maskIDs [][]byte) ([]*entity.Mask, error) {
+	var resp []*entity.Mask
+	if len(maskIDs) == 0 {
+		return resp, nil
+	}
+	err := st.session.Single().Read(ctx, maskTable, keysOf(maskIDs),
+		[]string{"MaskID", "Name", "Brand", "Color", "PackSize", "CreatedTime"}).Do(func(r *spannerSyntax.Row) error {
+		var (
+			brand, color spannerSyntax.NullString
+			packSize     spannerSyntax.NullInt64
+		)
+		item := &entity.Mask{}
+		if err := r.Columns(&item.MaskID, &item.Name, &brand, &color, &packSize, &item.CreatedTime); err != nil {
+			return err
+		}
+		item.Brand = brand.StringVal
+		item.Color = color.StringVal
+		item.PackSize = packSize.Int64
+		resp = append(resp, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+package spanner
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
+	"phantom_mask/internal/utils"
+	"testing"
+	"time"
+)
+
+type MaskSuite struct {
+	suite.Suite
+	ctx            context.Context
+	logger         *zap.Logger
+	client         *Mask
+	pharmacyClient *Pharmacy
+	productClient  *Product
+}
+
+func (suite *MaskSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	logger, err := zap.NewDevelopment()
+	suite.NoError(err)
+	suite.logger = logger
+	suite.client = NewMask(suite.logger, session)
+	suite.pharmacyClient = NewPharmacy(suite.logger, session)
+	suite.productClient = NewProduct(suite.logger, session)
+}
+
+func (suite *MaskSuite) TestCreateMethod() {
+	mask := storage.NewCanonicalMask("TesterMaskCreate (blue) (6 per pack)")
+	suite.NoError(suite.client.Create(suite.ctx, mask))
+	suite.NoError(suite.client.Create(suite.ctx, mask))
+	suite.ErrorIs(suite.client.Create(suite.ctx, entity.Mask{}), errorhandler.ErrInvalidArguments)
+
+	result, err := suite.client.Get(suite.ctx, mask.MaskID)
+	suite.NoError(err)
+	suite.Equal("testermaskcreate (blue) (6 per pack)", result.Name)
+	suite.Equal("TesterMaskCreate", result.Brand)
+	suite.Equal("blue", result.Color)
+	suite.Equal(int64(6), result.PackSize)
+
+	_, err = suite.client.Get(suite.ctx, utils.CanonicalMaskID("TesterMaskMissing"))
+	suite.ErrorIs(err, errorhandler.ErrNoRows)
+}
+
+func (suite *MaskSuite) TestProductsShareCanonicalMask() {
+	var pharmacyIDs, productIDs [][]byte
+	for _, name := range []string{"TesterMaskShareA", "TesterMaskShareB"} {
+		uid, err := uuid.NewUUID()
+		suite.NoError(err)
+		productID, err := uuid.NewUUID()
+		suite.NoError(err)
+		suite.NoError(suite.pharmacyClient.Create(suite.ctx, entity.Pharmacy{
+			UID:         uid[:],
+			Name:        name,
+			CashBalance: 100,
+		}))
+		suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+			UID:       uid[:],
+			ProductID: productID[:],
+			Name:      "TesterMaskShare (black) (10 per pack)",
+			Price:     10,
+		}))
+		pharmacyIDs = append(pharmacyIDs, uid[:])
+		productIDs = append(productIDs, productID[:])
+	}
+
+	maskID := utils.CanonicalMaskID("testermaskshare (black) (10 per pack)")
+	for _, pharmacyID := range pharmacyIDs {
+		result, err := suite.productClient.List(suite.ctx, 10, 1, storage.ProductNameASC,
+			storage.WithProductSpecifyPharmacy(storage.ProductListCondition{}, pharmacyID), storage.Cursor{})
+		suite.NoError(err)
+		suite.Equal(maskID, result.Products[0].MaskID)
+	}
+
+	userID, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(NewUser(suite.logger, session).Create(suite.ctx, entity.User{
+		UID:         userID[:],
+		Name:        "TesterMaskShareUser",
+		CashBalance: 100,
+	}))
+	start := time.Now().UnixMilli()
+	suite.NoError(suite.productClient.Purchase(suite.ctx, userID[:], pharmacyIDs[0], productIDs[0], 1))
+	suite.NoError(suite.productClient.Purchase(suite.ctx, userID[:], pharmacyIDs[1], productIDs[1], 2))
+
+	result, err := suite.client.ListTransactionAmount(suite.ctx, start, time.Now().Add(time.Minute).UnixMilli())
+	suite.NoError(err)
+	var found bool
+	for _, item := range result.MaskTransactions {
+		if string(item.MaskID) != string(maskID) {
+			continue
+		}
+		found = true
+		suite.Equal(int64(2), item.Total)
+		suite.Equal(float64(30), item.TransactionAmount)
+	}
+	suite.True(found)
+}
+
+func TestMaskSuite(t *testing.T) {
+	suite.Run(t, new(MaskSuite))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/justdomepaul/toolbox/database/spanner"
+	toolboxEntity "github.com/justdomepaul/toolbox/entity"
 	"github.com/justdomepaul/toolbox/errorhandler"
 	"github.com/justdomepaul/toolbox/spannertool"
 	"github.com/justdomepaul/toolbox/stringtool"
@@ -15,6 +16,7 @@ import (
 	"math"
 	"phantom_mask/internal/entity"
 	"phantom_mask/internal/storage"
+	"phantom_mask/internal/utils"
 	"time"
 )
 
@@ -24,6 +26,62 @@ var (
 
 var pharmacyClauseFn = map[storage.PharmacyEnumType]func(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error{
 	storage.PharmacyProductPriceRange: withPharmacyProductPriceRange,
+	storage.PharmacyProductBrand:      withPharmacyProductBrand,
+	storage.PharmacyProductColor:      withPharmacyProductColor,
+	storage.PharmacyProductPackSize:   withPharmacyProductPackSize,
+	storage.PharmacyOpenAt:            withPharmacyOpenAt,
+	storage.PharmacyFilter:            withPharmacyFilter,
+}
+
+var pharmacyHavingClauseFn = map[storage.PharmacyEnumType]func(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error{
+	storage.PharmacyProductCount: withPharmacyProductCount,
+	storage.PharmacyFilter:       withPharmacyHavingFilter,
+}
+
+// pharmacyFilterColumn maps the filterable fields of the pharmacy lists to their column, Ph being the pharmacy and P the product.
+var pharmacyFilterColumn = map[storage.ListField]string{
+	storage.FieldPharmacyName: "Ph.Name",
+	storage.FieldProductName:  "P.Name",
+	storage.FieldCashBalance:  "Ph.CashBalance",
+	storage.FieldPrice:        "P.Price",
+	storage.FieldBrand:        "IFNULL(P.Brand, '')",
+	storage.FieldColor:        "IFNULL(P.Color, '')",
+	storage.FieldPackSize:     "IFNULL(P.PackSize, 0)",
+	storage.FieldCreatedTime:  "Ph.CreatedTime",
+}
+
+// pharmacyHavingFilterColumn maps the aggregate fields of ListByProductPriceRange to their expression.
+var pharmacyHavingFilterColumn = map[storage.ListField]string{
+	storage.FieldProductCount: "COUNT(P.ProductID)",
+}
+
+// specifyTimeSortColumn maps the sortable fields of ListSpecifyTime to their column.
+var specifyTimeSortColumn = map[storage.ListField]string{
+	storage.FieldPharmacyName: "Name",
+	storage.FieldCashBalance:  "CashBalance",
+	storage.FieldCreatedTime:  "CreatedTime",
+}
+
+// pharmacyProductSortColumn maps the sortable fields of ListPharmacyMixProduct to their column.
+var pharmacyProductSortColumn = map[storage.ListField]string{
+	storage.FieldPharmacyName: "PharmacyName",
+	storage.FieldProductName:  "ProductName",
+	storage.FieldCashBalance:  "CashBalance",
+	storage.FieldPrice:        "Price",
+}
+
+// productCountSortColumn maps the sortable fields of ListByProductPriceRange to their column.
+var productCountSortColumn = map[storage.ListField]string{
+	storage.FieldPharmacyName: "Name",
+	storage.FieldCashBalance:  "CashBalance",
+	storage.FieldCreatedTime:  "CreatedTime",
+	storage.FieldProductCount: "ProductCount",
+}
+
+var countOperatorSyntax = map[storage.CountOperator]string{
+	storage.CountGreaterThan: ">",
+	storage.CountLessThan:    "<",
+	storage.CountEqual:       "=",
 }
 
 func withPharmacyProductPriceRange(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
@@ -33,16 +91,144 @@ func withPharmacyProductPriceRange(source storage.PharmacyListCondition, conditi
 	return nil
 }
 
+func withPharmacyProductBrand(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	*condition = stringtool.StringJoin(*condition, ` AND LOWER(Brand) = LOWER(@Brand)`)
+	args["Brand"] = source.Brand
+	return nil
+}
+
+func withPharmacyProductColor(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	*condition = stringtool.StringJoin(*condition, ` AND LOWER(Color) = LOWER(@Color)`)
+	args["Color"] = source.Color
+	return nil
+}
+
+func withPharmacyProductPackSize(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	*condition = stringtool.StringJoin(*condition, ` AND PackSize = @PackSize`)
+	args["PackSize"] = source.PackSize
+	return nil
+}
+
+func withPharmacyOpenAt(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	day, hour, err := specifyDayHour(source.OpenAt)
+	if err != nil {
+		return err
+	}
+	*condition = stringtool.StringJoin(*condition, fmt.Sprintf(` AND %s`, openAtSyntax("Ph.UID", "OpenAtDay", "OpenAtHour")))
+	args["OpenAtDay"] = day
+	args["OpenAtHour"] = hour
+	return nil
+}
+
+func withPharmacyProductCount(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	operator, ok := countOperatorSyntax[source.Operator]
+	if !ok {
+		return fmt.Errorf("%w: unsupported count operator %d", errorhandler.ErrInvalidArguments, source.Operator)
+	}
+	*condition = stringtool.StringJoin(*condition, fmt.Sprintf(` AND COUNT(P.ProductID) %s @ProductCount`, operator))
+	args["ProductCount"] = source.Count
+	return nil
+}
+
+func withPharmacyFilter(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	return withFilters(source.Filters, pharmacyFilterColumn, pharmacyHavingFilterColumn, condition, args)
+}
+
+func withPharmacyHavingFilter(source storage.PharmacyListCondition, condition *string, args map[string]interface{}) error {
+	return withFilters(source.Filters, pharmacyHavingFilterColumn, pharmacyFilterColumn, condition, args)
+}
+
+// specifyDayHour converts the specify utc0 millisecond timestamp to the UTC+8 weekday and hour stored in PharmacyInfo.
+func specifyDayHour(specifyTimestamp int64) (int64, float64, error) {
+	specify := timestamp.GetUTC8Time(specifyTimestamp)
+	specifyHour, err := time.ParseDuration(fmt.Sprintf("%dh%dm", specify.Hour(), specify.Minute()))
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(specify.Weekday()), math.Round(specifyHour.Hours()*100) / 100, nil
+}
+
+// openAtSyntax is an EXISTS expression telling whether the pharmacy uidColumn opens on the @dayParam weekday at @hourParam,
+// with the same overnight handling as ListSpecifyTime.
+func openAtSyntax(uidColumn, dayParam, hourParam string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM %[1]s AS PI WHERE PI.UID = %[2]s AND PI.Day = @%[3]s AND (CASE WHEN @%[4]s < PI.OpenHour
+		THEN PI.OpenHour <= @%[4]s + 24 AND @%[4]s + 24 < PI.CloseHour
+		ELSE PI.OpenHour <= @%[4]s AND @%[4]s < PI.CloseHour
+		END)
+	)`, pharmacyInfoTable, uidColumn, dayParam, hourParam)
+}
+
+// productJoinFields are the conditions on the product alone, which ListByProductPriceRange applies when joining the products
+// so the pharmacies without a matching product count zero, see countsNone.
+var productJoinFields = map[storage.PharmacyEnumType]bool{
+	storage.PharmacyProductPriceRange: true,
+	storage.PharmacyProductBrand:      true,
+	storage.PharmacyProductColor:      true,
+	storage.PharmacyProductPackSize:   true,
+}
+
+// countsNone reports whether source keeps the pharmacies of fewer than or exactly a count of matching products, the only
+// conditions the pharmacies without any matching product are listed for.
+func countsNone(source storage.PharmacyListCondition) bool {
+	for _, op := range source.Fields {
+		if op == storage.PharmacyProductCount && (source.Operator == storage.CountLessThan || source.Operator == storage.CountEqual) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitProductJoin separates the productJoinFields conditions of source from the rest.
+func splitProductJoin(source storage.PharmacyListCondition) (join, rest storage.PharmacyListCondition) {
+	join, rest = source, source
+	join.Fields, rest.Fields = nil, nil
+	for _, op := range source.Fields {
+		if productJoinFields[op] {
+			join.Fields = append(join.Fields, op)
+			continue
+		}
+		rest.Fields = append(rest.Fields, op)
+	}
+	return join, rest
+}
+
 func toPharmacyClauses(source storage.PharmacyListCondition) (conditionSyntax string, args map[string]interface{}, err error) {
 	args = map[string]interface{}{}
 	for _, op := range source.Fields {
-		if err := pharmacyClauseFn[op](source, &conditionSyntax, args); err != nil {
+		fn, ok := pharmacyClauseFn[op]
+		if !ok {
+			continue
+		}
+		if err := fn(source, &conditionSyntax, args); err != nil {
 			return conditionSyntax, args, err
 		}
 	}
 	return conditionSyntax, args, err
 }
 
+// toPharmacyHavingClauses appends the aggregate conditions to args and returns them as a HAVING clause, which keeps the
+// pharmacies of at least one matching product unless countsNone.
+func toPharmacyHavingClauses(source storage.PharmacyListCondition, args map[string]interface{}) (string, error) {
+	conditionSyntax := ""
+	if !countsNone(source) {
+		conditionSyntax = " AND COUNT(P.ProductID) > 0"
+	}
+	for _, op := range source.Fields {
+		fn, ok := pharmacyHavingClauseFn[op]
+		if !ok {
+			continue
+		}
+		if err := fn(source, &conditionSyntax, args); err != nil {
+			return "", err
+		}
+	}
+	if conditionSyntax == "" {
+		return "", nil
+	}
+	return stringtool.StringJoin(" HAVING TRUE", conditionSyntax), nil
+}
+
 // NewPharmacy method
 func NewPharmacy(logger *zap.Logger, session spanner.ISession) *Pharmacy {
 	return &Pharmacy{
@@ -75,16 +261,30 @@ func (st Pharmacy) Create(ctx context.Context, input entity.Pharmacy) error {
 	return err
 }
 
-func (st Pharmacy) ListPharmacyMixProduct(ctx context.Context, row, page uint64, name string, orderEnum storage.OrderListEnum) (*entity.PharmacyProductList, error) {
+func (st Pharmacy) ListPharmacyMixProduct(ctx context.Context, row, page uint64, name string, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*entity.PharmacyProductList, error) {
 	if err := spannertool.ValidListArgument(row, page); err != nil {
 		return nil, err
 	}
 
-	args := map[string]interface{}{}
+	conditionSyntax, args, err := toPharmacyClauses(condition)
+	if err != nil {
+		return nil, err
+	}
 
-	args["Row"] = int64(row)
-	args["Page"] = int64(page)
-	args["Offset"] = int64((page - 1) * row)
+	if orderEnum == storage.Relevance && len(condition.Sorts) == 0 {
+		return st.listPharmacyMixProductByRelevance(ctx, row, page, name, conditionSyntax, args, cursor)
+	}
+
+	sorts, err := listSorts(orderEnum, condition.Sorts)
+	if err != nil {
+		return nil, err
+	}
+	keysetSyntax := ""
+	orderSyntax, err := withKeyset(sorts, pharmacyProductSortColumn, []string{"UID", "ProductID"}, cursor, &keysetSyntax, args)
+	if err != nil {
+		return nil, err
+	}
+	withPageArgs(row, page, cursor, args)
 
 	args["Name"] = fmt.Sprintf(`\Q%s\E`, name)
 
@@ -92,19 +292,20 @@ func (st Pharmacy) ListPharmacyMixProduct(ctx context.Context, row, page uint64,
 		SQL: fmt.Sprintf(
 			`
 WITH Data AS (
-    SELECT Ph.UID AS UID, Ph.Name AS PharmacyName, CashBalance, ProductID, P.Name AS ProductName, Price 
+    SELECT Ph.UID AS UID, Ph.Name AS PharmacyName, CashBalance, ProductID, P.Name AS ProductName, Price, 
+		IFNULL(Brand, '') AS Brand, IFNULL(Color, '') AS Color, IFNULL(PackSize, 0) AS PackSize 
 	FROM %s AS Ph JOIN %s AS P on Ph.UID = P.UID 
-	WHERE REGEXP_CONTAINS(Ph.Name, @Name) OR REGEXP_CONTAINS(P.Name, @Name)
+	WHERE (REGEXP_CONTAINS(Ph.Name, @Name) OR REGEXP_CONTAINS(P.Name, @Name))%s
 )
 SELECT 
-	(SELECT COUNT(*) FROM Data) AS Count, 
+	%s AS Count, 
 	@Row AS Row, 
 	@Page AS Page, 
 	(SELECT ARRAY(
-		SELECT STRUCT(UID, ProductID, PharmacyName, CashBalance, ProductName, Price) 
-		FROM Data%s LIMIT @Row OFFSET @Offset
+		SELECT STRUCT(UID, ProductID, PharmacyName, CashBalance, ProductName, Price, Brand, Color, PackSize) 
+		FROM Data WHERE UID IS NOT NULL%s%s LIMIT @Limit OFFSET @Offset
 	)) AS PharmacyProducts
-`, pharmacyTable, productTable, withTimeOrder(orderEnum),
+`, pharmacyTable, productTable, conditionSyntax, withCount(cursor, "Data"), keysetSyntax, orderSyntax,
 		),
 		Params: args,
 	}
@@ -115,51 +316,106 @@ SELECT
 	if err := spannertool.GetIteratorFirstRow(iter, resp); err != nil {
 		return nil, err
 	}
+	var hasNext bool
+	if resp.PharmacyProducts, hasNext = trimPage(resp.PharmacyProducts, row); hasNext {
+		last := resp.PharmacyProducts[len(resp.PharmacyProducts)-1]
+		resp.NextPageToken = storage.PageToken{
+			Sort:         storage.SortKey(sorts),
+			PharmacyName: last.PharmacyName,
+			ProductName:  last.ProductName,
+			CashBalance:  last.CashBalance,
+			Price:        last.Price,
+			UID:          last.UID,
+			ProductID:    last.ProductID,
+		}.Encode()
+	}
 	return resp, nil
 }
 
-func (st Pharmacy) ListSpecifyTime(ctx context.Context, row, page uint64, specifyTimestamp int64, orderEnum storage.OrderListEnum) (*entity.PharmacySpecifyTimestampList, error) {
+// listPharmacyMixProductByRelevance scores every pharmacy product by the better of its pharmacy and product name,
+// drops the ones that do not match at all, and pages through the rest with storage.PageRelevance.
+func (st Pharmacy) listPharmacyMixProductByRelevance(ctx context.Context, row, page uint64, name, conditionSyntax string, args map[string]interface{}, cursor storage.Cursor) (*entity.PharmacyProductList, error) {
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(
+			`
+SELECT Ph.UID AS UID, ProductID, Ph.Name AS PharmacyName, CashBalance, P.Name AS ProductName, Price, 
+	IFNULL(Brand, '') AS Brand, IFNULL(Color, '') AS Color, IFNULL(PackSize, 0) AS PackSize
+FROM %s AS Ph JOIN %s AS P on Ph.UID = P.UID WHERE Ph.UID IS NOT NULL%s%s
+`, pharmacyTable, productTable, conditionSyntax, withTimeOrder(storage.PharmacyProduct),
+		),
+		Params: args,
+	}
+	iter := st.session.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var matches []*entity.PharmacyProduct
+	if err := iter.Do(func(r *spannerSyntax.Row) error {
+		item := &entity.PharmacyProduct{}
+		if err := r.ToStruct(item); err != nil {
+			return err
+		}
+		item.Score = math.Max(utils.Relevance(name, item.PharmacyName), utils.Relevance(name, item.ProductName))
+		if item.Score > 0 || name == "" {
+			matches = append(matches, item)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return storage.PageRelevance(matches, row, page, cursor)
+}
+
+func (st Pharmacy) ListSpecifyTime(ctx context.Context, row, page uint64, specifyTimestamp int64, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*entity.PharmacySpecifyTimestampList, error) {
 	if err := spannertool.ValidListArgument(row, page); err != nil {
 		return nil, err
 	}
 
-	args := map[string]interface{}{}
+	conditionSyntax, args, err := toPharmacyClauses(condition)
+	if err != nil {
+		return nil, err
+	}
 
-	args["Row"] = int64(row)
-	args["Page"] = int64(page)
-	args["Offset"] = int64((page - 1) * row)
+	sorts, err := listSorts(orderEnum, condition.Sorts)
+	if err != nil {
+		return nil, err
+	}
+	keysetSyntax := ""
+	orderSyntax, err := withKeyset(sorts, specifyTimeSortColumn, []string{"UID"}, cursor, &keysetSyntax, args)
+	if err != nil {
+		return nil, err
+	}
+	withPageArgs(row, page, cursor, args)
 
-	specify := timestamp.GetUTC8Time(specifyTimestamp)
-	specifyHour, err := time.ParseDuration(fmt.Sprintf("%dh%dm", specify.Hour(), specify.Minute()))
+	specifyDay, specifyHour, err := specifyDayHour(specifyTimestamp)
 	if err != nil {
 		return nil, err
 	}
 
-	args["SpecifyDay"] = int64(specify.Weekday())
-	args["SpecifyTime"] = math.Round(specifyHour.Hours()*100) / 100
+	args["SpecifyDay"] = specifyDay
+	args["SpecifyTime"] = specifyHour
 
 	stmt := spannerSyntax.Statement{
 		SQL: fmt.Sprintf(
 			`
 WITH SpecifyTimeData AS (
     SELECT
-        P.UID AS UID, Name, CashBalance, CreatedTime, Day, OpenHour, 
+        Ph.UID AS UID, Name, CashBalance, CreatedTime, Day, OpenHour, 
 		(CASE WHEN CloseHour > 24 THEN CloseHour-24 ELSE CloseHour END) AS CloseHour,
         (CASE WHEN @SpecifyTime < OpenHour
         THEN CASE WHEN OpenHour <= @SpecifyTime + 24 AND @SpecifyTime + 24 < CloseHour THEN true ELSE false END
         ELSE CASE WHEN OpenHour <= @SpecifyTime AND @SpecifyTime < CloseHour THEN true ELSE false END
         END) AS inRange
-    FROM %s AS P JOIN %s AS PI on P.UID = PI.UID WHERE Day = @SpecifyDay
+    FROM %s AS Ph JOIN %s AS PI on Ph.UID = PI.UID WHERE Day = @SpecifyDay%s
 )
 SELECT 
-	(SELECT COUNT(*) FROM SpecifyTimeData WHERE inRange = true) AS Count, 
+	%s AS Count, 
 	@Row AS Row, 
 	@Page AS Page, 
 	(SELECT ARRAY(
 		SELECT STRUCT(UID, Name, CashBalance, CreatedTime, Day, OpenHour, CloseHour) 
-		FROM specifyTimeData WHERE inRange = true%s LIMIT @Row OFFSET @Offset
+		FROM specifyTimeData WHERE inRange = true%s%s LIMIT @Limit OFFSET @Offset
 	)) AS Pharmacies
-`, pharmacyTable, pharmacyInfoTable, withTimeOrder(orderEnum),
+`, pharmacyTable, pharmacyInfoTable, conditionSyntax, withCount(cursor, "SpecifyTimeData WHERE inRange = true"), keysetSyntax, orderSyntax,
 		),
 		Params: args,
 	}
@@ -170,47 +426,220 @@ SELECT
 	if err := spannertool.GetIteratorFirstRow(iter, resp); err != nil {
 		return nil, err
 	}
+	var hasNext bool
+	if resp.Pharmacies, hasNext = trimPage(resp.Pharmacies, row); hasNext {
+		last := resp.Pharmacies[len(resp.Pharmacies)-1]
+		resp.NextPageToken = storage.PageToken{
+			Sort:         storage.SortKey(sorts),
+			PharmacyName: last.Name,
+			CashBalance:  last.CashBalance,
+			CreatedTime:  last.CreatedTime,
+			UID:          last.UID,
+		}.Encode()
+	}
 	return resp, nil
 }
 
-func (st Pharmacy) ListByProductPriceRange(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition) (*entity.PharmacyList, error) {
+func (st Pharmacy) ListByProductPriceRange(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum, condition storage.PharmacyListCondition, cursor storage.Cursor) (*entity.PharmacyProductCountList, error) {
 	if err := spannertool.ValidListArgument(row, page); err != nil {
 		return nil, err
 	}
 
-	conditionSyntax, args, err := toPharmacyClauses(condition)
+	join, rest := splitProductJoin(condition)
+	joinSyntax, args, err := toPharmacyClauses(join)
+	if err != nil {
+		return nil, err
+	}
+	conditionSyntax, restArgs, err := toPharmacyClauses(rest)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range restArgs {
+		args[key] = value
+	}
+	havingSyntax, err := toPharmacyHavingClauses(condition, args)
 	if err != nil {
 		return nil, err
 	}
 
-	args["Row"] = int64(row)
-	args["Offset"] = int64((page - 1) * row)
-	args["Page"] = int64(page)
+	sorts, err := listSorts(orderEnum, condition.Sorts)
+	if err != nil {
+		return nil, err
+	}
+	keysetSyntax := ""
+	orderSyntax, err := withKeyset(sorts, productCountSortColumn, []string{"UID"}, cursor, &keysetSyntax, args)
+	if err != nil {
+		return nil, err
+	}
+	withPageArgs(row, page, cursor, args)
 
 	stmt := spannerSyntax.Statement{
 		SQL: fmt.Sprintf(
 			`
 WITH Data AS (
-    SELECT DISTINCT Ph.* FROM %s AS Ph JOIN %s P on Ph.UID = P.UID WHERE Ph.UID IS NOT NULL%s
+    SELECT Ph.UID AS UID, Ph.Name AS Name, Ph.CashBalance AS CashBalance, Ph.CreatedTime AS CreatedTime, 
+		COUNT(P.ProductID) AS ProductCount 
+	FROM %s AS Ph LEFT JOIN %s P on Ph.UID = P.UID%s WHERE Ph.UID IS NOT NULL%s 
+	GROUP BY Ph.UID, Ph.Name, Ph.CashBalance, Ph.CreatedTime%s
 )
 SELECT 
-	(SELECT COUNT(*) FROM Data) AS Count, 
+	%s AS Count, 
 	@Row AS Row, 
 	@Page AS Page, 
 	(SELECT ARRAY(
-		SELECT STRUCT(UID, Name, CashBalance, CreatedTime) 
-		FROM Data%s LIMIT @Row OFFSET @Offset
+		SELECT STRUCT(UID, Name, CashBalance, CreatedTime, ProductCount) 
+		FROM Data WHERE UID IS NOT NULL%s%s LIMIT @Limit OFFSET @Offset
 	)) AS Pharmacies
-`, pharmacyTable, productTable, conditionSyntax, withTimeOrder(orderEnum),
+`, pharmacyTable, productTable, joinSyntax, conditionSyntax, havingSyntax, withCount(cursor, "Data"), keysetSyntax, orderSyntax,
 		),
 		Params: args,
 	}
 	iter := st.session.Single().Query(ctx, stmt)
 	defer iter.Stop()
 
-	resp := &entity.PharmacyList{}
+	resp := &entity.PharmacyProductCountList{}
 	if err := spannertool.GetIteratorFirstRow(iter, resp); err != nil {
 		return nil, err
 	}
+	var hasNext bool
+	if resp.Pharmacies, hasNext = trimPage(resp.Pharmacies, row); hasNext {
+		last := resp.Pharmacies[len(resp.Pharmacies)-1]
+		resp.NextPageToken = storage.PageToken{
+			Sort:         storage.SortKey(sorts),
+			PharmacyName: last.Name,
+			CashBalance:  last.CashBalance,
+			ProductCount: last.ProductCount,
+			CreatedTime:  last.CreatedTime,
+			UID:          last.UID,
+		}.Encode()
+	}
+	return resp, nil
+}
+
+func (st Pharmacy) ListProductPriceComparison(ctx context.Context, row, page uint64, name string, specifyTimestamp int64, condition storage.PharmacyListCondition) (*entity.ProductPriceComparisonList, error) {
+	if err := spannertool.ValidListArgument(row, page); err != nil {
+		return nil, err
+	}
+
+	conditionSyntax, args, err := toPharmacyClauses(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	specifyDay, specifyHour, err := specifyDayHour(specifyTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	args["SpecifyDay"] = specifyDay
+	args["SpecifyTime"] = specifyHour
+	args["Name"] = fmt.Sprintf(`(?i)\Q%s\E`, name)
+	args["Row"] = int64(row)
+	args["Page"] = int64(page)
+	args["Offset"] = int64((page - 1) * row)
+
+	// The offers are grouped by their catalogue mask, named after it. Products missing a MaskID fall back to
+	// their lowercased name.
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(
+			`
+WITH MaskOffers AS (
+    SELECT IFNULL(TO_HEX(P.MaskID), LOWER(P.Name)) AS MaskKey, P.MaskID AS MaskID, 
+		Ph.UID AS UID, Ph.Name AS PharmacyName, ProductID, P.Name AS ProductName, Price, IFNULL(PackSize, 0) AS PackSize, 
+		%s AS IsOpen 
+	FROM %s AS Ph JOIN %s AS P on Ph.UID = P.UID 
+	WHERE REGEXP_CONTAINS(P.Name, @Name)%s
+), Masks AS (
+    SELECT O.MaskKey AS MaskKey, IFNULL(MIN(M.Name), MIN(LOWER(O.ProductName))) AS MaskName 
+	FROM MaskOffers AS O LEFT JOIN %s AS M on O.MaskID = M.MaskID 
+	GROUP BY O.MaskKey
+), PageMasks AS (
+    SELECT MaskKey, MaskName FROM Masks ORDER BY MaskName ASC, MaskKey ASC LIMIT @Row OFFSET @Offset
+)
+SELECT 
+	(SELECT COUNT(*) FROM Masks) AS Count, 
+	@Row AS Row, 
+	@Page AS Page, 
+	(SELECT ARRAY(
+		SELECT STRUCT(PM.MaskKey AS MaskKey, PM.MaskName AS MaskName, O.UID AS UID, O.PharmacyName AS PharmacyName, 
+			O.ProductID AS ProductID, O.ProductName AS ProductName, O.Price AS Price, O.PackSize AS PackSize, O.IsOpen AS IsOpen) 
+		FROM PageMasks AS PM JOIN MaskOffers AS O on PM.MaskKey = O.MaskKey 
+		ORDER BY PM.MaskName ASC, PM.MaskKey ASC, O.Price ASC, O.PharmacyName ASC
+	)) AS Offers
+`, openAtSyntax("Ph.UID", "SpecifyDay", "SpecifyTime"), pharmacyTable, productTable, conditionSyntax, maskTable,
+		),
+		Params: args,
+	}
+	iter := st.session.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	result := &priceComparisonPage{}
+	if err := spannertool.GetIteratorFirstRow(iter, result); err != nil {
+		return nil, err
+	}
+	resp := &entity.ProductPriceComparisonList{
+		CommonListResponse: toolboxEntity.CommonListResponse{
+			Count: result.Count,
+			Row:   result.Row,
+			Page:  result.Page,
+		},
+		Products: []*entity.ProductPriceComparison{},
+	}
+	var group *entity.ProductPriceComparison
+	for i, item := range result.Offers {
+		if i == 0 || item.MaskKey != result.Offers[i-1].MaskKey {
+			group = &entity.ProductPriceComparison{Name: item.MaskName}
+			resp.Products = append(resp.Products, group)
+		}
+		group.Offers = append(group.Offers, &entity.PharmacyPriceOffer{
+			UID:          item.UID,
+			PharmacyName: item.PharmacyName,
+			ProductID:    item.ProductID,
+			ProductName:  item.ProductName,
+			Price:        item.Price,
+			PackSize:     item.PackSize,
+			IsOpen:       item.IsOpen,
+		})
+	}
+	return resp, nil
+}
+
+// priceComparisonPage is the row of ListProductPriceComparison, the offers of the masks of the page in the reply order.
+type priceComparisonPage struct {
+	Count  int64                   `spanner:"Count"`
+	Row    int64                   `spanner:"Row"`
+	Page   int64                   `spanner:"Page"`
+	Offers []*priceComparisonOffer `spanner:"Offers"`
+}
+
+type priceComparisonOffer struct {
+	MaskKey      string  `spanner:"MaskKey"`
+	MaskName     string  `spanner:"MaskName"`
+	UID          []byte  `spanner:"UID"`
+	PharmacyName string  `spanner:"PharmacyName"`
+	ProductID    []byte  `spanner:"ProductID"`
+	ProductName  string  `spanner:"ProductName"`
+	Price        float64 `spanner:"Price"`
+	PackSize     int64   `spanner:"PackSize"`
+	IsOpen       bool    `spanner:"IsOpen"`
+}
+
+func (st Pharmacy) Upsert(ctx context.Context, input entity.Pharmacy) error {
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return upsert(ctx, txn, pharmacyTable, []string{"UID"}, input)
+	})
+	return err
+}
+
+func (st Pharmacy) ListByIDs(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.Pharmacy, error) {
+	var resp []*entity.Pharmacy
+	if len(pharmacyIDs) == 0 {
+		return resp, nil
+	}
+	if err := readKeys(ctx, st.session.Single(), pharmacyTable, keysOf(pharmacyIDs), &resp); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
@@ -48,3 +48,35 @@ func (st PharmacyInfo) Create(ctx context.Context, input entity.PharmacyInfo) er
 	}
 	return err
 }
+
+func (st PharmacyInfo) Replace(ctx context.Context, pharmacyID []byte, inputs []entity.PharmacyInfo) error {
+	if err := validator.New().Var(pharmacyID, `required`); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	mut := []*spannerSyntax.Mutation{
+		spannerSyntax.Delete(pharmacyInfoTable, spannerSyntax.Key{pharmacyID}.AsPrefix()),
+	}
+	for _, input := range inputs {
+		input.UID = pharmacyID
+		prepareMut, err := spannerSyntax.InsertStruct(pharmacyInfoTable, input)
+		if err != nil {
+			return err
+		}
+		mut = append(mut, prepareMut)
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return txn.BufferWrite(mut)
+	})
+	return err
+}
+
+func (st PharmacyInfo) ListByPharmacies(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.PharmacyInfo, error) {
+	var resp []*entity.PharmacyInfo
+	if len(pharmacyIDs) == 0 {
+		return resp, nil
+	}
+	if err := readKeys(ctx, st.session.Single(), pharmacyInfoTable, childKeysOf(pharmacyIDs), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -1,6 +1,7 @@
 package spanner
 
 import (
+	spannerSyntax "cloud.google.com/go/spanner"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
@@ -89,6 +90,36 @@ func (suite *PharmacyInfoSuite) TestCreateMethod() {
 	}
 }
 
+func (suite *PharmacyInfoSuite) TestReplaceMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(NewPharmacy(suite.logger, session).Create(suite.ctx, entity.Pharmacy{
+		UID:         uid[:],
+		Name:        "TesterReplacePharmacyInfo",
+		CashBalance: 10.5,
+	}))
+	countRows := func() int {
+		var count int
+		iter := session.Single().Read(suite.ctx, pharmacyInfoTable, spannerSyntax.Key{uid[:]}.AsPrefix(), []string{"Day"})
+		suite.NoError(iter.Do(func(r *spannerSyntax.Row) error {
+			count++
+			return nil
+		}))
+		return count
+	}
+
+	suite.NoError(suite.client.Replace(suite.ctx, uid[:], []entity.PharmacyInfo{
+		{Day: 1, OpenHour: 8, CloseHour: 12},
+		{Day: 2, OpenHour: 8, CloseHour: 12},
+	}))
+	suite.Equal(2, countRows())
+	suite.NoError(suite.client.Replace(suite.ctx, uid[:], []entity.PharmacyInfo{
+		{Day: 3, OpenHour: 14, CloseHour: 18},
+	}))
+	suite.Equal(1, countRows())
+	suite.ErrorIs(suite.client.Replace(suite.ctx, nil, nil), errorhandler.ErrInvalidArguments)
+}
+
 func TestPharmacyInfoSuite(t *testing.T) {
 	suite.Run(t, new(PharmacyInfoSuite))
 }
@@ -1,6 +1,7 @@
 package spanner
 
 import (
+	spannerSyntax "cloud.google.com/go/spanner"
 	"context"
 	"github.com/google/uuid"
 	"github.com/justdomepaul/toolbox/errorhandler"
@@ -75,6 +76,28 @@ func (suite *PharmacySuite) TestCreateMethod() {
 	}
 }
 
+func (suite *PharmacySuite) TestUpsertMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	readCashBalance := func() float64 {
+		row, err := session.Single().ReadRow(suite.ctx, pharmacyTable, spannerSyntax.Key{uid[:]}, []string{"CashBalance"})
+		suite.NoError(err)
+		var cashBalance float64
+		suite.NoError(row.Column(0, &cashBalance))
+		return cashBalance
+	}
+
+	for _, cashBalance := range []float64{10.5, 10.5, 20} {
+		suite.NoError(suite.client.Upsert(suite.ctx, entity.Pharmacy{
+			UID:         uid[:],
+			Name:        "TesterUpsertPharmacy",
+			CashBalance: cashBalance,
+		}))
+		suite.Equal(cashBalance, readCashBalance())
+	}
+	suite.ErrorIs(suite.client.Upsert(suite.ctx, entity.Pharmacy{}), errorhandler.ErrInvalidArguments)
+}
+
 func (suite *PharmacySuite) TestListSpecifyTimeMethod() {
 	type want struct {
 		Error error
@@ -108,7 +131,7 @@ func (suite *PharmacySuite) TestListSpecifyTimeMethod() {
 	}
 
 	for _, tc := range testCases {
-		result, err := suite.client.ListSpecifyTime(suite.ctx, 10, 1, tc.SpecifyTimestamp, storage.PharmacyNameASC)
+		result, err := suite.client.ListSpecifyTime(suite.ctx, 10, 1, tc.SpecifyTimestamp, storage.PharmacyNameASC, storage.PharmacyListCondition{}, storage.Cursor{})
 		suite.NoError(err)
 		suite.T().Log(result.Pharmacies)
 		suite.Equal(uid[:], result.Pharmacies[0].UID)
@@ -173,12 +196,73 @@ func (suite *PharmacySuite) TestListByProductPriceRangeMethod() {
 	for _, tc := range testCases {
 		condition := storage.PharmacyListCondition{}
 		condition = storage.WithPharmacyProductPriceRange(condition, tc.Min, tc.Max)
-		result, err := suite.client.ListByProductPriceRange(suite.ctx, 10, 1, storage.CreatedTimeASC, condition)
+		result, err := suite.client.ListByProductPriceRange(suite.ctx, 10, 1, storage.CreatedTimeASC, condition, storage.Cursor{})
 		suite.NoError(err)
 		suite.Equal(uid[:], result.Pharmacies[0].UID)
 		suite.Equal("TesterListSpecifyTime", result.Pharmacies[0].Name)
 		suite.Equal(float64(100), result.Pharmacies[0].CashBalance)
+		suite.Equal(int64(2), result.Pharmacies[0].ProductCount)
+	}
+
+	condition := storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductPriceRange(condition, 20, 50)
+	condition = storage.WithPharmacyProductCount(condition, storage.CountGreaterThan, 2)
+	result, err := suite.client.ListByProductPriceRange(suite.ctx, 10, 1, storage.CreatedTimeASC, condition, storage.Cursor{})
+	suite.NoError(err)
+	for _, item := range result.Pharmacies {
+		suite.NotEqual(uid[:], item.UID)
+	}
+
+	condition = storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductPriceRange(condition, 20, 50)
+	condition = storage.WithPharmacyProductCount(condition, storage.CountEqual, 2)
+	result, err = suite.client.ListByProductPriceRange(suite.ctx, 10, 1, storage.CreatedTimeASC, condition, storage.Cursor{})
+	suite.NoError(err)
+	var found bool
+	for _, item := range result.Pharmacies {
+		found = found || string(item.UID) == string(uid[:])
+	}
+	suite.True(found)
+
+	emptyUID, err := uuid.NewUUID()
+	suite.NoError(err)
+	expensiveID, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.client.Create(suite.ctx, entity.Pharmacy{
+		UID:         emptyUID[:],
+		Name:        "TesterByRangeNoneInRange",
+		CashBalance: 100,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       emptyUID[:],
+		ProductID: expensiveID[:],
+		Name:      "TestByRangeExpensive",
+		Price:     90,
+	}))
+	condition = storage.PharmacyListCondition{}
+	condition = storage.WithPharmacyProductPriceRange(condition, 20, 50)
+	result, err = suite.client.ListByProductPriceRange(suite.ctx, 100, 1, storage.CreatedTimeASC, condition, storage.Cursor{})
+	suite.NoError(err)
+	for _, item := range result.Pharmacies {
+		suite.NotEqual(emptyUID[:], item.UID)
 	}
+
+	condition = storage.WithPharmacyProductCount(condition, storage.CountLessThan, 1)
+	result, err = suite.client.ListByProductPriceRange(suite.ctx, 100, 1, storage.CreatedTimeASC, condition, storage.Cursor{})
+	suite.NoError(err)
+	found = false
+	for _, item := range result.Pharmacies {
+		suite.NotEqual(uid[:], item.UID)
+		if string(item.UID) == string(emptyUID[:]) {
+			found = true
+			suite.Equal(int64(0), item.ProductCount)
+		}
+	}
+	suite.True(found)
+
+	condition = storage.WithPharmacyProductCount(storage.PharmacyListCondition{}, storage.CountOperator(99), 2)
+	_, err = suite.client.ListByProductPriceRange(suite.ctx, 10, 1, storage.CreatedTimeASC, condition, storage.Cursor{})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
 }
 
 func (suite *PharmacySuite) TestListPharmacyMixProductMethod() {
@@ -230,12 +314,136 @@ func (suite *PharmacySuite) TestListPharmacyMixProductMethod() {
 	}
 
 	for _, tc := range testCases {
-		result, err := suite.client.ListPharmacyMixProduct(suite.ctx, 10, 1, tc.Name, storage.PharmacyProduct)
+		result, err := suite.client.ListPharmacyMixProduct(suite.ctx, 10, 1, tc.Name, storage.PharmacyProduct, storage.PharmacyListCondition{}, storage.Cursor{})
 		suite.NoError(err)
 		suite.T().Log(result.PharmacyProducts)
 	}
 }
 
+func (suite *PharmacySuite) TestListPharmacyMixProductByRelevanceMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	productID, err := uuid.NewUUID()
+	suite.NoError(err)
+	productID2, err := uuid.NewUUID()
+	suite.NoError(err)
+	productID3, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.client.Create(suite.ctx, entity.Pharmacy{
+		UID:         uid[:],
+		Name:        "TesterRelevancePharmacy",
+		CashBalance: 100,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       uid[:],
+		ProductID: productID[:],
+		Name:      "Relevanceword Cotton",
+		Price:     20,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       uid[:],
+		ProductID: productID2[:],
+		Name:      "Cotton Relevanceword",
+		Price:     30,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       uid[:],
+		ProductID: productID3[:],
+		Name:      "Cotton Relevancewrod",
+		Price:     70,
+	}))
+
+	result, err := suite.client.ListPharmacyMixProduct(suite.ctx, 10, 1, "relevanceword", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{})
+	suite.NoError(err)
+	suite.Equal(int64(3), result.Count)
+	suite.Equal(productID[:], result.PharmacyProducts[0].ProductID)
+	suite.Equal(productID2[:], result.PharmacyProducts[1].ProductID)
+	suite.Equal(productID3[:], result.PharmacyProducts[2].ProductID)
+	suite.Greater(result.PharmacyProducts[0].Score, result.PharmacyProducts[1].Score)
+	suite.Greater(result.PharmacyProducts[1].Score, result.PharmacyProducts[2].Score)
+
+	result, err = suite.client.ListPharmacyMixProduct(suite.ctx, 1, 2, "relevanceword", storage.Relevance, storage.PharmacyListCondition{}, storage.Cursor{})
+	suite.NoError(err)
+	suite.Equal(int64(3), result.Count)
+	suite.Len(result.PharmacyProducts, 1)
+	suite.Equal(productID2[:], result.PharmacyProducts[0].ProductID)
+}
+
+func (suite *PharmacySuite) TestListProductPriceComparisonMethod() {
+	openUID, err := uuid.NewUUID()
+	suite.NoError(err)
+	closedUID, err := uuid.NewUUID()
+	suite.NoError(err)
+	openProductID, err := uuid.NewUUID()
+	suite.NoError(err)
+	closedProductID, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.client.Create(suite.ctx, entity.Pharmacy{
+		UID:         openUID[:],
+		Name:        "TesterCompareOpen",
+		CashBalance: 100,
+	}))
+	suite.NoError(suite.client.Create(suite.ctx, entity.Pharmacy{
+		UID:         closedUID[:],
+		Name:        "TesterCompareClosed",
+		CashBalance: 100,
+	}))
+	suite.NoError(suite.pharmacyInfoClient.Create(suite.ctx, entity.PharmacyInfo{
+		UID:       openUID[:],
+		Day:       3,
+		OpenHour:  20,
+		CloseHour: 26,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       openUID[:],
+		ProductID: openProductID[:],
+		Name:      "CompareSalt (green) (10 per pack)",
+		Price:     30,
+	}))
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       closedUID[:],
+		ProductID: closedProductID[:],
+		Name:      "compareSalt (Green)  (10 per pack)",
+		Price:     20,
+	}))
+	plusProductID, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.productClient.Create(suite.ctx, entity.Product{
+		UID:       closedUID[:],
+		ProductID: plusProductID[:],
+		Name:      "CompareSalt Plus (green) (10 per pack)",
+		Price:     40,
+	}))
+
+	specifyTimestamp := time.Date(2022, 10, 05, 15, 12, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+
+	result, err := suite.client.ListProductPriceComparison(suite.ctx, 10, 1, "comparesalt", specifyTimestamp, storage.PharmacyListCondition{})
+	suite.NoError(err)
+	suite.Equal(int64(2), result.Count)
+	suite.Len(result.Products, 2)
+	suite.Equal("comparesalt (green) (10 per pack)", result.Products[0].Name)
+	suite.Len(result.Products[0].Offers, 2)
+	suite.Equal(closedUID[:], result.Products[0].Offers[0].UID)
+	suite.False(result.Products[0].Offers[0].IsOpen)
+	suite.Equal(openUID[:], result.Products[0].Offers[1].UID)
+	suite.True(result.Products[0].Offers[1].IsOpen)
+	suite.Equal(int64(10), result.Products[0].Offers[1].PackSize)
+
+	result, err = suite.client.ListProductPriceComparison(suite.ctx, 1, 2, "comparesalt", specifyTimestamp, storage.PharmacyListCondition{})
+	suite.NoError(err)
+	suite.Equal(int64(2), result.Count)
+	suite.Len(result.Products, 1)
+	suite.Equal("comparesalt plus (green) (10 per pack)", result.Products[0].Name)
+	suite.Len(result.Products[0].Offers, 1)
+	suite.Equal(plusProductID[:], result.Products[0].Offers[0].ProductID)
+
+	condition := storage.WithPharmacyOpenAt(storage.PharmacyListCondition{}, specifyTimestamp)
+	result, err = suite.client.ListProductPriceComparison(suite.ctx, 10, 1, "comparesalt", specifyTimestamp, condition)
+	suite.NoError(err)
+	suite.Len(result.Products[0].Offers, 1)
+	suite.Equal(openUID[:], result.Products[0].Offers[0].UID)
+}
+
 func TestPharmacySuite(t *testing.T) {
 	suite.Run(t, new(PharmacySuite))
 }
@@ -4,7 +4,6 @@ import (
 	spannerSyntax "cloud.google.com/go/spanner"
 	"context"
 	"fmt"
-	"github.com/cockroachdb/errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/justdomepaul/toolbox/database/spanner"
 	"github.com/justdomepaul/toolbox/errorhandler"
@@ -23,6 +22,27 @@ var (
 
 var productClauseFn = map[storage.ProductEnumType]func(source storage.ProductListCondition, condition *string, args map[string]interface{}) error{
 	storage.ProductSpecifyPharmacy: withProductSpecifyPharmacy,
+	storage.ProductBrand:           withProductBrand,
+	storage.ProductColor:           withProductColor,
+	storage.ProductPackSize:        withProductPackSize,
+	storage.ProductFilter:          withProductFilter,
+}
+
+// productFilterColumn maps the filterable fields of the product list to their column.
+var productFilterColumn = map[storage.ListField]string{
+	storage.FieldProductName: "Name",
+	storage.FieldPrice:       "Price",
+	storage.FieldBrand:       "IFNULL(Brand, '')",
+	storage.FieldColor:       "IFNULL(Color, '')",
+	storage.FieldPackSize:    "IFNULL(PackSize, 0)",
+	storage.FieldCreatedTime: "CreatedTime",
+}
+
+// productSortColumn maps the sortable fields of the product list to their column.
+var productSortColumn = map[storage.ListField]string{
+	storage.FieldProductName: "Name",
+	storage.FieldPrice:       "Price",
+	storage.FieldCreatedTime: "CreatedTime",
 }
 
 func withProductSpecifyPharmacy(source storage.ProductListCondition, condition *string, args map[string]interface{}) error {
@@ -34,6 +54,28 @@ func withProductSpecifyPharmacy(source storage.ProductListCondition, condition *
 	return nil
 }
 
+func withProductBrand(source storage.ProductListCondition, condition *string, args map[string]interface{}) error {
+	*condition = stringtool.StringJoin(*condition, ` AND LOWER(Brand) = LOWER(@Brand)`)
+	args["Brand"] = source.Brand
+	return nil
+}
+
+func withProductColor(source storage.ProductListCondition, condition *string, args map[string]interface{}) error {
+	*condition = stringtool.StringJoin(*condition, ` AND LOWER(Color) = LOWER(@Color)`)
+	args["Color"] = source.Color
+	return nil
+}
+
+func withProductPackSize(source storage.ProductListCondition, condition *string, args map[string]interface{}) error {
+	*condition = stringtool.StringJoin(*condition, ` AND PackSize = @PackSize`)
+	args["PackSize"] = source.PackSize
+	return nil
+}
+
+func withProductFilter(source storage.ProductListCondition, condition *string, args map[string]interface{}) error {
+	return withFilters(source.Filters, productFilterColumn, nil, condition, args)
+}
+
 func toProductClauses(source storage.ProductListCondition) (conditionSyntax string, args map[string]interface{}, err error) {
 	args = map[string]interface{}{}
 	for _, op := range source.Fields {
@@ -58,10 +100,17 @@ type Product struct {
 }
 
 func (st Product) Create(ctx context.Context, input entity.Product) error {
+	canonical := len(input.MaskID) == 0
+	input = storage.WithCanonicalMask(storage.WithMaskAttribute(input))
 	if err := validator.New().Struct(&input); err != nil {
 		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
 	}
 	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		if canonical {
+			if err := createMaskIfMissing(ctx, txn, storage.NewCanonicalMask(input.Name)); err != nil {
+				return err
+			}
+		}
 		columns, placeholder, params := spannertool.FetchSpannerTagValue(input, false, DBCreatedTime)
 		stmt := spannerSyntax.Statement{
 			SQL:    fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, productTable, columns, placeholder),
@@ -76,6 +125,23 @@ func (st Product) Create(ctx context.Context, input entity.Product) error {
 	return err
 }
 
+func (st Product) Upsert(ctx context.Context, input entity.Product) error {
+	canonical := len(input.MaskID) == 0
+	input = storage.WithCanonicalMask(storage.WithMaskAttribute(input))
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		if canonical {
+			if err := createMaskIfMissing(ctx, txn, storage.NewCanonicalMask(input.Name)); err != nil {
+				return err
+			}
+		}
+		return upsert(ctx, txn, productTable, []string{"UID", "ProductID"}, input)
+	})
+	return err
+}
+
 func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []byte, quantity int) error {
 	input := struct {
 		UserID     []byte `json:"user_id,omitempty" validate:"required"`
@@ -94,6 +160,9 @@ func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []
 	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
 		getUserBalance := func(key spannerSyntax.Key) (float64, error) {
 			row, err := txn.ReadRow(ctx, userTable, key, []string{"CashBalance"})
+			if spannerSyntax.ErrCode(err) == codes.NotFound {
+				return 0, storage.ErrUserNotFound
+			}
 			if err != nil {
 				return 0, err
 			}
@@ -105,6 +174,9 @@ func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []
 		}
 		getPharmacyBalance := func(key spannerSyntax.Key) (float64, error) {
 			row, err := txn.ReadRow(ctx, pharmacyTable, key, []string{"CashBalance"})
+			if spannerSyntax.ErrCode(err) == codes.NotFound {
+				return 0, storage.ErrPharmacyNotFound
+			}
 			if err != nil {
 				return 0, err
 			}
@@ -116,6 +188,9 @@ func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []
 		}
 		getProductPrice := func(key spannerSyntax.Key) (float64, error) {
 			row, err := txn.ReadRow(ctx, productTable, key, []string{"Price"})
+			if spannerSyntax.ErrCode(err) == codes.NotFound {
+				return 0, storage.ErrProductNotFound
+			}
 			if err != nil {
 				return 0, err
 			}
@@ -145,7 +220,7 @@ func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []
 		}
 		upgradeUserCashBalance := userCashBalance - (productPrice * float64(quantity))
 		if upgradeUserCashBalance < 0 {
-			return errors.New("user CashBalance not enough")
+			return storage.ErrInsufficientBalance
 		}
 		mut = append(mut, spannerSyntax.Update(userTable, userColumns, []interface{}{userID, upgradeUserCashBalance}))
 		mut = append(mut, spannerSyntax.Update(
@@ -163,7 +238,7 @@ func (st Product) Purchase(ctx context.Context, userID, pharmacyID, productID []
 	return err
 }
 
-func (st Product) List(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum, condition storage.ProductListCondition) (*entity.ProductList, error) {
+func (st Product) List(ctx context.Context, row, page uint64, orderEnum storage.OrderListEnum, condition storage.ProductListCondition, cursor storage.Cursor) (*entity.ProductList, error) {
 	if err := spannertool.ValidListArgument(row, page); err != nil {
 		return nil, err
 	}
@@ -173,22 +248,29 @@ func (st Product) List(ctx context.Context, row, page uint64, orderEnum storage.
 		return nil, err
 	}
 
-	args["Row"] = int64(row)
-	args["Offset"] = int64((page - 1) * row)
-	args["Page"] = int64(page)
+	sorts, err := listSorts(orderEnum, condition.Sorts)
+	if err != nil {
+		return nil, err
+	}
+	keysetSyntax := ""
+	orderSyntax, err := withKeyset(sorts, productSortColumn, []string{"UID", "ProductID"}, cursor, &keysetSyntax, args)
+	if err != nil {
+		return nil, err
+	}
+	withPageArgs(row, page, cursor, args)
 
 	stmt := spannerSyntax.Statement{
 		SQL: fmt.Sprintf(
 			`
 SELECT 
-	(SELECT COUNT(*) FROM %s WHERE UID IS NOT NULL%s) AS Count, 
+	%s AS Count, 
 	@Row AS Row, 
 	@Page AS Page, 
 	(SELECT ARRAY(
-		SELECT STRUCT(UID, ProductID, Name, Price, CreatedTime) 
-		FROM %s WHERE UID IS NOT NULL%s%s LIMIT @Row OFFSET @Offset
+		SELECT STRUCT(UID, ProductID, Name, Price, IFNULL(Brand, '') AS Brand, IFNULL(Color, '') AS Color, IFNULL(PackSize, 0) AS PackSize, MaskID, CreatedTime) 
+		FROM %s WHERE UID IS NOT NULL%s%s%s LIMIT @Limit OFFSET @Offset
 	)) AS Products
-`, productTable, conditionSyntax, productTable, conditionSyntax, withTimeOrder(orderEnum),
+`, withCount(cursor, fmt.Sprintf("%s WHERE UID IS NOT NULL%s", productTable, conditionSyntax)), productTable, conditionSyntax, keysetSyntax, orderSyntax,
 		),
 		Params: args,
 	}
@@ -199,5 +281,56 @@ SELECT
 	if err := spannertool.GetIteratorFirstRow(iter, resp); err != nil {
 		return nil, err
 	}
+	var hasNext bool
+	if resp.Products, hasNext = trimPage(resp.Products, row); hasNext {
+		last := resp.Products[len(resp.Products)-1]
+		resp.NextPageToken = storage.PageToken{
+			Sort:        storage.SortKey(sorts),
+			ProductName: last.Name,
+			Price:       last.Price,
+			CreatedTime: last.CreatedTime,
+			UID:         last.UID,
+			ProductID:   last.ProductID,
+		}.Encode()
+	}
+	return resp, nil
+}
+
+func (st Product) ListByPharmacies(ctx context.Context, pharmacyIDs [][]byte) ([]*entity.Product, error) {
+	if len(pharmacyIDs) == 0 {
+		return nil, nil
+	}
+	return st.read(ctx, childKeysOf(pharmacyIDs))
+}
+
+func (st Product) ListByKeys(ctx context.Context, keys []storage.ProductKey) ([]*entity.Product, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return st.read(ctx, productKeysOf(keys))
+}
+
+// read reads the products within keys, the Brand, Color and PackSize left NULL by the products created before them read as empty.
+func (st Product) read(ctx context.Context, keys spannerSyntax.KeySet) ([]*entity.Product, error) {
+	var resp []*entity.Product
+	err := st.session.Single().Read(ctx, productTable, keys,
+		[]string{"UID", "ProductID", "Name", "Price", "Brand", "Color", "PackSize", "MaskID", "CreatedTime"}).Do(func(r *spannerSyntax.Row) error {
+		var (
+			brand, color spannerSyntax.NullString
+			packSize     spannerSyntax.NullInt64
+		)
+		item := &entity.Product{}
+		if err := r.Columns(&item.UID, &item.ProductID, &item.Name, &item.Price, &brand, &color, &packSize, &item.MaskID, &item.CreatedTime); err != nil {
+			return err
+		}
+		item.Brand = brand.StringVal
+		item.Color = color.StringVal
+		item.PackSize = packSize.Int64
+		resp = append(resp, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
@@ -96,10 +96,12 @@ func (suite *ProductSuite) TestCreateMethod() {
 
 func (suite *ProductSuite) TestPurchaseMethod() {
 	type want struct {
-		Error bool
+		Error error
 	}
 	productID, err := uuid.NewUUID()
 	suite.NoError(err)
+	missingProductID, err := uuid.NewUUID()
+	suite.NoError(err)
 	suite.NoError(suite.client.Create(suite.ctx, entity.Product{
 		UID:       suite.pharmacyID,
 		ProductID: productID[:],
@@ -124,16 +126,24 @@ func (suite *ProductSuite) TestPurchaseMethod() {
 			ProductID: productID[:],
 			Quantity:  100,
 			Want: want{
-				Error: true,
+				Error: storage.ErrInsufficientBalance,
+			},
+		},
+		{
+			Label:     "PurchaseUnknownProductShouldResponseProductNotFound",
+			ProductID: missingProductID[:],
+			Quantity:  1,
+			Want: want{
+				Error: storage.ErrProductNotFound,
 			},
 		},
 	}
 
 	for _, tc := range testCases {
-		if tc.Want.Error {
-			suite.Error(suite.client.Purchase(suite.ctx, suite.userID, suite.pharmacyID, tc.ProductID, tc.Quantity))
+		if tc.Want.Error != nil {
+			suite.ErrorIs(suite.client.Purchase(suite.ctx, suite.userID, suite.pharmacyID, tc.ProductID, tc.Quantity), tc.Want.Error, tc.Label)
 		} else {
-			suite.NoError(suite.client.Purchase(suite.ctx, suite.userID, suite.pharmacyID, tc.ProductID, tc.Quantity))
+			suite.NoError(suite.client.Purchase(suite.ctx, suite.userID, suite.pharmacyID, tc.ProductID, tc.Quantity), tc.Label)
 		}
 	}
 }
@@ -196,7 +206,7 @@ func (suite *ProductSuite) TestListMethod() {
 	for _, tc := range testCases {
 		condition := storage.ProductListCondition{}
 		condition = storage.WithProductSpecifyPharmacy(condition, tc.SpecifyPharmacyID)
-		result, err := suite.client.List(suite.ctx, 10, 1, tc.Sorted, condition)
+		result, err := suite.client.List(suite.ctx, 10, 1, tc.Sorted, condition, storage.Cursor{})
 		suite.NoError(err)
 		suite.Equal(uid[:], result.Products[0].UID)
 		suite.Equal(productID[:], result.Products[0].ProductID)
@@ -205,6 +215,95 @@ func (suite *ProductSuite) TestListMethod() {
 	}
 }
 
+func (suite *ProductSuite) TestListPageTokenMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.pharmacyClient.Create(suite.ctx, entity.Pharmacy{
+		UID:         uid[:],
+		Name:        "TesterListPageToken",
+		CashBalance: 100,
+	}))
+	for _, name := range []string{"TesterPageTokenC", "TesterPageTokenA", "TesterPageTokenB"} {
+		productID, err := uuid.NewUUID()
+		suite.NoError(err)
+		suite.NoError(suite.client.Create(suite.ctx, entity.Product{
+			UID:       uid[:],
+			ProductID: productID[:],
+			Name:      name,
+			Price:     10,
+		}))
+	}
+	condition := storage.WithProductSpecifyPharmacy(storage.ProductListCondition{}, uid[:])
+
+	for _, order := range []storage.OrderListEnum{storage.ProductNameASC, storage.ProductPriceASC} {
+		first, err := suite.client.List(suite.ctx, 2, 1, order, condition, storage.Cursor{})
+		suite.NoError(err)
+		suite.Equal(int64(3), first.Count)
+		suite.Len(first.Products, 2)
+		suite.NotEmpty(first.NextPageToken)
+
+		next, err := suite.client.List(suite.ctx, 2, 1, order, condition, storage.Cursor{Token: first.NextPageToken, WithoutCount: true})
+		suite.NoError(err)
+		suite.Zero(next.Count)
+		suite.Len(next.Products, 1)
+		suite.Empty(next.NextPageToken)
+
+		seen := map[string]bool{}
+		for _, item := range append(first.Products, next.Products...) {
+			seen[item.Name] = true
+		}
+		suite.Len(seen, 3)
+	}
+
+	first, err := suite.client.List(suite.ctx, 2, 1, storage.ProductNameASC, condition, storage.Cursor{})
+	suite.NoError(err)
+	suite.Equal("TesterPageTokenA", first.Products[0].Name)
+	suite.Equal("TesterPageTokenB", first.Products[1].Name)
+
+	_, err = suite.client.List(suite.ctx, 2, 1, storage.ProductPriceASC, condition, storage.Cursor{Token: first.NextPageToken})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+	_, err = suite.client.List(suite.ctx, 2, 1, storage.ProductNameASC, condition, storage.Cursor{Token: "not a token"})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
+func (suite *ProductSuite) TestListFilterAndSortMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	suite.NoError(suite.pharmacyClient.Create(suite.ctx, entity.Pharmacy{
+		UID:         uid[:],
+		Name:        "TesterListFilterAndSort",
+		CashBalance: 100,
+	}))
+	for i, name := range []string{"TesterFilter Cheap", "TesterFilter Middle", "TesterFilter Dear"} {
+		productID, err := uuid.NewUUID()
+		suite.NoError(err)
+		suite.NoError(suite.client.Create(suite.ctx, entity.Product{
+			UID:       uid[:],
+			ProductID: productID[:],
+			Name:      name,
+			Price:     float64(10 * (i + 1)),
+		}))
+	}
+	condition := storage.WithProductSpecifyPharmacy(storage.ProductListCondition{}, uid[:])
+	condition = storage.WithProductFilter(condition, storage.Filter{Field: storage.FieldPrice, Operator: storage.FilterGreaterThan, Value: float64(10)})
+	condition = storage.WithProductSort(condition, storage.Sort{Field: storage.FieldPrice, Desc: true})
+
+	first, err := suite.client.List(suite.ctx, 1, 1, storage.ProductNameASC, condition, storage.Cursor{})
+	suite.NoError(err)
+	suite.Equal(int64(2), first.Count)
+	suite.Equal("TesterFilter Dear", first.Products[0].Name)
+
+	next, err := suite.client.List(suite.ctx, 1, 1, storage.ProductNameASC, condition, storage.Cursor{Token: first.NextPageToken})
+	suite.NoError(err)
+	suite.Len(next.Products, 1)
+	suite.Equal("TesterFilter Middle", next.Products[0].Name)
+	suite.Empty(next.NextPageToken)
+
+	condition = storage.WithProductSort(storage.ProductListCondition{}, storage.Sort{Field: storage.FieldProductCount})
+	_, err = suite.client.List(suite.ctx, 1, 1, storage.ProductNameASC, condition, storage.Cursor{})
+	suite.ErrorIs(err, errorhandler.ErrInvalidArguments)
+}
+
 func TestProductSuite(t *testing.T) {
 	suite.Run(t, new(ProductSuite))
 }
@@ -48,3 +48,24 @@ func (st PurchaseHistory) Create(ctx context.Context, input entity.PurchaseHisto
 	}
 	return err
 }
+
+func (st PurchaseHistory) Upsert(ctx context.Context, input entity.PurchaseHistory) error {
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return upsert(ctx, txn, purchaseHistoryTable, []string{"UID", "TransactionDate"}, input)
+	})
+	return err
+}
+
+func (st PurchaseHistory) ListByUsers(ctx context.Context, userIDs [][]byte) ([]*entity.PurchaseHistory, error) {
+	var resp []*entity.PurchaseHistory
+	if len(userIDs) == 0 {
+		return resp, nil
+	}
+	if err := readKeys(ctx, st.session.Single(), purchaseHistoryTable, childKeysOf(userIDs), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
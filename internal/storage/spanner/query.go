@@ -0,0 +1,48 @@
+package spanner
+
+import (
+	"fmt"
+	"github.com/justdomepaul/toolbox/errorhandler"
+	"github.com/justdomepaul/toolbox/stringtool"
+	"phantom_mask/internal/storage"
+)
+
+var filterOperatorSyntax = map[storage.FilterOperator]string{
+	storage.FilterEqual:        "=",
+	storage.FilterNotEqual:     "!=",
+	storage.FilterGreaterThan:  ">",
+	storage.FilterGreaterEqual: ">=",
+	storage.FilterLessThan:     "<",
+	storage.FilterLessEqual:    "<=",
+}
+
+// withFilters appends the filters whose field columns maps to condition, skipping the fields of skip,
+// and binds their values to args as Filter followed by the filter index.
+func withFilters(filters []storage.Filter, columns map[storage.ListField]string, skip map[storage.ListField]string, condition *string, args map[string]interface{}) error {
+	for i, filter := range filters {
+		if _, ok := skip[filter.Field]; ok {
+			continue
+		}
+		column, ok := columns[filter.Field]
+		if !ok {
+			return fmt.Errorf("%w: unsupported filter field %d", errorhandler.ErrInvalidArguments, filter.Field)
+		}
+		param := fmt.Sprintf("Filter%d", i)
+		args[param] = filter.Value
+		_, isString := filter.Value.(string)
+		if filter.Operator == storage.FilterContains && isString {
+			*condition = stringtool.StringJoin(*condition, fmt.Sprintf(` AND STRPOS(LOWER(%s), LOWER(@%s)) > 0`, column, param))
+			continue
+		}
+		operator, ok := filterOperatorSyntax[filter.Operator]
+		if !ok {
+			return fmt.Errorf("%w: unsupported filter operator %d", errorhandler.ErrInvalidArguments, filter.Operator)
+		}
+		if isString {
+			*condition = stringtool.StringJoin(*condition, fmt.Sprintf(` AND LOWER(%s) %s LOWER(@%s)`, column, operator, param))
+			continue
+		}
+		*condition = stringtool.StringJoin(*condition, fmt.Sprintf(` AND %s %s @%s`, column, operator, param))
+	}
+	return nil
+}
@@ -8,4 +8,5 @@ type Set struct {
 	Product         storage.IProduct
 	User            storage.IUser
 	PurchaseHistory storage.IPurchaseHistory
+	Mask            storage.IMask
 }
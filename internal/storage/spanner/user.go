@@ -3,6 +3,7 @@ package spanner
 import (
 	spannerSyntax "cloud.google.com/go/spanner"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/justdomepaul/toolbox/database/spanner"
@@ -11,11 +12,13 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
 	"time"
 )
 
 var (
-	userTable = "User"
+	userTable       = "User"
+	credentialTable = "Credential"
 )
 
 // NewUser method
@@ -107,3 +110,158 @@ SELECT
 	}
 	return resp, nil
 }
+
+func (st User) Upsert(ctx context.Context, input entity.User) error {
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return upsert(ctx, txn, userTable, []string{"UID"}, input)
+	})
+	return err
+}
+
+func (st User) ListByIDs(ctx context.Context, userIDs [][]byte) ([]*entity.User, error) {
+	var resp []*entity.User
+	if len(userIDs) == 0 {
+		return resp, nil
+	}
+	if err := readKeys(ctx, st.session.Single(), userTable, keysOf(userIDs), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Register creates the user, whose CashBalance may be zero, and its credential.
+func (st User) Register(ctx context.Context, user entity.User, credential entity.Credential) error {
+	if err := validator.New().StructExcept(&user, "CashBalance"); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	if err := validator.New().Struct(&credential); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		insert := func(table string, input interface{}) error {
+			columns, placeholder, params := spannertool.FetchSpannerTagValue(input, false, DBCreatedTime)
+			stmt := spannerSyntax.Statement{
+				SQL:    fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, columns, placeholder),
+				Params: params,
+			}
+			_, err := txn.Update(ctx, stmt)
+			return err
+		}
+		if err := insert(userTable, user); spannerSyntax.ErrCode(err) == codes.AlreadyExists {
+			return fmt.Errorf("%w: %s", errorhandler.ErrAlreadyExists, err.Error())
+		} else if err != nil {
+			return err
+		}
+		if err := insert(credentialTable, credential); spannerSyntax.ErrCode(err) == codes.AlreadyExists {
+			return fmt.Errorf("%w: %s", storage.ErrEmailTaken, credential.Email)
+		} else if err != nil {
+			return err
+		}
+		return nil
+	})
+	return err
+}
+
+func (st User) GetCredential(ctx context.Context, email string) (*entity.Credential, error) {
+	if err := validator.New().Var(email, `required`); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(`SELECT UID, Email, PasswordHash, CreatedTime FROM %s@{FORCE_INDEX=CredentialEmail} WHERE Email = @Email`,
+			credentialTable),
+		Params: map[string]interface{}{
+			"Email": email,
+		},
+	}
+	iter := st.session.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	resp := &entity.Credential{}
+	if err := spannertool.GetIteratorFirstRow(iter, resp); errors.Is(err, errorhandler.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", storage.ErrCredentialNotFound, email)
+	} else if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (st User) GetProfile(ctx context.Context, userID []byte) (*entity.Profile, error) {
+	if err := validator.New().Var(userID, `required`); err != nil {
+		return nil, fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	stmt := spannerSyntax.Statement{
+		SQL: fmt.Sprintf(`
+SELECT U.UID, U.Name, IFNULL(C.Email, "") AS Email, U.CashBalance, U.CreatedTime
+FROM %s AS U LEFT JOIN %s AS C ON U.UID = C.UID
+WHERE U.UID = @UID
+`, userTable, credentialTable),
+		Params: map[string]interface{}{
+			"UID": userID,
+		},
+	}
+	iter := st.session.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	resp := &entity.Profile{}
+	if err := spannertool.GetIteratorFirstRow(iter, resp); errors.Is(err, errorhandler.ErrNoRows) {
+		return nil, storage.ErrUserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (st User) UpdateName(ctx context.Context, userID []byte, name string) error {
+	input := struct {
+		UID  []byte `validate:"required,max=16"`
+		Name string `validate:"required"`
+	}{
+		UID:  userID,
+		Name: name,
+	}
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return spannertool.ExecuteAndCheckEffectRowOverZero(txn, ctx, spannerSyntax.Statement{
+			SQL: fmt.Sprintf(`UPDATE %s SET Name = @Name WHERE UID = @UID`, userTable),
+			Params: map[string]interface{}{
+				"UID":  input.UID,
+				"Name": input.Name,
+			},
+		})
+	})
+	if errors.Is(err, errorhandler.ErrUpdateNoEffect) {
+		return storage.ErrUserNotFound
+	}
+	return err
+}
+
+func (st User) UpdatePassword(ctx context.Context, userID []byte, passwordHash string) error {
+	input := struct {
+		UID          []byte `validate:"required,max=16"`
+		PasswordHash string `validate:"required"`
+	}{
+		UID:          userID,
+		PasswordHash: passwordHash,
+	}
+	if err := validator.New().Struct(&input); err != nil {
+		return fmt.Errorf("%w: %s", errorhandler.ErrInvalidArguments, err.Error())
+	}
+	_, err := st.session.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spannerSyntax.ReadWriteTransaction) error {
+		return spannertool.ExecuteAndCheckEffectRowOverZero(txn, ctx, spannerSyntax.Statement{
+			SQL: fmt.Sprintf(`UPDATE %s SET PasswordHash = @PasswordHash WHERE UID = @UID`, credentialTable),
+			Params: map[string]interface{}{
+				"UID":          input.UID,
+				"PasswordHash": input.PasswordHash,
+			},
+		})
+	})
+	if errors.Is(err, errorhandler.ErrUpdateNoEffect) {
+		return storage.ErrCredentialNotFound
+	}
+	return err
+}
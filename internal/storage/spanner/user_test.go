@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 	"phantom_mask/internal/entity"
+	"phantom_mask/internal/storage"
 	"testing"
 	"time"
 )
@@ -230,6 +231,91 @@ func (suite *UserSuite) TestGetTransactionTotalMethod() {
 	}
 }
 
+func (suite *UserSuite) TestRegisterMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	otherUID, err := uuid.NewUUID()
+	suite.NoError(err)
+	email := uid.String() + "@example.com"
+
+	suite.NoError(suite.client.Register(suite.ctx, entity.User{
+		UID:  uid[:],
+		Name: "TesterRegister",
+	}, entity.Credential{
+		UID:          uid[:],
+		Email:        email,
+		PasswordHash: "hash",
+	}))
+	suite.ErrorIs(suite.client.Register(suite.ctx, entity.User{
+		UID:  otherUID[:],
+		Name: "TesterRegisterAgain",
+	}, entity.Credential{
+		UID:          otherUID[:],
+		Email:        email,
+		PasswordHash: "hash",
+	}), storage.ErrEmailTaken)
+	users, err := suite.client.ListByIDs(suite.ctx, [][]byte{otherUID[:]})
+	suite.NoError(err)
+	suite.Empty(users)
+
+	credential, err := suite.client.GetCredential(suite.ctx, email)
+	suite.NoError(err)
+	suite.Equal(uid[:], credential.UID)
+	suite.Equal("hash", credential.PasswordHash)
+	_, err = suite.client.GetCredential(suite.ctx, "missing@example.com")
+	suite.ErrorIs(err, storage.ErrCredentialNotFound)
+
+	suite.NoError(suite.client.UpdatePassword(suite.ctx, uid[:], "new hash"))
+	credential, err = suite.client.GetCredential(suite.ctx, email)
+	suite.NoError(err)
+	suite.Equal("new hash", credential.PasswordHash)
+	suite.ErrorIs(suite.client.UpdatePassword(suite.ctx, otherUID[:], "new hash"), storage.ErrCredentialNotFound)
+}
+
+func (suite *UserSuite) TestProfileMethod() {
+	uid, err := uuid.NewUUID()
+	suite.NoError(err)
+	importedUID, err := uuid.NewUUID()
+	suite.NoError(err)
+	missingUID, err := uuid.NewUUID()
+	suite.NoError(err)
+	email := uid.String() + "@example.com"
+
+	suite.NoError(suite.client.Register(suite.ctx, entity.User{
+		UID:  uid[:],
+		Name: "TesterProfile",
+	}, entity.Credential{
+		UID:          uid[:],
+		Email:        email,
+		PasswordHash: "hash",
+	}))
+	suite.NoError(suite.client.Create(suite.ctx, entity.User{
+		UID:         importedUID[:],
+		Name:        "TesterImportedProfile",
+		CashBalance: 10.5,
+	}))
+
+	profile, err := suite.client.GetProfile(suite.ctx, uid[:])
+	suite.NoError(err)
+	suite.Equal("TesterProfile", profile.Name)
+	suite.Equal(email, profile.Email)
+	suite.Equal(float64(0), profile.CashBalance)
+
+	profile, err = suite.client.GetProfile(suite.ctx, importedUID[:])
+	suite.NoError(err)
+	suite.Empty(profile.Email)
+	suite.Equal(10.5, profile.CashBalance)
+
+	suite.NoError(suite.client.UpdateName(suite.ctx, uid[:], "TesterRenamed"))
+	profile, err = suite.client.GetProfile(suite.ctx, uid[:])
+	suite.NoError(err)
+	suite.Equal("TesterRenamed", profile.Name)
+
+	_, err = suite.client.GetProfile(suite.ctx, missingUID[:])
+	suite.ErrorIs(err, storage.ErrUserNotFound)
+	suite.ErrorIs(suite.client.UpdateName(suite.ctx, missingUID[:], "Nobody"), storage.ErrUserNotFound)
+}
+
 func TestUserSuite(t *testing.T) {
 	suite.Run(t, new(UserSuite))
 }
@@ -17,6 +17,27 @@ type UserListCondition struct {
 
 type IUser interface {
 	Create(ctx context.Context, input entity.User) error
+	// Upsert method
+	// inserts the user or updates the existing one with the same UID, keeping its CreatedTime
+	Upsert(ctx context.Context, input entity.User) error
 	ListTopTransactionAmount(ctx context.Context, topNumber, startTime, endTime int64) (*entity.TopTransactionAmountList, error)
 	GetTransactionTotal(ctx context.Context, startTime, endTime int64) (*entity.TransactionTotal, error)
+	// ListByIDs method
+	// reads the users of userIDs in a single read, the missing ones are left out
+	ListByIDs(ctx context.Context, userIDs [][]byte) ([]*entity.User, error)
+	// Register method
+	// creates the user along with its credential in a single transaction, ErrEmailTaken when the email has one already
+	Register(ctx context.Context, user entity.User, credential entity.Credential) error
+	// GetCredential method
+	// reads the credential of email, ErrCredentialNotFound when there is none
+	GetCredential(ctx context.Context, email string) (*entity.Credential, error)
+	// GetProfile method
+	// reads the user of userID along with its email, ErrUserNotFound when there is none
+	GetProfile(ctx context.Context, userID []byte) (*entity.Profile, error)
+	// UpdateName method
+	// renames the user of userID, ErrUserNotFound when there is none
+	UpdateName(ctx context.Context, userID []byte, name string) error
+	// UpdatePassword method
+	// replaces the password hash of the credential of userID, ErrCredentialNotFound when there is none
+	UpdatePassword(ctx context.Context, userID []byte, passwordHash string) error
 }
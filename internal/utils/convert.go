@@ -3,7 +3,10 @@ package utils
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 )
 
 type DaySchema struct {
@@ -13,13 +16,23 @@ type DaySchema struct {
 }
 
 var weekday = map[string]int{
-	"Sun":  int(time.Sunday),
-	"Mon":  int(time.Monday),
-	"Tue":  int(time.Tuesday),
-	"Wed":  int(time.Wednesday),
-	"Thur": int(time.Thursday),
-	"Fri":  int(time.Friday),
-	"Sat":  int(time.Saturday),
+	"sun":       int(time.Sunday),
+	"sunday":    int(time.Sunday),
+	"mon":       int(time.Monday),
+	"monday":    int(time.Monday),
+	"tue":       int(time.Tuesday),
+	"tues":      int(time.Tuesday),
+	"tuesday":   int(time.Tuesday),
+	"wed":       int(time.Wednesday),
+	"wednesday": int(time.Wednesday),
+	"thu":       int(time.Thursday),
+	"thur":      int(time.Thursday),
+	"thurs":     int(time.Thursday),
+	"thursday":  int(time.Thursday),
+	"fri":       int(time.Friday),
+	"friday":    int(time.Friday),
+	"sat":       int(time.Saturday),
+	"saturday":  int(time.Saturday),
 }
 
 var weekdayStr = map[int]string{
@@ -32,90 +45,327 @@ var weekdayStr = map[int]string{
 	int(time.Saturday):  "Sat",
 }
 
-func ParseTimeFormat(input string) ([]DaySchema, error) {
+// ParseError reports the position and token where an opening hours string could not be parsed.
+type ParseError struct {
+	Input string
+	Pos   int
+	Token string
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("parse opening hours %q: %s at position %d", e.Input, e.Msg, e.Pos)
+	}
+	return fmt.Sprintf("parse opening hours %q: %s %q at position %d", e.Input, e.Msg, e.Token, e.Pos)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenComma
+	tokenSlash
+	tokenDash
+	tokenUnknown
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func tokenize(input string) []token {
 	runes := []rune(input)
-	runes = append(runes, ' ')
-	var (
-		result  []DaySchema
-		chars   []rune
-		days    []int
-		times   [][]rune
-		isTime  bool
-		isRange bool
-	)
-	for _, char := range runes {
-		switch char {
-		case ' ':
-			if isTime {
-				if len(chars) > 0 {
-					times = append(times, chars)
-				}
-				if len(times) == 2 && isRange {
-					openHour, err := time.Parse("15:04", string(times[0]))
-					if err != nil {
-						return nil, err
-					}
-					openH, err := time.ParseDuration(fmt.Sprintf("%dh%dm", openHour.Hour(), openHour.Minute()))
-					if err != nil {
-						return nil, err
-					}
-					closeHour, err := time.Parse("15:04", string(times[1]))
-					if err != nil {
-						return nil, err
-					}
-					closeH, err := time.ParseDuration(fmt.Sprintf("%dh%dm", closeHour.Hour(), closeHour.Minute()))
-					if err != nil {
-						return nil, err
-					}
-					closeHDetermine := closeH.Hours()
-					if closeH.Hours() < openH.Hours() {
-						closeHDetermine = closeH.Hours() + 24
-					}
-					for _, d := range days {
-						result = append(result, DaySchema{
-							Day:       int64(d),
-							OpenHour:  math.Round(openH.Hours()*100) / 100,
-							CloseHour: math.Round(closeHDetermine*100) / 100,
-						})
-					}
-					isTime = false
-					isRange = false
-					days = []int{}
-					times = [][]rune{}
-				}
-				chars = []rune{}
-				continue
-			} else {
-				if len(chars) > 0 {
-					days = append(days, weekday[string(chars)])
-				}
-				if len(days) == 2 && isRange {
-					start := days[0]
-					end := days[1]
-					days = []int{}
-					for i := start; i <= end; i++ {
-						days = append(days, i)
-					}
-				}
-				chars = []rune{}
-				continue
+	var tokens []token
+	for i := 0; i < len(runes); {
+		char := runes[i]
+		switch {
+		case unicode.IsSpace(char):
+			i++
+		case char == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ",", pos: i})
+			i++
+		case char == '/':
+			tokens = append(tokens, token{kind: tokenSlash, value: "/", pos: i})
+			i++
+		case char == '-':
+			tokens = append(tokens, token{kind: tokenDash, value: "-", pos: i})
+			i++
+		case isNumberChar(char):
+			start := i
+			for i < len(runes) && (isNumberChar(runes[i]) || runes[i] == ':') {
+				i++
 			}
-		case ',':
-		case '/':
-			isTime = false
-			isRange = false
-		case '-':
-			isRange = true
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(char):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i]), pos: start})
 		default:
-			if isNumberChar(char) {
-				isTime = true
+			tokens = append(tokens, token{kind: tokenUnknown, value: string(char), pos: i})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)})
+}
+
+type timeParser struct {
+	input  string
+	tokens []token
+	index  int
+}
+
+func (p *timeParser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *timeParser) next() token {
+	tk := p.tokens[p.index]
+	if tk.kind != tokenEOF {
+		p.index++
+	}
+	return tk
+}
+
+func (p *timeParser) errorf(tk token, format string, args ...interface{}) error {
+	return &ParseError{
+		Input: p.input,
+		Pos:   tk.pos,
+		Token: tk.value,
+		Msg:   fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *timeParser) parseDay() (int, error) {
+	tk := p.next()
+	if tk.kind != tokenWord {
+		return 0, p.errorf(tk, "expected day name")
+	}
+	day, ok := weekday[strings.ToLower(strings.TrimSuffix(tk.value, "."))]
+	if !ok {
+		return 0, p.errorf(tk, "unknown day")
+	}
+	return day, nil
+}
+
+// parseDays reads a comma separated list of days or day ranges, a range wrapping past Saturday continues from Sunday.
+func (p *timeParser) parseDays() ([]int, error) {
+	var days []int
+	for {
+		start, err := p.parseDay()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind == tokenDash {
+			p.next()
+			end, err := p.parseDay()
+			if err != nil {
+				return nil, err
+			}
+			for d := start; ; d = (d + 1) % 7 {
+				days = append(days, d)
+				if d == end {
+					break
+				}
 			}
-			chars = append(chars, char)
+		} else {
+			days = append(days, start)
+		}
+		if p.peek().kind != tokenComma {
+			return days, nil
+		}
+		p.next()
+	}
+}
+
+// parseTime reads "15:04", "3:04 PM", "3PM" or "3 pm" and returns the minutes since midnight, "24:00" being the
+// midnight ending the day.
+func (p *timeParser) parseTime() (int, error) {
+	tk := p.next()
+	if tk.kind != tokenNumber {
+		return 0, p.errorf(tk, "expected time")
+	}
+	meridiem := ""
+	if next := p.peek(); next.kind == tokenWord {
+		switch strings.ToLower(strings.ReplaceAll(next.value, ".", "")) {
+		case "am":
+			meridiem = "am"
+			p.next()
+		case "pm":
+			meridiem = "pm"
+			p.next()
+		}
+	}
+
+	hourStr, minuteStr, hasMinute := strings.Cut(tk.value, ":")
+	if !hasMinute && meridiem == "" {
+		return 0, p.errorf(tk, "invalid time")
+	}
+	if hasMinute && len(minuteStr) != 2 {
+		return 0, p.errorf(tk, "invalid time")
+	}
+	if !hasMinute {
+		minuteStr = "0"
+	}
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || len(hourStr) > 2 {
+		return 0, p.errorf(tk, "invalid time")
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || minute > 59 {
+		return 0, p.errorf(tk, "invalid time")
+	}
+	switch meridiem {
+	case "":
+		if hour > 24 || hour == 24 && minute > 0 {
+			return 0, p.errorf(tk, "invalid time")
 		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, p.errorf(tk, "invalid 12-hour time")
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	return hour*60 + minute, nil
+}
+
+func (p *timeParser) parseSegment() ([]DaySchema, error) {
+	days, err := p.parseDays()
+	if err != nil {
+		return nil, err
+	}
+	openToken := p.peek()
+	openMinute, err := p.parseTime()
+	if err != nil {
+		return nil, err
+	}
+	if openMinute == 24*60 {
+		return nil, p.errorf(openToken, "invalid opening time")
+	}
+	if tk := p.next(); tk.kind != tokenDash {
+		return nil, p.errorf(tk, "expected \"-\" between opening and closing time")
+	}
+	closeMinute, err := p.parseTime()
+	if err != nil {
+		return nil, err
+	}
+	if closeMinute <= openMinute {
+		closeMinute += 24 * 60
+	}
+	result := make([]DaySchema, 0, len(days))
+	for _, d := range days {
+		result = append(result, DaySchema{
+			Day:       int64(d),
+			OpenHour:  minuteToHour(openMinute),
+			CloseHour: minuteToHour(closeMinute),
+		})
 	}
 	return result, nil
 }
 
+// ParseTimeFormat parses opening hours such as "Mon - Fri 08:00 - 17:00 / Sat, Sun 08:00 - 12:00".
+// A closing time earlier than the opening time is treated as the next day, so CloseHour exceeds 24, and a closing
+// time equal to it as a full day.
+// Errors are returned as *ParseError.
+func ParseTimeFormat(input string) ([]DaySchema, error) {
+	p := &timeParser{
+		input:  input,
+		tokens: tokenize(input),
+	}
+	for _, tk := range p.tokens {
+		if tk.kind == tokenUnknown {
+			return nil, p.errorf(tk, "unexpected character")
+		}
+	}
+	var result []DaySchema
+	if p.peek().kind == tokenEOF {
+		return result, nil
+	}
+	for {
+		segment, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, segment...)
+		tk := p.next()
+		switch tk.kind {
+		case tokenEOF:
+			return result, nil
+		case tokenSlash:
+		default:
+			return nil, p.errorf(tk, "expected \"/\" or end of input")
+		}
+	}
+}
+
+// FormatTimeFormat renders schemas back to the canonical ParseTimeFormat syntax.
+// Adjacent schemas sharing the same hours form one segment, and runs of three or more
+// consecutive days are written as a range, so ParseTimeFormat(FormatTimeFormat(x)) == x.
+func FormatTimeFormat(schemas []DaySchema) string {
+	var segments []string
+	for start := 0; start < len(schemas); {
+		end := start + 1
+		for end < len(schemas) &&
+			schemas[end].OpenHour == schemas[start].OpenHour &&
+			schemas[end].CloseHour == schemas[start].CloseHour {
+			end++
+		}
+		segments = append(segments, fmt.Sprintf("%s %s - %s",
+			formatDays(schemas[start:end]),
+			formatHour(schemas[start].OpenHour),
+			formatCloseHour(schemas[start].CloseHour),
+		))
+		start = end
+	}
+	return strings.Join(segments, " / ")
+}
+
+func formatDays(schemas []DaySchema) string {
+	var items []string
+	for start := 0; start < len(schemas); {
+		end := start + 1
+		for end < len(schemas) && end-start < 7 && schemas[end].Day == (schemas[end-1].Day+1)%7 {
+			end++
+		}
+		if end-start >= 3 {
+			items = append(items, fmt.Sprintf("%s - %s", weekdayStr[int(schemas[start].Day)], weekdayStr[int(schemas[end-1].Day)]))
+		} else {
+			for _, item := range schemas[start:end] {
+				items = append(items, weekdayStr[int(item.Day)])
+			}
+		}
+		start = end
+	}
+	return strings.Join(items, ", ")
+}
+
+func formatHour(hour float64) string {
+	minutes := int(math.Round(hour*60)) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// formatCloseHour is formatHour writing the midnight ending the day as "24:00" rather than "00:00", the opening time of
+// a full day.
+func formatCloseHour(hour float64) string {
+	if math.Round(hour*60) == 24*60 {
+		return "24:00"
+	}
+	return formatHour(hour)
+}
+
+func minuteToHour(minute int) float64 {
+	return math.Round(float64(minute)/60*100) / 100
+}
+
 func isNumberChar(s rune) bool {
 	return '0' <= s && s <= '9'
 }
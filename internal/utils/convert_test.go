@@ -2,6 +2,7 @@ package utils
 
 import (
 	"github.com/stretchr/testify/suite"
+	"math/rand"
 	"testing"
 )
 
@@ -183,11 +184,227 @@ func (suite *ParseSuite) TestParseTimeFormat() {
 				},
 			},
 		},
+		{
+			Label: "Parse wraparound day range",
+			Input: "Fri - Sun 20:00 - 02:00",
+			Want: want{
+				Result: []DaySchema{
+					{
+						Day:       5,
+						OpenHour:  20,
+						CloseHour: 26,
+					},
+					{
+						Day:       6,
+						OpenHour:  20,
+						CloseHour: 26,
+					},
+					{
+						Day:       0,
+						OpenHour:  20,
+						CloseHour: 26,
+					},
+				},
+			},
+		},
+		{
+			Label: "Parse full day names and Thu variant",
+			Input: "Monday, Thu, thursday 09:30 - 18:45",
+			Want: want{
+				Result: []DaySchema{
+					{
+						Day:       1,
+						OpenHour:  9.5,
+						CloseHour: 18.75,
+					},
+					{
+						Day:       4,
+						OpenHour:  9.5,
+						CloseHour: 18.75,
+					},
+					{
+						Day:       4,
+						OpenHour:  9.5,
+						CloseHour: 18.75,
+					},
+				},
+			},
+		},
+		{
+			Label: "Parse 12-hour format",
+			Input: "Tue - Wed, Sat 8:00 AM - 5pm / Sun 12 am - 12:30 PM",
+			Want: want{
+				Result: []DaySchema{
+					{
+						Day:       2,
+						OpenHour:  8,
+						CloseHour: 17,
+					},
+					{
+						Day:       3,
+						OpenHour:  8,
+						CloseHour: 17,
+					},
+					{
+						Day:       6,
+						OpenHour:  8,
+						CloseHour: 17,
+					},
+					{
+						Day:       0,
+						OpenHour:  0,
+						CloseHour: 12.5,
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		result, err := ParseTimeFormat(tc.Input)
+		suite.NoError(err, tc.Label)
+		suite.Equal(tc.Want.Result, result, tc.Label)
+	}
+}
+
+func (suite *ParseSuite) TestParseTimeFormatError() {
+	testCases := []struct {
+		Label string
+		Input string
+		Pos   int
+		Token string
+	}{
+		{
+			Label: "Unknown day",
+			Input: "Mon, Fry 08:00 - 12:00",
+			Pos:   5,
+			Token: "Fry",
+		},
+		{
+			Label: "Unknown day in second segment",
+			Input: "Mon 08:00 - 12:00 / Xyz 08:00 - 12:00",
+			Pos:   20,
+			Token: "Xyz",
+		},
+		{
+			Label: "Invalid hour",
+			Input: "Mon 25:00 - 12:00",
+			Pos:   4,
+			Token: "25:00",
+		},
+		{
+			Label: "Opening at the end of the day",
+			Input: "Mon 24:00 - 12:00",
+			Pos:   4,
+			Token: "24:00",
+		},
+		{
+			Label: "Past the end of the day",
+			Input: "Mon 08:00 - 24:30",
+			Pos:   12,
+			Token: "24:30",
+		},
+		{
+			Label: "Invalid 12-hour time",
+			Input: "Mon 13:00 pm - 12:00",
+			Pos:   4,
+			Token: "13:00",
+		},
+		{
+			Label: "Missing closing time",
+			Input: "Mon 08:00 -",
+			Pos:   11,
+		},
+		{
+			Label: "Unexpected character",
+			Input: "Mon 08:00 - 12:00 ; Tue",
+			Pos:   18,
+			Token: ";",
+		},
 	}
 	for _, tc := range testCases {
 		result, err := ParseTimeFormat(tc.Input)
-		suite.NoError(err)
-		suite.Equal(tc.Want.Result, result)
+		suite.Nil(result, tc.Label)
+		var parseErr *ParseError
+		if suite.ErrorAs(err, &parseErr, tc.Label) {
+			suite.Equal(tc.Pos, parseErr.Pos, tc.Label)
+			suite.Equal(tc.Token, parseErr.Token, tc.Label)
+		}
+	}
+}
+
+func (suite *ParseSuite) TestFormatTimeFormat() {
+	testCases := []struct {
+		Label string
+		Input string
+		Want  string
+	}{
+		{
+			Label: "Format list and range",
+			Input: "Mon - Fri 08:00 - 17:00 / Sat, Sun 08:00 - 12:00",
+			Want:  "Mon - Fri 08:00 - 17:00 / Sat, Sun 08:00 - 12:00",
+		},
+		{
+			Label: "Format overnight hours",
+			Input: "Mon - Wed 08:00 - 17:00 / Thur, Sat 20:00 - 02:00",
+			Want:  "Mon - Wed 08:00 - 17:00 / Thur, Sat 20:00 - 02:00",
+		},
+		{
+			Label: "Format wraparound range",
+			Input: "Friday - Sunday 8pm - 2am",
+			Want:  "Fri - Sun 20:00 - 02:00",
+		},
+		{
+			Label: "Format full days",
+			Input: "Mon 00:00 - 24:00 / Tue 00:00 - 00:00 / Wed 08:00 - 08:00",
+			Want:  "Mon, Tue 00:00 - 24:00 / Wed 08:00 - 08:00",
+		},
+		{
+			Label: "Format consecutive list as range",
+			Input: "Mon, Tue, Wed, Fri 09:30 - 18:00",
+			Want:  "Mon - Wed, Fri 09:30 - 18:00",
+		},
+	}
+	for _, tc := range testCases {
+		schemas, err := ParseTimeFormat(tc.Input)
+		suite.NoError(err, tc.Label)
+		suite.Equal(tc.Want, FormatTimeFormat(schemas), tc.Label)
+	}
+}
+
+func (suite *ParseSuite) TestFormatTimeFormatRoundTrip() {
+	r := rand.New(rand.NewSource(20221006))
+	for i := 0; i < 1000; i++ {
+		var schemas []DaySchema
+		for segment := r.Intn(4) + 1; segment > 0; segment-- {
+			openMinute := r.Intn(24 * 60)
+			closeMinute := r.Intn(24 * 60)
+			if closeMinute <= openMinute {
+				closeMinute += 24 * 60
+			}
+			for day := r.Intn(9) + 1; day > 0; day-- {
+				schemas = append(schemas, DaySchema{
+					Day:       int64(r.Intn(7)),
+					OpenHour:  minuteToHour(openMinute),
+					CloseHour: minuteToHour(closeMinute),
+				})
+			}
+		}
+		formatted := FormatTimeFormat(schemas)
+		result, err := ParseTimeFormat(formatted)
+		suite.NoError(err, formatted)
+		suite.Equal(schemas, result, formatted)
+	}
+
+	// a full day, from midnight to midnight or from any time to the same time the next day
+	for _, schemas := range [][]DaySchema{
+		{{Day: 1, OpenHour: 0, CloseHour: 24}},
+		{{Day: 2, OpenHour: 8, CloseHour: 32}},
+		{{Day: 3, OpenHour: 8, CloseHour: 24}, {Day: 5, OpenHour: 0, CloseHour: 24}},
+	} {
+		formatted := FormatTimeFormat(schemas)
+		result, err := ParseTimeFormat(formatted)
+		suite.NoError(err, formatted)
+		suite.Equal(schemas, result, formatted)
 	}
 }
 
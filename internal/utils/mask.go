@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type MaskAttribute struct {
+	Brand    string
+	Color    string
+	PackSize int64
+}
+
+// MaskNamespace is the UUIDv5 namespace of canonical mask IDs.
+var MaskNamespace = uuid.MustParse("5b0f4a4e-6c4e-4f53-9a52-3d0b8c1e7a21")
+
+var (
+	maskGroupPattern    = regexp.MustCompile(`\(([^()]*)\)`)
+	maskPackSizePattern = regexp.MustCompile(`(?i)^(\d+)\s*(?:per\s*pack|pcs|pieces|pack)$`)
+)
+
+// ParseMaskName splits a product name such as "True Barrier (green) (3 per pack)" into its brand,
+// color and units per pack. Parenthesised groups holding a pack size set PackSize, the first other group
+// is the color, and the text before the first group is the brand. Missing parts are left zero.
+func ParseMaskName(name string) MaskAttribute {
+	attr := MaskAttribute{}
+	brandEnd := len(name)
+	for _, loc := range maskGroupPattern.FindAllStringSubmatchIndex(name, -1) {
+		if loc[0] < brandEnd {
+			brandEnd = loc[0]
+		}
+		group := strings.TrimSpace(name[loc[2]:loc[3]])
+		if match := maskPackSizePattern.FindStringSubmatch(group); match != nil {
+			if size, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+				attr.PackSize = size
+			}
+			continue
+		}
+		if attr.Color == "" {
+			attr.Color = strings.ToLower(group)
+		}
+	}
+	attr.Brand = strings.Join(strings.Fields(name[:brandEnd]), " ")
+	return attr
+}
+
+// NormalizeProductName lowercases the name and collapses its whitespace, including the spaces around parentheses,
+// so "MaskT (green) (10 per pack)" and "maskt(Green)  (10 per pack)" name the same product.
+func NormalizeProductName(name string) string {
+	name = strings.NewReplacer("(", " (", ")", ") ").Replace(strings.ToLower(name))
+	name = strings.Join(strings.Fields(name), " ")
+	return strings.NewReplacer("( ", "(", " )", ")").Replace(name)
+}
+
+// CanonicalMaskID is the UUIDv5 of the normalized product name, identical for every listing of the same mask.
+func CanonicalMaskID(name string) []byte {
+	uid := uuid.NewSHA1(MaskNamespace, []byte(NormalizeProductName(name)))
+	return uid[:]
+}
+
+// PricePerMask divides the pack price by its units, rounded to cents. It is zero when the pack size is unknown.
+func PricePerMask(price float64, packSize int64) float64 {
+	if packSize <= 0 {
+		return 0
+	}
+	return math.Round(price/float64(packSize)*100) / 100
+}
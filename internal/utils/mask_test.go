@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type MaskSuite struct {
+	suite.Suite
+}
+
+func (suite *MaskSuite) TestParseMaskName() {
+	testCases := []struct {
+		Label string
+		Input string
+		Want  MaskAttribute
+	}{
+		{
+			Label: "Brand color and pack size",
+			Input: "True Barrier (green) (3 per pack)",
+			Want:  MaskAttribute{Brand: "True Barrier", Color: "green", PackSize: 3},
+		},
+		{
+			Label: "Extra spaces and capitalised color",
+			Input: "  Second   Smile ( Black )(10 Per Pack) ",
+			Want:  MaskAttribute{Brand: "Second Smile", Color: "black", PackSize: 10},
+		},
+		{
+			Label: "Pack size only",
+			Input: "MaskT (6 per pack)",
+			Want:  MaskAttribute{Brand: "MaskT", PackSize: 6},
+		},
+		{
+			Label: "No groups",
+			Input: "Cotton Kiss",
+			Want:  MaskAttribute{Brand: "Cotton Kiss"},
+		},
+	}
+	for _, tc := range testCases {
+		suite.Equal(tc.Want, ParseMaskName(tc.Input), tc.Label)
+	}
+}
+
+func (suite *MaskSuite) TestNormalizeProductName() {
+	suite.Equal("maskt (green) (10 per pack)", NormalizeProductName("MaskT (green) (10 per pack)"))
+	suite.Equal("maskt (green) (10 per pack)", NormalizeProductName("  maskt(Green)  ( 10 per  pack )"))
+	suite.Equal("cotton kiss", NormalizeProductName("Cotton   Kiss"))
+}
+
+func (suite *MaskSuite) TestCanonicalMaskID() {
+	id := CanonicalMaskID("MaskT (green) (10 per pack)")
+	suite.Len(id, 16)
+	suite.Equal(id, CanonicalMaskID("maskt(Green)  (10 per pack)"))
+	suite.NotEqual(id, CanonicalMaskID("MaskT (black) (10 per pack)"))
+}
+
+func (suite *MaskSuite) TestPricePerMask() {
+	suite.Equal(4.57, PricePerMask(13.7, 3))
+	suite.Equal(3.2, PricePerMask(31.98, 10))
+	suite.Equal(float64(0), PricePerMask(10, 0))
+}
+
+func TestMaskSuite(t *testing.T) {
+	suite.Run(t, new(MaskSuite))
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	RelevanceExact     float64 = 100
+	RelevancePrefix    float64 = 80
+	RelevanceWord      float64 = 60
+	RelevanceSubstring float64 = 40
+	RelevanceFuzzy     float64 = 20
+)
+
+// Relevance scores how well target matches query, case-insensitively.
+// An exact match scores highest, followed by prefix, whole word and substring matches;
+// words within a small edit distance of every query word score at most RelevanceFuzzy.
+// Zero means no match.
+func Relevance(query, target string) float64 {
+	query = strings.ToLower(strings.TrimSpace(query))
+	target = strings.ToLower(strings.TrimSpace(target))
+	if query == "" || target == "" {
+		return 0
+	}
+	switch {
+	case query == target:
+		return RelevanceExact
+	case strings.HasPrefix(target, query):
+		return RelevancePrefix
+	}
+
+	queryWords := SplitWords(query)
+	targetWords := SplitWords(target)
+	if len(queryWords) > 0 && containsAllWords(targetWords, queryWords) {
+		return RelevanceWord
+	}
+	if strings.Contains(target, query) {
+		return RelevanceSubstring
+	}
+	return fuzzyRelevance(queryWords, targetWords)
+}
+
+// SplitWords lower-cases input and splits it on every non letter or digit character.
+func SplitWords(input string) []string {
+	return strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsAllWords(targetWords, queryWords []string) bool {
+	for _, q := range queryWords {
+		found := false
+		for _, t := range targetWords {
+			if q == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyRelevance requires every query word to be within MaxEditDistance of some target word,
+// and scales RelevanceFuzzy down by the accumulated distance.
+func fuzzyRelevance(queryWords, targetWords []string) float64 {
+	if len(queryWords) == 0 {
+		return 0
+	}
+	var distance, allowed int
+	for _, q := range queryWords {
+		limit := MaxEditDistance(q)
+		best := limit + 1
+		for _, t := range targetWords {
+			if d := EditDistance(q, t); d < best {
+				best = d
+			}
+		}
+		if best > limit {
+			return 0
+		}
+		distance += best
+		allowed += limit
+	}
+	return RelevanceFuzzy * float64(allowed+1-distance) / float64(allowed+1)
+}
+
+// MaxEditDistance is the typo tolerance for a word: none for one or two letters,
+// one up to five letters and two beyond.
+func MaxEditDistance(word string) int {
+	switch n := len([]rune(word)); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EditDistance returns the Damerau-Levenshtein (optimal string alignment) distance between a and b,
+// so a transposition such as "maks" for "mask" costs one edit.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
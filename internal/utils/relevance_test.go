@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RelevanceSuite struct {
+	suite.Suite
+}
+
+func (suite *RelevanceSuite) TestRelevance() {
+	testCases := []struct {
+		Label  string
+		Query  string
+		Target string
+		Want   float64
+	}{
+		{
+			Label:  "Exact match ignores case",
+			Query:  "medlife",
+			Target: "Medlife",
+			Want:   RelevanceExact,
+		},
+		{
+			Label:  "Prefix match",
+			Query:  "true",
+			Target: "True Barrier (green) (3 per pack)",
+			Want:   RelevancePrefix,
+		},
+		{
+			Label:  "Word match",
+			Query:  "green barrier",
+			Target: "True Barrier (green) (3 per pack)",
+			Want:   RelevanceWord,
+		},
+		{
+			Label:  "Substring match",
+			Query:  "arrie",
+			Target: "True Barrier (green) (3 per pack)",
+			Want:   RelevanceSubstring,
+		},
+		{
+			Label:  "Fuzzy match with one transposition",
+			Query:  "maks",
+			Target: "Cotton Mask (black) (10 per pack)",
+			Want:   RelevanceFuzzy * 1 / 2,
+		},
+		{
+			Label:  "No match",
+			Query:  "pharmacy",
+			Target: "True Barrier (green) (3 per pack)",
+			Want:   0,
+		},
+		{
+			Label:  "Empty query",
+			Query:  "",
+			Target: "Medlife",
+			Want:   0,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Equal(tc.Want, Relevance(tc.Query, tc.Target), tc.Label)
+	}
+}
+
+func (suite *RelevanceSuite) TestRelevanceOrder() {
+	target := "Second Smile (black) (10 per pack)"
+	exact := Relevance("second smile (black) (10 per pack)", target)
+	prefix := Relevance("second", target)
+	word := Relevance("smile", target)
+	substring := Relevance("mile", target)
+	fuzzy := Relevance("seconds smile", target)
+	suite.Greater(exact, prefix)
+	suite.Greater(prefix, word)
+	suite.Greater(word, substring)
+	suite.Greater(substring, fuzzy)
+	suite.Greater(fuzzy, float64(0))
+}
+
+func (suite *RelevanceSuite) TestEditDistance() {
+	testCases := []struct {
+		A    string
+		B    string
+		Want int
+	}{
+		{A: "mask", B: "mask", Want: 0},
+		{A: "maks", B: "mask", Want: 1},
+		{A: "seconds", B: "second", Want: 1},
+		{A: "kitten", B: "sitting", Want: 3},
+		{A: "", B: "abc", Want: 3},
+	}
+	for _, tc := range testCases {
+		suite.Equal(tc.Want, EditDistance(tc.A, tc.B), tc.A+"/"+tc.B)
+	}
+}
+
+func TestRelevanceSuite(t *testing.T) {
+	suite.Run(t, new(RelevanceSuite))
+}